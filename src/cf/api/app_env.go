@@ -0,0 +1,52 @@
+package api
+
+import (
+	"cf"
+	"cf/configuration"
+	"cf/net"
+	"fmt"
+)
+
+type AppEnvResource struct {
+	EnvironmentJson    map[string]interface{} `json:"environment_json"`
+	SystemEnvJson      map[string]interface{} `json:"system_env_json"`
+	ApplicationEnvJson map[string]interface{} `json:"application_env_json"`
+	RunningEnvJson     map[string]interface{} `json:"running_env_json"`
+	StagingEnvJson     map[string]interface{} `json:"staging_env_json"`
+}
+
+func (resource AppEnvResource) ToModel() (env cf.AppEnvironment) {
+	env.UserProvided = resource.EnvironmentJson
+	env.SystemProvided = resource.SystemEnvJson
+	env.ApplicationProvided = resource.ApplicationEnvJson
+	env.Running = resource.RunningEnvJson
+	env.Staging = resource.StagingEnvJson
+	return
+}
+
+type AppEnvRepository interface {
+	GetEnv(appGuid string) (env cf.AppEnvironment, apiResponse net.ApiResponse)
+}
+
+type CloudControllerAppEnvRepository struct {
+	config  *configuration.Configuration
+	gateway net.Gateway
+}
+
+func NewCloudControllerAppEnvRepository(config *configuration.Configuration, gateway net.Gateway) (repo CloudControllerAppEnvRepository) {
+	repo.config = config
+	repo.gateway = gateway
+	return
+}
+
+func (repo CloudControllerAppEnvRepository) GetEnv(appGuid string) (env cf.AppEnvironment, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/apps/%s/env", repo.config.Target, appGuid)
+	resource := new(AppEnvResource)
+	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resource)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	env = resource.ToModel()
+	return
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"cf/configuration"
+	"cf/net"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	testnet "testhelpers/net"
+	"testing"
+)
+
+func TestGetAppEnv(t *testing.T) {
+	ts, handler := testnet.NewTLSServer(t, []testnet.TestRequest{
+		testnet.TestRequest{
+			Method: "GET",
+			Path:   "/v2/apps/my-app-guid/env",
+			Response: testnet.TestResponse{
+				Status: http.StatusOK,
+				Body: `
+{
+  "staging_env_json": { "STAGING_KEY": "staging-value" },
+  "running_env_json": { "RUNNING_KEY": "running-value" },
+  "environment_json": { "my-key": "my-value" },
+  "system_env_json": {
+    "VCAP_SERVICES": {
+      "p-mysql": [ { "name": "my-db", "credentials": { "password": "secret" } } ]
+    }
+  },
+  "application_env_json": {
+    "VCAP_APPLICATION": { "application_name": "my-app", "instance_index": 0 }
+  }
+}`,
+			},
+		},
+	})
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		Target:      ts.URL,
+		AccessToken: "BEARER my_access_token",
+	}
+	repo := NewCloudControllerAppEnvRepository(config, net.NewCloudControllerGateway())
+
+	env, apiResponse := repo.GetEnv("my-app-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+
+	assert.Equal(t, env.UserProvided["my-key"], "my-value")
+	assert.Equal(t, env.Running["RUNNING_KEY"], "running-value")
+	assert.Equal(t, env.Staging["STAGING_KEY"], "staging-value")
+
+	vcapApplication := env.ApplicationProvided["VCAP_APPLICATION"].(map[string]interface{})
+	assert.Equal(t, vcapApplication["application_name"], "my-app")
+
+	services := env.SystemProvided["VCAP_SERVICES"].(map[string]interface{})
+	mysql := services["p-mysql"].([]interface{})
+	assert.Equal(t, mysql[0].(map[string]interface{})["name"], "my-db")
+}
+
+func TestGetAppEnvWhenNotFound(t *testing.T) {
+	ts, handler := testnet.NewTLSServer(t, []testnet.TestRequest{
+		testnet.TestRequest{
+			Method:   "GET",
+			Path:     "/v2/apps/my-app-guid/env",
+			Response: testnet.TestResponse{Status: http.StatusNotFound},
+		},
+	})
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		Target:      ts.URL,
+		AccessToken: "BEARER my_access_token",
+	}
+	repo := NewCloudControllerAppEnvRepository(config, net.NewCloudControllerGateway())
+
+	_, apiResponse := repo.GetEnv("my-app-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.Equal(t, apiResponse.StatusCode, http.StatusNotFound)
+}
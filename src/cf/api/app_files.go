@@ -4,10 +4,17 @@ import (
 	"cf/configuration"
 	"cf/net"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
 )
 
 type AppFilesRepository interface {
-	ListFiles(appGuid, path string) (files string, apiResponse net.ApiResponse)
+	ListFiles(appGuid string, instance int, path string) (files string, apiResponse net.ApiResponse)
+	DownloadFile(appGuid string, instance int, path string, destination io.Writer) (apiResponse net.ApiResponse)
+	DownloadFileFrom(appGuid string, instance int, path string, offset int64, destination io.Writer) (newOffset int64, apiResponse net.ApiResponse)
 }
 
 type CloudControllerAppFilesRepository struct {
@@ -21,9 +28,8 @@ func NewCloudControllerAppFilesRepository(config *configuration.Configuration, g
 	return
 }
 
-func (repo CloudControllerAppFilesRepository) ListFiles(appGuid, path string) (files string, apiResponse net.ApiResponse) {
-	url := fmt.Sprintf("%s/v2/apps/%s/instances/0/files/%s", repo.config.Target, appGuid, path)
-	request, apiResponse := repo.gateway.NewRequest("GET", url, repo.config.AccessToken, nil)
+func (repo CloudControllerAppFilesRepository) ListFiles(appGuid string, instance int, path string) (files string, apiResponse net.ApiResponse) {
+	request, apiResponse := repo.gateway.NewRequest("GET", repo.filesUrl(appGuid, instance, path), repo.config.AccessToken, nil)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -31,3 +37,82 @@ func (repo CloudControllerAppFilesRepository) ListFiles(appGuid, path string) (f
 	files, _, apiResponse = repo.gateway.PerformRequestForTextResponse(request)
 	return
 }
+
+func (repo CloudControllerAppFilesRepository) DownloadFile(appGuid string, instance int, path string, destination io.Writer) (apiResponse net.ApiResponse) {
+	_, apiResponse = repo.DownloadFileFrom(appGuid, instance, path, 0, destination)
+	return
+}
+
+// DownloadFileFrom copies the contents of the file from offset onwards into
+// destination using an HTTP Range request. When the file has not grown past
+// offset the request succeeds without writing anything, so callers can poll
+// it to follow a growing file. When it has shrunk below offset, as happens
+// when a log is truncated or rotated, newOffset goes back to 0 so the next
+// poll starts again from the beginning.
+func (repo CloudControllerAppFilesRepository) DownloadFileFrom(appGuid string, instance int, path string, offset int64, destination io.Writer) (newOffset int64, apiResponse net.ApiResponse) {
+	newOffset = offset
+
+	request, apiResponse := repo.gateway.NewRequest("GET", repo.filesUrl(appGuid, instance, path), repo.config.AccessToken, nil)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	if offset > 0 {
+		request.HttpReq.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	rawResponse, apiResponse := repo.gateway.PerformRequestForResponse(request)
+	if apiResponse.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		apiResponse = net.NewSuccessfulApiResponse()
+		if size, found := contentRangeSize(rawResponse); found && size < offset {
+			newOffset = 0
+		}
+		return
+	}
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+	defer rawResponse.Body.Close()
+
+	if offset > 0 && rawResponse.StatusCode != http.StatusPartialContent {
+		// the server ignored the Range header and sent the whole file
+		skipped, err := io.CopyN(ioutil.Discard, rawResponse.Body, offset)
+		if err != nil && err != io.EOF {
+			apiResponse = net.NewApiResponseWithError("Error reading file", err)
+			return
+		}
+		if skipped < offset {
+			newOffset = 0
+			return
+		}
+	}
+
+	written, err := io.Copy(destination, rawResponse.Body)
+	newOffset += written
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error downloading file", err)
+	}
+	return
+}
+
+// contentRangeSize reads the full size of the file from the Content-Range
+// header of a response, e.g. "bytes */1234".
+func contentRangeSize(rawResponse *http.Response) (size int64, found bool) {
+	if rawResponse == nil {
+		return
+	}
+
+	contentRange := rawResponse.Header.Get("Content-Range")
+	slash := strings.LastIndex(contentRange, "/")
+	if slash == -1 {
+		return
+	}
+
+	size, err := strconv.ParseInt(contentRange[slash+1:], 10, 64)
+	found = err == nil
+	return
+}
+
+func (repo CloudControllerAppFilesRepository) filesUrl(appGuid string, instance int, path string) string {
+	return fmt.Sprintf("%s/v2/apps/%s/instances/%d/files/%s", repo.config.Target, appGuid, instance, path)
+}
@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	. "cf/api"
 	"cf/configuration"
 	"cf/net"
@@ -11,6 +12,7 @@ import (
 	testapi "testhelpers/api"
 	testnet "testhelpers/net"
 	"testing"
+	"time"
 )
 
 func TestListFiles(t *testing.T) {
@@ -56,9 +58,130 @@ func TestListFiles(t *testing.T) {
 
 	gateway := net.NewCloudControllerGateway()
 	repo := NewCloudControllerAppFilesRepository(config, gateway)
-	list, err := repo.ListFiles("my-app-guid", "some/path")
+	list, err := repo.ListFiles("my-app-guid", 0, "some/path")
 
 	assert.True(t, handler.AllRequestsCalled())
 	assert.False(t, err.IsNotSuccessful())
 	assert.Equal(t, list, expectedResponse)
 }
+
+func TestListFilesForAnInstance(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "GET",
+		Path:     "/v2/apps/my-app-guid/instances/2/files/some/path",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: "file 1"},
+	})
+
+	ts, handler := testnet.NewTLSServer(t, []testnet.TestRequest{req})
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		Target:      ts.URL,
+		AccessToken: "BEARER my_access_token",
+	}
+
+	repo := NewCloudControllerAppFilesRepository(config, net.NewCloudControllerGateway())
+	list, apiResponse := repo.ListFiles("my-app-guid", 2, "some/path")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Contains(t, list, "file 1")
+}
+
+var binaryFileContents = []byte{'l', 'o', 'g', 0x00, 0xff, 0x0a, 0x89, 'P', 'N', 'G', 0x0d, 0x0a}
+
+func TestDownloadFileIsBinarySafe(t *testing.T) {
+	ts, repo := createAppFilesRepoServingContent(t, binaryFileContents)
+	defer ts.Close()
+
+	destination := &bytes.Buffer{}
+	apiResponse := repo.DownloadFile("my-app-guid", 1, "logs/stdout.log", destination)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, destination.Bytes(), binaryFileContents)
+}
+
+func TestDownloadFileFromAnOffset(t *testing.T) {
+	ts, repo := createAppFilesRepoServingContent(t, binaryFileContents)
+	defer ts.Close()
+
+	destination := &bytes.Buffer{}
+	newOffset, apiResponse := repo.DownloadFileFrom("my-app-guid", 1, "logs/stdout.log", 4, destination)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, destination.Bytes(), binaryFileContents[4:])
+	assert.Equal(t, newOffset, int64(len(binaryFileContents)))
+}
+
+func TestDownloadFileFromTheEndOfTheFile(t *testing.T) {
+	ts, repo := createAppFilesRepoServingContent(t, binaryFileContents)
+	defer ts.Close()
+
+	destination := &bytes.Buffer{}
+	newOffset, apiResponse := repo.DownloadFileFrom("my-app-guid", 1, "logs/stdout.log", int64(len(binaryFileContents)), destination)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, destination.Len(), 0)
+	assert.Equal(t, newOffset, int64(len(binaryFileContents)))
+}
+
+func TestDownloadFileFromAnOffsetWhenTheServerIgnoresRanges(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, request.Header.Get("Range"), "bytes=4-")
+		writer.WriteHeader(http.StatusOK)
+		writer.Write(binaryFileContents)
+	}))
+	defer ts.Close()
+
+	config := &configuration.Configuration{Target: ts.URL, AccessToken: "BEARER my_access_token"}
+	repo := NewCloudControllerAppFilesRepository(config, net.NewCloudControllerGateway())
+
+	destination := &bytes.Buffer{}
+	newOffset, apiResponse := repo.DownloadFileFrom("my-app-guid", 1, "logs/stdout.log", 4, destination)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, destination.Bytes(), binaryFileContents[4:])
+	assert.Equal(t, newOffset, int64(len(binaryFileContents)))
+}
+
+func TestDownloadFileFromPastTheEndOfATruncatedFile(t *testing.T) {
+	ts, repo := createAppFilesRepoServingContent(t, binaryFileContents)
+	defer ts.Close()
+
+	destination := &bytes.Buffer{}
+	newOffset, apiResponse := repo.DownloadFileFrom("my-app-guid", 1, "logs/stdout.log", 100, destination)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, destination.Len(), 0)
+	assert.Equal(t, newOffset, int64(0))
+}
+
+func TestDownloadFileFromATruncatedFileWhenTheServerIgnoresRanges(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+		writer.Write(binaryFileContents)
+	}))
+	defer ts.Close()
+
+	config := &configuration.Configuration{Target: ts.URL, AccessToken: "BEARER my_access_token"}
+	repo := NewCloudControllerAppFilesRepository(config, net.NewCloudControllerGateway())
+
+	destination := &bytes.Buffer{}
+	newOffset, apiResponse := repo.DownloadFileFrom("my-app-guid", 1, "logs/stdout.log", 100, destination)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, destination.Len(), 0)
+	assert.Equal(t, newOffset, int64(0))
+}
+
+func createAppFilesRepoServingContent(t *testing.T, content []byte) (ts *httptest.Server, repo AppFilesRepository) {
+	ts = httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, request.URL.Path, "/v2/apps/my-app-guid/instances/1/files/logs/stdout.log")
+		assert.Contains(t, request.Header.Get("Authorization"), "BEARER my_access_token")
+		http.ServeContent(writer, request, "stdout.log", time.Time{}, bytes.NewReader(content))
+	}))
+
+	config := &configuration.Configuration{Target: ts.URL, AccessToken: "BEARER my_access_token"}
+	repo = NewCloudControllerAppFilesRepository(config, net.NewCloudControllerGateway())
+	return
+}
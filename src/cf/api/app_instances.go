@@ -33,6 +33,7 @@ type InstanceStatsApiResponse struct {
 
 type AppInstancesRepository interface {
 	GetInstances(appGuid string) (instances []cf.AppInstanceFields, apiResponse net.ApiResponse)
+	DeleteInstance(appGuid string, index int) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerAppInstancesRepository struct {
@@ -76,6 +77,11 @@ func (repo CloudControllerAppInstancesRepository) GetInstances(appGuid string) (
 	return repo.updateInstancesWithStats(appGuid, instances)
 }
 
+func (repo CloudControllerAppInstancesRepository) DeleteInstance(appGuid string, index int) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/apps/%s/instances/%d", repo.config.Target, appGuid, index)
+	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+}
+
 func (repo CloudControllerAppInstancesRepository) updateInstancesWithStats(guid string, instances []cf.AppInstanceFields) (updatedInst []cf.AppInstanceFields, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/apps/%s/stats", repo.config.Target, guid)
 	statsResponse := StatsApiResponse{}
@@ -84,6 +84,21 @@ func TestAppInstancesGetInstances(t *testing.T) {
 	assert.Equal(t, instance0.CpuUsage, 3.659571249238058e-05)
 }
 
+func TestAppInstancesDeleteInstance(t *testing.T) {
+	ts, handler, repo := createAppInstancesRepo(t, []testnet.TestRequest{
+		testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+			Method:   "DELETE",
+			Path:     "/v2/apps/my-cool-app-guid/instances/3",
+			Response: testnet.TestResponse{Status: http.StatusNoContent},
+		}),
+	})
+	defer ts.Close()
+
+	apiResponse := repo.DeleteInstance("my-cool-app-guid", 3)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
 func createAppInstancesRepo(t *testing.T, requests []testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo AppInstancesRepository) {
 	ts, handler = testnet.NewTLSServer(t, requests)
 	space := cf.SpaceFields{}
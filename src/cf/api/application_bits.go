@@ -22,7 +22,8 @@ type AppFileResource struct {
 }
 
 type ApplicationBitsRepository interface {
-	UploadApp(appGuid, dir string, cb func(zipSize, fileCount uint64)) (apiResponse net.ApiResponse)
+	UploadApp(appGuid, dir string, options cf.ZipOptions, progress net.ProgressReporter, cb func(zipSize, fileCount uint64)) (apiResponse net.ApiResponse)
+	DownloadApp(appGuid string, destination io.Writer) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerApplicationBitsRepository struct {
@@ -38,159 +39,147 @@ func NewCloudControllerApplicationBitsRepository(config *configuration.Configura
 	return
 }
 
-func (repo CloudControllerApplicationBitsRepository) UploadApp(appGuid string, appDir string, cb func(zipSize, fileCount uint64)) (apiResponse net.ApiResponse) {
-	fileutils.TempDir("apps", func(uploadDir string, err error) {
+// UploadApp zips and uploads the files of appDir that are not ignored. The
+// zip and the multipart request around it are streamed straight from the app
+// files to the Cloud Controller, so nothing but extracted archives is copied
+// to disk. progress is told how much of the request has been sent.
+func (repo CloudControllerApplicationBitsRepository) UploadApp(appGuid string, appDir string, options cf.ZipOptions, progress net.ProgressReporter, cb func(zipSize, fileCount uint64)) (apiResponse net.ApiResponse) {
+	options.NormalizeFileModes = true
+
+	repo.sourceDir(appDir, func(sourceDir string, err error) {
 		if err != nil {
-			apiResponse = net.NewApiResponseWithMessage(err.Error())
+			apiResponse = net.NewApiResponseWithMessage("%s", err)
 			return
 		}
 
-		repo.sourceDir(appDir, func(sourceDir string, sourceErr error) {
-			if sourceErr != nil {
-				err = sourceErr
-				return
-			}
-			err = repo.copyUploadableFiles(sourceDir, uploadDir)
-		})
-
+		// zipping once up front gives the size the request needs to carry
+		zipSize := &countingWriter{}
+		err = repo.zipper.WriteZip(sourceDir, zipSize, options)
 		if err != nil {
-			apiResponse = net.NewApiResponseWithMessage("%s", err)
+			apiResponse = net.NewApiResponseWithError("Error zipping application", err)
 			return
 		}
+		cb(uint64(zipSize.count), cf.CountFiles(sourceDir))
 
-		fileutils.TempFile("uploads", func(zipFile *os.File, err error) {
-			if err != nil {
-				apiResponse = net.NewApiResponseWithMessage("%s", err.Error())
-				return
-			}
-
-			err = repo.zipper.Zip(uploadDir, zipFile)
-			if err != nil {
-				apiResponse = net.NewApiResponseWithError("Error zipping application", err)
-				return
-			}
-
-			stat, err := zipFile.Stat()
-			if err != nil {
-				apiResponse = net.NewApiResponseWithError("Error zipping application", err)
-				return
-			}
-			cb(uint64(stat.Size()), cf.CountFiles(uploadDir))
-
-			apiResponse = repo.uploadBits(appGuid, zipFile)
-			if apiResponse.IsNotSuccessful() {
-				return
-			}
-		})
+		apiResponse = repo.uploadBits(appGuid, sourceDir, options, zipSize.count, progress)
 	})
 	return
 }
 
-func (repo CloudControllerApplicationBitsRepository) uploadBits(appGuid string, zipFile *os.File) (apiResponse net.ApiResponse) {
-	url := fmt.Sprintf("%s/v2/apps/%s/bits", repo.config.Target, appGuid)
-
-	fileutils.TempFile("requests", func(requestFile *os.File, err error) {
-		if err != nil {
-			apiResponse = net.NewApiResponseWithError("Error creating tmp file: %s", err)
-			return
-		}
-
-		boundary, err := repo.writeUploadBody(zipFile, requestFile)
-		if err != nil {
-			apiResponse = net.NewApiResponseWithError("Error writing to tmp file: %s", err)
-			return
-		}
-
-		var request *net.Request
-		request, apiResponse = repo.gateway.NewRequest("PUT", url, repo.config.AccessToken, requestFile)
-		if apiResponse.IsNotSuccessful() {
-			return
-		}
-
-		contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
-		request.HttpReq.Header.Set("Content-Type", contentType)
+// DownloadApp copies the package last uploaded for the app, as a zip, into
+// destination.
+func (repo CloudControllerApplicationBitsRepository) DownloadApp(appGuid string, destination io.Writer) (apiResponse net.ApiResponse) {
+	url := fmt.Sprintf("%s/v2/apps/%s/download", repo.config.Target, appGuid)
+	request, apiResponse := repo.gateway.NewRequest("GET", url, repo.config.AccessToken, nil)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
 
-		response := &Resource{}
-		_, apiResponse = repo.gateway.PerformPollingRequestForJSONResponse(request, response)
-		if apiResponse.IsNotSuccessful() {
-			return
-		}
-	})
+	rawResponse, apiResponse := repo.gateway.PerformRequestForResponse(request)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+	defer rawResponse.Body.Close()
 
+	_, err := io.Copy(destination, rawResponse.Body)
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error downloading app package", err)
+	}
 	return
 }
 
-func (repo CloudControllerApplicationBitsRepository) sourceDir(appDir string, cb func(sourceDir string, err error)) {
-	// If appDir is a zip, first extract it to a temporary directory
-	zipReader, err := zip.OpenReader(appDir)
+func (repo CloudControllerApplicationBitsRepository) uploadBits(appGuid string, sourceDir string, options cf.ZipOptions, zipSize int64, progress net.ProgressReporter) (apiResponse net.ApiResponse) {
+	url := fmt.Sprintf("%s/v2/apps/%s/bits", repo.config.Target, appGuid)
+	boundary := multipart.NewWriter(nil).Boundary()
+
+	bodySize := &countingWriter{}
+	err := repo.writeUploadBody(bodySize, boundary, sourceDir, options, zipSize, false)
 	if err != nil {
-		cb(appDir, nil)
+		apiResponse = net.NewApiResponseWithError("Error creating upload", err)
 		return
 	}
 
-	fileutils.TempDir("unzipped-app", func(tmpDir string, err error) {
-		if err != nil {
-			cb("", err)
-			return
-		}
-
-		err = repo.extractZip(zipReader, tmpDir)
-		zipReader.Close()
-		cb(tmpDir, err)
+	streamingBody := net.NewStreamingBody(bodySize.count+zipSize, func(writer io.Writer) error {
+		return repo.writeUploadBody(writer, boundary, sourceDir, options, zipSize, true)
 	})
-}
+	defer streamingBody.Close()
 
-func (repo CloudControllerApplicationBitsRepository) copyUploadableFiles(appDir string, uploadDir string) (err error) {
-	// Find which files need to be uploaded
-	allAppFiles, err := cf.AppFilesInDir(appDir)
+	body, err := net.NewProgressReader(streamingBody, progress)
 	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error creating upload", err)
 		return
 	}
 
-	// Copy files into a temporary directory and return it
-	err = cf.CopyFiles(allAppFiles, appDir, uploadDir)
-	if err != nil {
+	request, apiResponse := repo.gateway.NewRequest("PUT", url, repo.config.AccessToken, body)
+	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
+	contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
+	request.HttpReq.Header.Set("Content-Type", contentType)
+
+	response := &Resource{}
+	_, apiResponse = repo.gateway.PerformPollingRequestForJSONResponse(request, response)
 	return
 }
 
-func (repo CloudControllerApplicationBitsRepository) extractZip(r *zip.ReadCloser, destDir string) (err error) {
-	for _, f := range r.File {
-		func() {
-			// Don't try to extract directories
-			if f.FileInfo().IsDir() {
-				return
-			}
-
+func (repo CloudControllerApplicationBitsRepository) sourceDir(appDir string, cb func(sourceDir string, err error)) {
+	// If appDir is a zip, first extract it to a temporary directory
+	if isZipArchive(appDir) {
+		fileutils.TempDir("unzipped-app", func(tmpDir string, err error) {
 			if err != nil {
+				cb("", err)
 				return
 			}
 
-			var rc io.ReadCloser
-			rc, err = f.Open()
+			err = cf.ExtractZipArchive(appDir, tmpDir)
+			cb(tmpDir, err)
+		})
+		return
+	}
+
+	if cf.IsTarArchive(appDir) {
+		fileutils.TempDir("untarred-app", func(tmpDir string, err error) {
 			if err != nil {
+				cb("", err)
 				return
 			}
 
-			defer rc.Close()
+			err = cf.ExtractTarArchive(appDir, tmpDir)
+			cb(tmpDir, err)
+		})
+		return
+	}
 
-			destFilePath := filepath.Join(destDir, f.Name)
+	// A single file is pushed as an app holding just that file
+	fileInfo, err := os.Stat(appDir)
+	if err != nil || fileInfo.IsDir() {
+		cb(appDir, nil)
+		return
+	}
 
-			err = fileutils.CopyReaderToPath(rc, destFilePath)
-			if err != nil {
-				return
-			}
+	fileutils.TempDir("single-file-app", func(tmpDir string, err error) {
+		if err != nil {
+			cb("", err)
+			return
+		}
 
-			err = fileutils.SetExecutableBits(destFilePath, f.FileInfo())
-			if err != nil {
-				return
-			}
-		}()
-	}
+		destPath := filepath.Join(tmpDir, filepath.Base(appDir))
+		err = fileutils.CopyFilePaths(appDir, destPath)
+		if err == nil {
+			err = os.Chmod(destPath, fileInfo.Mode().Perm())
+		}
+		cb(tmpDir, err)
+	})
+}
 
-	return
+func isZipArchive(path string) bool {
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		return false
+	}
+	zipReader.Close()
+	return true
 }
 
 func (repo CloudControllerApplicationBitsRepository) deleteAppFile(appFiles []cf.AppFileFields, targetFile cf.AppFileFields) []cf.AppFileFields {
@@ -203,48 +192,57 @@ func (repo CloudControllerApplicationBitsRepository) deleteAppFile(appFiles []cf
 	return appFiles
 }
 
-func (repo CloudControllerApplicationBitsRepository) writeUploadBody(zipFile *os.File, body *os.File) (boundary string, err error) {
+// writeUploadBody writes the multipart body of an upload with the given
+// boundary. Without withZip it leaves out the zip itself, which is how the
+// size of everything around it is measured.
+func (repo CloudControllerApplicationBitsRepository) writeUploadBody(body io.Writer, boundary string, sourceDir string, options cf.ZipOptions, zipSize int64, withZip bool) (err error) {
 	writer := multipart.NewWriter(body)
-	defer writer.Close()
-
-	boundary = writer.Boundary()
-
-	part, err := writer.CreateFormField("resources")
+	err = writer.SetBoundary(boundary)
 	if err != nil {
 		return
 	}
 
-	_, err = io.Copy(part, bytes.NewBufferString("[]"))
+	part, err := writer.CreateFormField("resources")
 	if err != nil {
 		return
 	}
 
-	zipStats, err := zipFile.Stat()
+	_, err = io.Copy(part, bytes.NewBufferString("[]"))
 	if err != nil {
 		return
 	}
 
-	if zipStats.Size() == 0 {
-		return
-	}
+	if zipSize > 0 {
+		part, err = createZipPartWriter(zipSize, writer)
+		if err != nil {
+			return
+		}
 
-	part, err = createZipPartWriter(zipStats, writer)
-	if err != nil {
-		return
+		if withZip {
+			err = repo.zipper.WriteZip(sourceDir, part, options)
+			if err != nil {
+				return
+			}
+		}
 	}
 
-	_, err = io.Copy(part, zipFile)
-	if err != nil {
-		return
-	}
-	return
+	return writer.Close()
 }
 
-func createZipPartWriter(zipStats os.FileInfo, writer *multipart.Writer) (io.Writer, error) {
+func createZipPartWriter(zipSize int64, writer *multipart.Writer) (io.Writer, error) {
 	h := make(textproto.MIMEHeader)
 	h.Set("Content-Disposition", `form-data; name="application"; filename="application.zip"`)
 	h.Set("Content-Type", "application/zip")
-	h.Set("Content-Length", fmt.Sprintf("%d", zipStats.Size()))
+	h.Set("Content-Length", fmt.Sprintf("%d", zipSize))
 	h.Set("Content-Transfer-Encoding", "binary")
 	return writer.CreatePart(h)
 }
+
+type countingWriter struct {
+	count int64
+}
+
+func (writer *countingWriter) Write(p []byte) (n int, err error) {
+	writer.count += int64(len(p))
+	return len(p), nil
+}
@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"archive/zip"
+	"bytes"
 	"cf"
 	. "cf/api"
 	"cf/configuration"
@@ -9,6 +10,8 @@ import (
 	"fileutils"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -149,7 +152,7 @@ func TestUploadWithInvalidDirectory(t *testing.T) {
 
 	repo := NewCloudControllerApplicationBitsRepository(config, gateway, zipper)
 
-	apiResponse := repo.UploadApp("app-guid", "/foo/bar", func(uploadSize, fileCount uint64) {})
+	apiResponse := repo.UploadApp("app-guid", "/foo/bar", cf.ZipOptions{}, &testnet.FakeProgressReporter{}, func(uploadSize, fileCount uint64) {})
 	assert.True(t, apiResponse.IsNotSuccessful())
 	assert.Contains(t, apiResponse.Message, filepath.Join("foo", "bar"))
 }
@@ -198,6 +201,60 @@ func TestUploadAppFailsWhilePushingBits(t *testing.T) {
 	assert.False(t, apiResponse.IsSuccessful())
 }
 
+func TestUploadAppStreamsABodyOfTheAnnouncedLength(t *testing.T) {
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+	dir = filepath.Join(dir, "../../fixtures/example-app")
+
+	request := uploadApplicationRequest
+	request.Matcher = func(t *testing.T, request *http.Request) {
+		body, err := ioutil.ReadAll(request.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, request.ContentLength, int64(len(body)))
+
+		reader := multipart.NewReader(bytes.NewReader(body), request.Header.Get("Content-Type")[len("multipart/form-data; boundary="):])
+		form, err := reader.ReadForm(4096)
+		assert.NoError(t, err)
+		defer form.RemoveAll()
+
+		assert.Equal(t, form.Value["resources"], []string{"[]"})
+
+		zipFile, err := form.File["application"][0].Open()
+		assert.NoError(t, err)
+		zipContents, err := ioutil.ReadAll(zipFile)
+		assert.NoError(t, err)
+
+		zipReader, err := zip.NewReader(bytes.NewReader(zipContents), int64(len(zipContents)))
+		assert.NoError(t, err)
+		assert.Equal(t, len(zipReader.File), len(expectedApplicationContent))
+	}
+
+	ts, handler := testnet.NewTLSServer(t, []testnet.TestRequest{
+		request,
+		createProgressEndpoint("finished"),
+	})
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		AccessToken: "BEARER my_access_token",
+		Target:      ts.URL,
+	}
+	gateway := net.NewCloudControllerGateway()
+	gateway.PollingThrottle = time.Duration(0)
+	repo := NewCloudControllerApplicationBitsRepository(config, gateway, cf.ApplicationZipper{})
+
+	var reportedFileCount, reportedZipSize uint64
+	apiResponse := repo.UploadApp("my-cool-app-guid", dir, cf.ZipOptions{}, &testnet.FakeProgressReporter{}, func(zipSize, fileCount uint64) {
+		reportedZipSize = zipSize
+		reportedFileCount = fileCount
+	})
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, reportedFileCount, uint64(len(expectedApplicationContent)))
+	assert.True(t, reportedZipSize > 0)
+}
+
 func testUploadApp(t *testing.T, dir string, requests []testnet.TestRequest) (app cf.Application, apiResponse net.ApiResponse) {
 	ts, handler := testnet.NewTLSServer(t, requests)
 	defer ts.Close()
@@ -212,7 +269,8 @@ func testUploadApp(t *testing.T, dir string, requests []testnet.TestRequest) (ap
 	repo := NewCloudControllerApplicationBitsRepository(config, gateway, zipper)
 
 	var reportedFileCount, reportedUploadSize uint64
-	apiResponse = repo.UploadApp("my-cool-app-guid", dir, func(uploadSize, fileCount uint64) {
+	progress := &testnet.FakeProgressReporter{}
+	apiResponse = repo.UploadApp("my-cool-app-guid", dir, cf.ZipOptions{}, progress, func(uploadSize, fileCount uint64) {
 		reportedUploadSize = uploadSize
 		reportedFileCount = fileCount
 	})
@@ -220,6 +278,56 @@ func testUploadApp(t *testing.T, dir string, requests []testnet.TestRequest) (ap
 	assert.Equal(t, reportedFileCount, uint64(len(expectedApplicationContent)))
 	assert.Equal(t, reportedUploadSize, uint64(1094))
 	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, progress.Total > 0)
+	assert.Equal(t, progress.Sent, progress.Total)
 
 	return
 }
+
+func TestDownloadApp(t *testing.T) {
+	ts, handler := testnet.NewTLSServer(t, []testnet.TestRequest{
+		testnet.TestRequest{
+			Method:   "GET",
+			Path:     "/v2/apps/my-cool-app-guid/download",
+			Response: testnet.TestResponse{Status: http.StatusOK, Body: "zipped app package"},
+		},
+	})
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		AccessToken: "BEARER my_access_token",
+		Target:      ts.URL,
+	}
+	repo := NewCloudControllerApplicationBitsRepository(config, net.NewCloudControllerGateway(), cf.ApplicationZipper{})
+
+	destination := &bytes.Buffer{}
+	apiResponse := repo.DownloadApp("my-cool-app-guid", destination)
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, destination.String(), "zipped app package\n")
+}
+
+func TestDownloadAppWhenThereIsNoPackage(t *testing.T) {
+	ts, handler := testnet.NewTLSServer(t, []testnet.TestRequest{
+		testnet.TestRequest{
+			Method:   "GET",
+			Path:     "/v2/apps/my-cool-app-guid/download",
+			Response: testnet.TestResponse{Status: http.StatusNotFound, Body: `{"code": 100004, "description": "The app package could not be found"}`},
+		},
+	})
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		AccessToken: "BEARER my_access_token",
+		Target:      ts.URL,
+	}
+	repo := NewCloudControllerApplicationBitsRepository(config, net.NewCloudControllerGateway(), cf.ApplicationZipper{})
+
+	destination := &bytes.Buffer{}
+	apiResponse := repo.DownloadApp("my-cool-app-guid", destination)
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, destination.String(), "")
+}
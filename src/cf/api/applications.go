@@ -39,6 +39,9 @@ type ApplicationEntity struct {
 	SpaceGuid       string `json:"space_guid"`
 	Instances       int
 	Memory          int
+	DiskQuota       uint64 `json:"disk_quota"`
+	Buildpack       string
+	Command         string
 	Stack           StackResource
 	Routes          []AppRouteResource
 	EnvironmentJson map[string]string `json:"environment_json"`
@@ -56,6 +59,9 @@ func (resource ApplicationResource) ToFields() (app cf.ApplicationFields) {
 	app.State = strings.ToLower(resource.Entity.State)
 	app.InstanceCount = resource.Entity.Instances
 	app.Memory = uint64(resource.Entity.Memory)
+	app.DiskQuota = resource.Entity.DiskQuota
+	app.BuildpackUrl = resource.Entity.Buildpack
+	app.Command = resource.Entity.Command
 	app.SpaceGuid = resource.Entity.SpaceGuid
 	return
 }
@@ -147,6 +153,7 @@ func (repo CloudControllerApplicationRepository) Update(appGuid string, params c
 var allowedAppKeys = []string{
 	"buildpack",
 	"command",
+	"disk_quota",
 	"instances",
 	"memory",
 	"name",
@@ -172,6 +179,11 @@ func (repo CloudControllerApplicationRepository) formatAppJSON(input cf.AppParam
 		params.Set("stack_guid", stringOrNull(params.Get("stack_guid")))
 	}
 
+	// a zero disk quota means the Cloud Controller default rather than no disk
+	if params.Has("disk_quota") && params.Get("disk_quota") == uint64(0) {
+		params.Delete("disk_quota")
+	}
+
 	if params.Has("state") {
 		params.Set("state", strings.ToUpper(params.Get("state").(string)))
 	}
@@ -181,13 +193,11 @@ func (repo CloudControllerApplicationRepository) formatAppJSON(input cf.AppParam
 	if !params.IsEmpty() {
 		vals = append(vals, mapToJsonValues(params)...)
 	}
+	// an empty env is sent too, since that is how every variable is removed
 	if input.Has("env") {
 		envVars := input.Get("env").(generic.Map)
-		if !envVars.IsEmpty() {
-			envVal := fmt.Sprintf(`"environment_json":{%s}`, strings.Join(mapToJsonValues(envVars), ","))
-			vals = append(vals, envVal)
-		}
-
+		envVal := fmt.Sprintf(`"environment_json":{%s}`, strings.Join(mapToJsonValues(envVars), ","))
+		vals = append(vals, envVal)
 	}
 	data = fmt.Sprintf("{%s}", strings.Join(vals, ","))
 	return
@@ -122,6 +122,26 @@ func TestSetEnv(t *testing.T) {
 	assert.False(t, apiResponse.IsNotSuccessful())
 }
 
+func TestSetEnvToNoVariables(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "PUT",
+		Path:     "/v2/apps/app1-guid",
+		Matcher:  testnet.RequestBodyMatcher(`{"environment_json":{}}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated},
+	})
+
+	ts, handler, repo := createAppRepo(t, []testnet.TestRequest{request})
+	defer ts.Close()
+
+	params := cf.NewEmptyAppParams()
+	params.Set("env", generic.NewMap())
+
+	_, apiResponse := repo.Update("app1-guid", params)
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+}
+
 var createApplicationResponse = `
 {
     "metadata": {
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"cf"
+	"cf/configuration"
+	"cf/net"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type PaginatedAuditEventResources struct {
+	Resources []AuditEventResource
+	NextURL   string `json:"next_url"`
+}
+
+type AuditEventResource struct {
+	Metadata Metadata
+	Entity   AuditEventEntity
+}
+
+type AuditEventEntity struct {
+	Type             string
+	Timestamp        time.Time
+	Actor            string
+	ActorType        string `json:"actor_type"`
+	ActorName        string `json:"actor_name"`
+	Actee            string
+	ActeeType        string `json:"actee_type"`
+	ActeeName        string `json:"actee_name"`
+	SpaceGuid        string `json:"space_guid"`
+	OrganizationGuid string `json:"organization_guid"`
+}
+
+func (resource AuditEventResource) ToFields() (event cf.AuditEventFields) {
+	event.Guid = resource.Metadata.Guid
+	event.Type = resource.Entity.Type
+	event.Timestamp = resource.Entity.Timestamp
+	event.ActorGuid = resource.Entity.Actor
+	event.ActorType = resource.Entity.ActorType
+	event.ActorName = resource.Entity.ActorName
+	event.ActeeGuid = resource.Entity.Actee
+	event.ActeeType = resource.Entity.ActeeType
+	event.ActeeName = resource.Entity.ActeeName
+	event.SpaceGuid = resource.Entity.SpaceGuid
+	event.OrganizationGuid = resource.Entity.OrganizationGuid
+	return
+}
+
+// AuditEventFilter narrows the events the Cloud Controller returns. Zero
+// values are left out of the query.
+type AuditEventFilter struct {
+	Types            []string
+	SpaceGuid        string
+	OrganizationGuid string
+	Since            time.Time
+	Until            time.Time
+}
+
+func (filter AuditEventFilter) query() string {
+	conditions := []string{}
+
+	if len(filter.Types) == 1 {
+		conditions = append(conditions, "type:"+filter.Types[0])
+	} else if len(filter.Types) > 1 {
+		conditions = append(conditions, "type IN "+strings.Join(filter.Types, ","))
+	}
+	if filter.SpaceGuid != "" {
+		conditions = append(conditions, "space_guid:"+filter.SpaceGuid)
+	}
+	if filter.OrganizationGuid != "" {
+		conditions = append(conditions, "organization_guid:"+filter.OrganizationGuid)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "timestamp>"+filter.Since.UTC().Format(APP_EVENT_TIMESTAMP_FORMAT))
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "timestamp<"+filter.Until.UTC().Format(APP_EVENT_TIMESTAMP_FORMAT))
+	}
+
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "&q=" + url.QueryEscape(strings.Join(conditions, ";"))
+}
+
+type AuditEventsRepository interface {
+	ListAuditEvents(filter AuditEventFilter) (eventChan chan []cf.AuditEventFields, statusChan chan net.ApiResponse)
+}
+
+type CloudControllerAuditEventsRepository struct {
+	config  *configuration.Configuration
+	gateway net.Gateway
+}
+
+func NewCloudControllerAuditEventsRepository(config *configuration.Configuration, gateway net.Gateway) (repo CloudControllerAuditEventsRepository) {
+	repo.config = config
+	repo.gateway = gateway
+	return
+}
+
+// ListAuditEvents pages through /v2/events oldest first, sending each page of
+// events that match the filter on eventChan.
+func (repo CloudControllerAuditEventsRepository) ListAuditEvents(filter AuditEventFilter) (eventChan chan []cf.AuditEventFields, statusChan chan net.ApiResponse) {
+	eventChan = make(chan []cf.AuditEventFields, 4)
+	statusChan = make(chan net.ApiResponse, 1)
+
+	go func() {
+		path := "/v2/events?order-direction=asc" + filter.query()
+		for path != "" {
+			eventsUrl := fmt.Sprintf("%s%s", repo.config.Target, path)
+			eventResources := &PaginatedAuditEventResources{}
+			apiResponse := repo.gateway.GetResource(eventsUrl, repo.config.AccessToken, eventResources)
+			if apiResponse.IsNotSuccessful() {
+				statusChan <- apiResponse
+				close(eventChan)
+				close(statusChan)
+				return
+			}
+
+			events := []cf.AuditEventFields{}
+			for _, resource := range eventResources.Resources {
+				events = append(events, resource.ToFields())
+			}
+			if len(events) > 0 {
+				eventChan <- events
+			}
+
+			path = eventResources.NextURL
+		}
+		close(eventChan)
+		close(statusChan)
+	}()
+
+	return
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"cf"
+	"cf/configuration"
+	"cf/net"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	testnet "testhelpers/net"
+	"testing"
+	"time"
+)
+
+var firstPageAuditEventsRequest = testnet.TestRequest{
+	Method: "GET",
+	Path:   "/v2/events?order-direction=asc&q=type+IN+app.create%2Capp.update%3Bspace_guid%3Amy-space-guid%3Btimestamp%3E2014-01-21T12%3A00%3A00%2B00%3A00",
+	Response: testnet.TestResponse{
+		Status: http.StatusOK,
+		Body: `
+{
+  "next_url": "/v2/events?order-direction=asc&page=2",
+  "resources": [
+    {
+      "metadata": { "guid": "event-1-guid" },
+      "entity": {
+        "type": "app.create",
+        "actor": "user-guid",
+        "actor_type": "user",
+        "actor_name": "admin",
+        "actee": "my-app-guid",
+        "actee_type": "app",
+        "actee_name": "my-app",
+        "timestamp": "2014-01-21T13:00:00+00:00",
+        "space_guid": "my-space-guid",
+        "organization_guid": "my-org-guid"
+      }
+    }
+  ]
+}
+`},
+}
+
+var secondPageAuditEventsRequest = testnet.TestRequest{
+	Method: "GET",
+	Path:   "/v2/events?order-direction=asc&page=2",
+	Response: testnet.TestResponse{
+		Status: http.StatusOK,
+		Body: `
+{
+  "next_url": null,
+  "resources": [
+    {
+      "metadata": { "guid": "event-2-guid" },
+      "entity": {
+        "type": "app.update",
+        "actor": "user-guid",
+        "actor_type": "user",
+        "actor_name": "admin",
+        "actee": "my-app-guid",
+        "actee_type": "app",
+        "actee_name": "my-app",
+        "timestamp": "2014-01-21T14:00:00+00:00",
+        "space_guid": "my-space-guid",
+        "organization_guid": "my-org-guid"
+      }
+    }
+  ]
+}
+`},
+}
+
+func TestListAuditEvents(t *testing.T) {
+	ts, handler := testnet.NewTLSServer(t, []testnet.TestRequest{
+		firstPageAuditEventsRequest,
+		secondPageAuditEventsRequest,
+	})
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		Target:      ts.URL,
+		AccessToken: "BEARER my_access_token",
+	}
+	repo := NewCloudControllerAuditEventsRepository(config, net.NewCloudControllerGateway())
+
+	since, err := time.Parse(APP_EVENT_TIMESTAMP_FORMAT, "2014-01-21T12:00:00+00:00")
+	assert.NoError(t, err)
+
+	eventChan, statusChan := repo.ListAuditEvents(AuditEventFilter{
+		Types:     []string{"app.create", "app.update"},
+		SpaceGuid: "my-space-guid",
+		Since:     since,
+	})
+
+	list := []cf.AuditEventFields{}
+	for events := range eventChan {
+		list = append(list, events...)
+	}
+	_, open := <-statusChan
+
+	assert.False(t, open)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.Equal(t, len(list), 2)
+	assert.Equal(t, list[0].Guid, "event-1-guid")
+	assert.Equal(t, list[0].Type, "app.create")
+	assert.Equal(t, list[0].ActorGuid, "user-guid")
+	assert.Equal(t, list[0].ActorType, "user")
+	assert.Equal(t, list[0].ActorName, "admin")
+	assert.Equal(t, list[0].ActeeGuid, "my-app-guid")
+	assert.Equal(t, list[0].ActeeType, "app")
+	assert.Equal(t, list[0].ActeeName, "my-app")
+	assert.Equal(t, list[0].SpaceGuid, "my-space-guid")
+	assert.Equal(t, list[0].OrganizationGuid, "my-org-guid")
+	assert.True(t, list[0].Timestamp.Equal(since.Add(time.Hour)))
+	assert.Equal(t, list[1].Guid, "event-2-guid")
+}
+
+func TestListAuditEventsWhenTheRequestFails(t *testing.T) {
+	ts, handler := testnet.NewTLSServer(t, []testnet.TestRequest{
+		testnet.TestRequest{
+			Method:   "GET",
+			Path:     "/v2/events?order-direction=asc&q=organization_guid%3Amy-org-guid",
+			Response: testnet.TestResponse{Status: http.StatusInternalServerError},
+		},
+	})
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		Target:      ts.URL,
+		AccessToken: "BEARER my_access_token",
+	}
+	repo := NewCloudControllerAuditEventsRepository(config, net.NewCloudControllerGateway())
+
+	eventChan, statusChan := repo.ListAuditEvents(AuditEventFilter{OrganizationGuid: "my-org-guid"})
+
+	_, ok := <-eventChan
+	apiResponse := <-statusChan
+
+	assert.False(t, ok)
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.True(t, handler.AllRequestsCalled())
+}
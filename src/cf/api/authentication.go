@@ -6,8 +6,8 @@ import (
 	"cf/terminal"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
-	"os"
 	"strings"
 )
 
@@ -55,8 +55,7 @@ func (uaa UAAAuthenticationRepository) RefreshAuthToken() (updatedToken string,
 	updatedToken = uaa.config.AccessToken
 
 	if apiResponse.IsError() {
-		fmt.Printf("%s\n\n", terminal.NotLoggedInText())
-		os.Exit(1)
+		apiResponse = net.NewApiResponse(terminal.NotLoggedInText(), apiResponse.ErrorCode, http.StatusUnauthorized)
 	}
 
 	return
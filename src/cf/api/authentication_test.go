@@ -2,6 +2,7 @@ package api_test
 
 import (
 	. "cf/api"
+	"cf/errors"
 	"cf/net"
 	"encoding/base64"
 	"github.com/stretchr/testify/assert"
@@ -104,6 +105,26 @@ func TestServerErrorLoggingIn(t *testing.T) {
 	assert.Empty(t, savedConfig.AccessToken)
 }
 
+var unsuccessfulRefreshRequest = testnet.TestRequest{
+	Method: "POST",
+	Path:   "/oauth/token",
+	Response: testnet.TestResponse{
+		Status: http.StatusUnauthorized,
+	},
+}
+
+func TestUnsuccessfullyRefreshingTheAuthToken(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, unsuccessfulRefreshRequest)
+	defer ts.Close()
+
+	_, apiResponse := auth.RefreshAuthToken()
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Not logged in")
+	assert.Equal(t, apiResponse.ToError().ExitCode(), errors.EXIT_NOT_LOGGED_IN)
+}
+
 var errorMaskedAsSuccessLoginRequest = testnet.TestRequest{
 	Method: "POST",
 	Path:   "/oauth/token",
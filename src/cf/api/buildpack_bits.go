@@ -13,7 +13,7 @@ import (
 )
 
 type BuildpackBitsRepository interface {
-	UploadBuildpack(buildpack cf.Buildpack, dir string) (apiResponse net.ApiResponse)
+	UploadBuildpack(buildpack cf.Buildpack, dir string, progress net.ProgressReporter) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerBuildpackBitsRepository struct {
@@ -29,7 +29,7 @@ func NewCloudControllerBuildpackBitsRepository(config *configuration.Configurati
 	return
 }
 
-func (repo CloudControllerBuildpackBitsRepository) UploadBuildpack(buildpack cf.Buildpack, dir string) (apiResponse net.ApiResponse) {
+func (repo CloudControllerBuildpackBitsRepository) UploadBuildpack(buildpack cf.Buildpack, dir string, progress net.ProgressReporter) (apiResponse net.ApiResponse) {
 	fileutils.TempFile("buildpack", func(zipFile *os.File, err error) {
 		if err != nil {
 			apiResponse = net.NewApiResponseWithMessage(err.Error())
@@ -40,7 +40,7 @@ func (repo CloudControllerBuildpackBitsRepository) UploadBuildpack(buildpack cf.
 			apiResponse = net.NewApiResponseWithError("Invalid buildpack", err)
 			return
 		}
-		apiResponse = repo.uploadBits(buildpack, zipFile, dir)
+		apiResponse = repo.uploadBits(buildpack, zipFile, dir, progress)
 		if apiResponse.IsNotSuccessful() {
 			return
 		}
@@ -48,7 +48,7 @@ func (repo CloudControllerBuildpackBitsRepository) UploadBuildpack(buildpack cf.
 	return
 }
 
-func (repo CloudControllerBuildpackBitsRepository) uploadBits(buildpack cf.Buildpack, zipFile *os.File, filename string) (apiResponse net.ApiResponse) {
+func (repo CloudControllerBuildpackBitsRepository) uploadBits(buildpack cf.Buildpack, zipFile *os.File, filename string, progress net.ProgressReporter) (apiResponse net.ApiResponse) {
 	url := fmt.Sprintf("%s/v2/buildpacks/%s/bits", repo.config.Target, buildpack.Guid)
 
 	fileutils.TempFile("requests", func(requestFile *os.File, err error) {
@@ -63,8 +63,14 @@ func (repo CloudControllerBuildpackBitsRepository) uploadBits(buildpack cf.Build
 			return
 		}
 
+		body, err := net.NewProgressReader(requestFile, progress)
+		if err != nil {
+			apiResponse = net.NewApiResponseWithError("Error creating upload", err)
+			return
+		}
+
 		var request *net.Request
-		request, apiResponse = repo.gateway.NewRequest("PUT", url, repo.config.AccessToken, requestFile)
+		request, apiResponse = repo.gateway.NewRequest("PUT", url, repo.config.AccessToken, body)
 		contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
 		request.HttpReq.Header.Set("Content-Type", contentType)
 		if apiResponse.IsNotSuccessful() {
@@ -86,7 +86,7 @@ func TestUploadBuildpackWithInvalidDirectory(t *testing.T) {
 	repo := NewCloudControllerBuildpackBitsRepository(config, gateway, cf.ApplicationZipper{})
 	buildpack := cf.Buildpack{}
 
-	apiResponse := repo.UploadBuildpack(buildpack, "/foo/bar")
+	apiResponse := repo.UploadBuildpack(buildpack, "/foo/bar", &testnet.FakeProgressReporter{})
 	assert.True(t, apiResponse.IsNotSuccessful())
 	assert.Contains(t, apiResponse.Message, "Invalid buildpack")
 }
@@ -129,7 +129,10 @@ func testUploadBuildpack(t *testing.T, dir string, requests []testnet.TestReques
 	buildpack.Name = "my-cool-buildpack"
 	buildpack.Guid = "my-cool-buildpack-guid"
 
-	apiResponse = repo.UploadBuildpack(buildpack, dir)
+	progress := &testnet.FakeProgressReporter{}
+	apiResponse = repo.UploadBuildpack(buildpack, dir, progress)
 	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, progress.Total > 0)
+	assert.Equal(t, progress.Sent, progress.Total)
 	return
 }
@@ -26,6 +26,10 @@ type PaginatedOrganizationResources struct {
 	NextUrl   string `json:"next_url"`
 }
 
+type resourceTotal struct {
+	TotalResults int `json:"total_results"`
+}
+
 func (resource OrganizationResource) ToFields() (fields cf.OrganizationFields) {
 	fields.Name = resource.Entity.Name
 	fields.Guid = resource.Metadata.Guid
@@ -55,6 +59,7 @@ func (resource OrganizationResource) ToModel() (org cf.Organization) {
 type OrganizationRepository interface {
 	ListOrgs(stop chan bool) (orgsChan chan []cf.Organization, statusChan chan net.ApiResponse)
 	FindByName(name string) (org cf.Organization, apiResponse net.ApiResponse)
+	CountResources(orgGuid string) (counts cf.OrganizationResourceCounts, apiResponse net.ApiResponse)
 	Create(name string) (apiResponse net.ApiResponse)
 	Rename(orgGuid string, name string) (apiResponse net.ApiResponse)
 	Delete(orgGuid string) (apiResponse net.ApiResponse)
@@ -142,6 +147,35 @@ func (repo CloudControllerOrganizationRepository) FindByName(name string) (org c
 	return
 }
 
+func (repo CloudControllerOrganizationRepository) CountResources(orgGuid string) (counts cf.OrganizationResourceCounts, apiResponse net.ApiResponse) {
+	totals := []struct {
+		collection string
+		count      *int
+	}{
+		{"apps", &counts.Apps},
+		{"service_instances", &counts.ServiceInstances},
+		{"routes", &counts.Routes},
+	}
+
+	for _, total := range totals {
+		*total.count, apiResponse = repo.countInOrg(total.collection, orgGuid)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+	}
+	return
+}
+
+// countInOrg asks for a single result, since only the total is needed.
+func (repo CloudControllerOrganizationRepository) countInOrg(collection, orgGuid string) (count int, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/%s?q=%s&results-per-page=1", repo.config.Target, collection, url.QueryEscape("organization_guid:"+orgGuid))
+
+	total := new(resourceTotal)
+	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, total)
+	count = total.TotalResults
+	return
+}
+
 func (repo CloudControllerOrganizationRepository) Create(name string) (apiResponse net.ApiResponse) {
 	url := repo.config.Target + "/v2/organizations"
 	data := fmt.Sprintf(`{"name":"%s"}`, name)
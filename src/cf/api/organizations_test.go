@@ -5,6 +5,7 @@ import (
 	. "cf/api"
 	"cf/configuration"
 	"cf/net"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -150,6 +151,28 @@ func TestOrganizationsFindByNameWhenDoesNotExist(t *testing.T) {
 	assert.True(t, apiResponse.IsNotFound())
 }
 
+func TestOrganizationsCountResources(t *testing.T) {
+	countRequest := func(collection string, total int) testnet.TestRequest {
+		return testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+			Method:   "GET",
+			Path:     "/v2/" + collection + "?q=organization_guid%3Aorg1-guid&results-per-page=1",
+			Response: testnet.TestResponse{Status: http.StatusOK, Body: fmt.Sprintf(`{"total_results": %d, "resources": []}`, total)},
+		})
+	}
+
+	ts, handler, repo := createOrganizationRepo(t,
+		countRequest("apps", 4),
+		countRequest("service_instances", 2),
+		countRequest("routes", 5),
+	)
+	defer ts.Close()
+
+	counts, apiResponse := repo.CountResources("org1-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, counts, cf.OrganizationResourceCounts{Apps: 4, ServiceInstances: 2, Routes: 5})
+}
+
 func TestCreateOrganization(t *testing.T) {
 	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:   "POST",
@@ -18,6 +18,8 @@ type RepositoryLocator struct {
 	appSummaryRepo                  CloudControllerAppSummaryRepository
 	appInstancesRepo                CloudControllerAppInstancesRepository
 	appEventsRepo                   CloudControllerAppEventsRepository
+	appEnvRepo                      CloudControllerAppEnvRepository
+	auditEventsRepo                 CloudControllerAuditEventsRepository
 	appFilesRepo                    CloudControllerAppFilesRepository
 	domainRepo                      CloudControllerDomainRepository
 	routeRepo                       CloudControllerRouteRepository
@@ -39,6 +41,9 @@ func NewRepositoryLocator(config *configuration.Configuration, configRepo config
 	authGateway := gatewaysByName["auth"]
 	cloudControllerGateway := gatewaysByName["cloud-controller"]
 	uaaGateway := gatewaysByName["uaa"]
+
+	// logging in and refreshing tokens must still work when mutating requests are only previewed
+	authGateway.DryRunExempt = true
 	loc.authRepo = NewUAAAuthenticationRepository(authGateway, configRepo)
 
 	// ensure gateway refreshers are set before passing them by value to repositories
@@ -47,6 +52,8 @@ func NewRepositoryLocator(config *configuration.Configuration, configRepo config
 
 	loc.appBitsRepo = NewCloudControllerApplicationBitsRepository(config, cloudControllerGateway, cf.ApplicationZipper{})
 	loc.appEventsRepo = NewCloudControllerAppEventsRepository(config, cloudControllerGateway)
+	loc.auditEventsRepo = NewCloudControllerAuditEventsRepository(config, cloudControllerGateway)
+	loc.appEnvRepo = NewCloudControllerAppEnvRepository(config, cloudControllerGateway)
 	loc.appFilesRepo = NewCloudControllerAppFilesRepository(config, cloudControllerGateway)
 	loc.appRepo = NewCloudControllerApplicationRepository(config, cloudControllerGateway)
 	loc.appSummaryRepo = NewCloudControllerAppSummaryRepository(config, cloudControllerGateway)
@@ -118,6 +125,14 @@ func (locator RepositoryLocator) GetAppEventsRepository() AppEventsRepository {
 	return locator.appEventsRepo
 }
 
+func (locator RepositoryLocator) GetAppEnvRepository() AppEnvRepository {
+	return locator.appEnvRepo
+}
+
+func (locator RepositoryLocator) GetAuditEventsRepository() AuditEventsRepository {
+	return locator.auditEventsRepo
+}
+
 func (locator RepositoryLocator) GetAppFilesRepository() AppFilesRepository {
 	return locator.appFilesRepo
 }
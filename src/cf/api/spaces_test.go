@@ -302,6 +302,32 @@ func TestDeleteSpace(t *testing.T) {
 	assert.False(t, apiResponse.IsNotSuccessful())
 }
 
+func TestCreateSpaceAndSetARoleInItDuringADryRun(t *testing.T) {
+	net.EnableDryRun()
+	defer net.DisableDryRun()
+
+	config := &configuration.Configuration{
+		AccessToken: "BEARER my_access_token",
+		Target:      "https://api.example.com",
+	}
+	ccGateway := net.NewCloudControllerGateway()
+	spaceRepo := NewCloudControllerSpaceRepository(config, ccGateway)
+	userRepo := NewCloudControllerUserRepository(config, net.NewUAAGateway(), ccGateway, &testapi.FakeEndpointRepo{})
+
+	space, apiResponse := spaceRepo.Create("my-space", "my-org-guid")
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, space.Name, "my-space")
+	assert.NotEqual(t, space.Guid, "")
+
+	apiResponse = userRepo.SetSpaceRole("my-user-guid", space.Guid, "my-org-guid", cf.SPACE_MANAGER)
+	assert.True(t, apiResponse.IsSuccessful())
+
+	requests := net.DryRunRequests()
+	assert.Equal(t, len(requests), 3)
+	assert.Equal(t, requests[2].Method, "PUT")
+	assert.Equal(t, requests[2].Url, "https://api.example.com/v2/spaces/"+space.Guid+"/managers/my-user-guid")
+}
+
 func createSpacesRepo(t *testing.T, reqs ...testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo SpaceRepository) {
 	ts, handler = testnet.NewTLSServer(t, reqs)
 	org4 := cf.OrganizationFields{}
@@ -28,6 +28,10 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 	app.Usage = cf.Usage
 	app.Version = cf.Version
 	app.Action = helpCommand.Action
+	app.Flags = []cli.Flag{
+		cli.BoolFlag{Name: "dry-run", Usage: "Print the changes a command would make instead of making them"},
+		cli.BoolFlag{Name: "non-interactive", Usage: "Fail instead of prompting for input (default when stdin is not a terminal)"},
+	}
 	app.Commands = []cli.Command{
 		helpCommand,
 		{
@@ -41,7 +45,13 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		{
 			Name:        "app",
 			Description: "Display health and status for app",
-			Usage:       fmt.Sprintf("%s app APP", cf.Name()),
+			Usage:       fmt.Sprintf("%s app APP [--watch [--interval SECONDS] [--count REFRESHES] [--space-apps memory|cpu]]", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "watch", Usage: "Redraw the app status in place until interrupted"},
+				NewIntFlag("interval", "seconds between refreshes with --watch (default 2)"),
+				NewIntFlag("count", "number of refreshes before exiting with --watch (default: until interrupted)"),
+				NewStringFlag("space-apps", "also list every app in the space with --watch, sorted by memory or cpu"),
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("app", c)
 			},
@@ -55,6 +65,28 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("apps", c)
 			},
 		},
+		{
+			Name:        "audit-events",
+			Description: "Show audit events for the targeted space or org",
+			Usage: fmt.Sprintf("%s audit-events [--type TYPES] [--actor ACTOR] [--target TARGET] [--org] [--since TIME] [--until TIME] [--follow]\n\n", cf.Name()) +
+				"   TIME can be a timestamp such as 2014-01-21T15:04:05Z or 2014-01-21, or a duration ago such as 30m, 12h or 7d\n\n" +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s audit-events --type app.create,app.update --since 2h\n", cf.Name()) +
+				fmt.Sprintf("   %s audit-events --org --actor admin --since 2014-01-21\n", cf.Name()) +
+				fmt.Sprintf("   %s audit-events --target my-app --follow", cf.Name()),
+			Flags: []cli.Flag{
+				NewStringFlag("type", "Comma separated event types to show, e.g. app.create,service_instance.delete"),
+				NewStringFlag("actor", "Only show events by this user or client, given by name or guid"),
+				NewStringFlag("target", "Only show events on this app, service instance or other target, given by name or guid"),
+				cli.BoolFlag{Name: "org", Usage: "Show events for the whole targeted org instead of the targeted space"},
+				NewStringFlag("since", "Only show events after this time"),
+				NewStringFlag("until", "Only show events before this time"),
+				cli.BoolFlag{Name: "follow", Usage: "Keep polling for new events"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("audit-events", c)
+			},
+		},
 		{
 			Name:        "auth",
 			Description: "Authenticate user non-interactively",
@@ -84,6 +116,54 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("buildpacks", c)
 			},
 		},
+		{
+			Name:        "copy-app",
+			Description: "Copy an app's package and configuration to another space",
+			Usage: fmt.Sprintf("%s copy-app APP --to-space [ORG/]SPACE [--name NEW_NAME] [--bind-services]\n\n", cf.Name()) +
+				"   The copy is created stopped, with the same memory, disk, instances, buildpack, command and env.\n\n" +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s copy-app my-app --to-space my-org/production --bind-services", cf.Name()),
+			Flags: []cli.Flag{
+				NewStringFlag("to-space", "Space to copy the app to, in the targeted org unless given as ORG/SPACE"),
+				NewStringFlag("name", "Name of the copy, defaults to the name of the app"),
+				cli.BoolFlag{Name: "bind-services", Usage: "Bind the copy to the service instances with the same names in the target space"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("copy-app", c)
+			},
+		},
+		{
+			Name:        "crash-reports",
+			Description: "List crash reports, show one, or redact text from it before sharing",
+			Usage: fmt.Sprintf("%s crash-reports [REPORT] [--redact TEXT]\n\n", cf.Name()) +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s crash-reports\n", cf.Name()) +
+				fmt.Sprintf("   %s crash-reports crash-20140102-030405.log\n", cf.Name()) +
+				fmt.Sprintf("   %s crash-reports --redact my-company --redact my-app crash-20140102-030405.log", cf.Name()),
+			Flags: []cli.Flag{
+				NewStringSliceFlag("redact", "Text to replace with a placeholder in the report, flag can be specified multiple times"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("crash-reports", c)
+			},
+		},
+		{
+			Name:        "create-app-manifest",
+			Description: "Create a manifest from apps that are already pushed",
+			Usage: fmt.Sprintf("%s create-app-manifest APP [APP...] [-p PATH] [-f]\n\n", cf.Name()) +
+				"   The manifest holds the memory, disk quota, instances, buildpack, command,\n" +
+				"   stack, env, routes and bound services of each app, so pushing it\n" +
+				"   reproduces them.\n\n" +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s create-app-manifest web worker -p manifest.yml", cf.Name()),
+			Flags: []cli.Flag{
+				NewStringFlag("p", "Path the manifest is written to (default: APP_manifest.yml for the first APP)"),
+				cli.BoolFlag{Name: "f", Usage: "Overwrite an existing manifest without confirmation"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("create-app-manifest", c)
+			},
+		},
 		{
 			Name:        "create-buildpack",
 			Description: "Create a buildpack",
@@ -329,11 +409,31 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("domains", c)
 			},
 		},
+		{
+			Name:        "download",
+			Description: "Download a file or directory from an app instance",
+			Usage:       fmt.Sprintf("%s download APP REMOTE_PATH [LOCAL_PATH] [-i INSTANCE]", cf.Name()),
+			Flags: []cli.Flag{
+				NewIntFlag("i", "index of the app instance to download from (default 0)"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("download", c)
+			},
+		},
 		{
 			Name:        "env",
 			ShortName:   "e",
 			Description: "Show all env variables for an app",
-			Usage:       fmt.Sprintf("%s env APP", cf.Name()),
+			Usage: fmt.Sprintf("%s env APP [--json] [--reveal] [--export dotenv]\n\n", cf.Name()) +
+				"   Shows the user-provided env variables together with the system-provided ones,\n" +
+				"   such as VCAP_SERVICES and VCAP_APPLICATION, and the running and staging groups.\n\n" +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s env my-app --export dotenv > .env", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "json", Usage: "Print the env as JSON"},
+				cli.BoolFlag{Name: "reveal", Usage: "Show service credentials instead of hiding them"},
+				NewStringFlag("export", "Print the user-provided env variables in the given format, only dotenv is supported"),
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("env", c)
 			},
@@ -350,7 +450,11 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 			Name:        "files",
 			ShortName:   "f",
 			Description: "Print out a list of files in a directory or the contents of a specific file",
-			Usage:       fmt.Sprintf("%s files APP [PATH]", cf.Name()),
+			Usage:       fmt.Sprintf("%s files APP [PATH] [-i INSTANCE] [--tail]", cf.Name()),
+			Flags: []cli.Flag{
+				NewIntFlag("i", "index of the app instance to read from (default 0)"),
+				cli.BoolFlag{Name: "tail", Usage: "Follow the file at PATH as it grows"},
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("files", c)
 			},
@@ -419,6 +523,19 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("map-route", c)
 			},
 		},
+		{
+			Name:        "metrics-exporter",
+			Description: "Serve Prometheus metrics for the instances of apps in the target space",
+			Usage:       fmt.Sprintf("%s metrics-exporter [--listen ADDRESS] [--apps APP1,APP2] [--interval SECONDS]", cf.Name()),
+			Flags: []cli.Flag{
+				NewStringFlag("listen", "address to serve /metrics on (default :9100)"),
+				NewStringFlag("apps", "comma-separated apps to export (default: every app in the space)"),
+				NewIntFlag("interval", "seconds between polls of the Cloud Controller (default 15)"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("metrics-exporter", c)
+			},
+		},
 		{
 			Name:        "org",
 			Description: "Show org info",
@@ -453,13 +570,30 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("passwd", c)
 			},
 		},
+		{
+			Name:        "protect",
+			Description: "Require typing the name to delete an org, a space, or anything on the api endpoint",
+			Usage: fmt.Sprintf("%s protect [--api] [-o ORG] [-s SPACE] [--remove]\n\n", cf.Name()) +
+				"   With no options, lists what is protected. A space is looked up in ORG, or in\n" +
+				"   the targeted org when -o is not given.",
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "api", Usage: "Protect every org and space of the targeted api endpoint"},
+				NewStringFlag("o", "organization"),
+				NewStringFlag("s", "space"),
+				cli.BoolFlag{Name: "remove", Usage: "Remove the protection instead of adding it"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("protect", c)
+			},
+		},
 		{
 			Name:        "push",
 			ShortName:   "p",
 			Description: "Push a new app or sync changes to an existing app",
 			Usage: fmt.Sprintf("%s push APP [-b URL] [-c COMMAND] [-d DOMAIN] [-i NUM_INSTANCES]\n", cf.Name()) +
 				"               [-m MEMORY] [-n HOST] [-p PATH] [-s STACK]\n" +
-				"               [--no-hostname] [--no-route] [--no-start]",
+				"               [--no-hostname] [--no-route] [--no-start] [--show-ignored]\n" +
+				"               [--dereference-symlinks] [--dry-run] [--prune-routes]",
 			Flags: []cli.Flag{
 				NewStringFlag("b", "Custom buildpack URL (e.g. https://github.com/heroku/heroku-buildpack-play.git)"),
 				NewStringFlag("c", "Startup command, set to null to reset to default start command"),
@@ -467,14 +601,18 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				NewStringFlag("i", "Number of instances"),
 				NewStringFlag("m", "Memory limit (e.g. 256M, 1024M, 1G)"),
 				NewStringFlag("n", "Hostname (e.g. my-subdomain)"),
-				NewStringFlag("p", "Path of app directory or zip file"),
+				NewStringFlag("p", "Path of app directory, zip or tar archive, or single file"),
 				NewStringFlag("s", "Stack to use"),
 				NewStringFlag("t", "Start timeout in seconds"),
 				NewStringFlag("f", "Path to manifest"),
 				cli.BoolFlag{Name: "no-manifest", Usage: "Ignore manifest file"},
 				cli.BoolFlag{Name: "no-hostname", Usage: "Map the root domain to this app"},
 				cli.BoolFlag{Name: "no-route", Usage: "Do not map a route to this app"},
+				cli.BoolFlag{Name: "prune-routes", Usage: "Unbind routes of the app that aren't declared by the manifest or flags"},
 				cli.BoolFlag{Name: "no-start", Usage: "Do not start an app after pushing"},
+				cli.BoolFlag{Name: "show-ignored", Usage: "List the files left out of the upload and the rule excluding each"},
+				cli.BoolFlag{Name: "dereference-symlinks", Usage: "Upload what symlinks point to instead of the symlinks themselves"},
+				cli.BoolFlag{Name: "dry-run", Usage: "Show the resolved params, routes, services and files for each app without changing anything"},
 			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("push", c)
@@ -532,11 +670,24 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 			Name:        "restart",
 			ShortName:   "rs",
 			Description: "Restart an app",
-			Usage:       fmt.Sprintf("%s restart APP", cf.Name()),
+			Usage:       fmt.Sprintf("%s restart APP [--rolling [--batch-size INSTANCES] [--pause SECONDS]]", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "rolling", Usage: "Restart instances in batches so the app stays available"},
+				NewIntFlagWithValue("batch-size", "number of instances to restart at a time with --rolling (default 1)", -1),
+				NewIntFlagWithValue("pause", "seconds to wait between batches with --rolling", -1),
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("restart", c)
 			},
 		},
+		{
+			Name:        "restart-app-instance",
+			Description: "Terminate the running application instance at the given index and wait for it to restart",
+			Usage:       fmt.Sprintf("%s restart-app-instance APP INDEX", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("restart-app-instance", c)
+			},
+		},
 		{
 			Name:        "routes",
 			ShortName:   "r",
@@ -549,10 +700,13 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		{
 			Name:        "scale",
 			Description: "Change the instance count and memory limit for an app",
-			Usage:       fmt.Sprintf("%s scale APP -i INSTANCES -m MEMORY", cf.Name()),
+			Usage:       fmt.Sprintf("%s scale APP -i INSTANCES -m MEMORY [--rolling [--batch-size INSTANCES] [--pause SECONDS]]", cf.Name()),
 			Flags: []cli.Flag{
 				NewIntFlagWithValue("i", "number of instances", -1),
 				NewStringFlag("m", "memory limit (e.g. 256M, 1024M, 1G)"),
+				cli.BoolFlag{Name: "rolling", Usage: "Restart instances in batches after changing memory so the app stays available"},
+				NewIntFlagWithValue("batch-size", "number of instances to restart at a time with --rolling (default 1)", -1),
+				NewIntFlagWithValue("pause", "seconds to wait between batches with --rolling", -1),
 			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("scale", c)
@@ -595,7 +749,19 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 			Name:        "set-env",
 			ShortName:   "se",
 			Description: "Set an env variable for an app",
-			Usage:       fmt.Sprintf("%s set-env APP NAME VALUE", cf.Name()),
+			Usage: fmt.Sprintf("%s set-env APP NAME VALUE\n", cf.Name()) +
+				fmt.Sprintf("   %s set-env APP --from-file FILE [--replace] [--reveal] [-f]\n\n", cf.Name()) +
+				"   With --from-file, every variable in the .env file is set in a single update\n" +
+				"   after showing what changes and asking for confirmation. Values are hidden\n" +
+				"   unless --reveal is given. Variables not in the file are kept, unless --replace is given.\n\n" +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s set-env my-app --from-file .env", cf.Name()),
+			Flags: []cli.Flag{
+				NewStringFlag("from-file", "Set the variables in a .env file"),
+				cli.BoolFlag{Name: "replace", Usage: "Remove the variables that are not in the file"},
+				cli.BoolFlag{Name: "reveal", Usage: "Show the values of the variables that change"},
+				cli.BoolFlag{Name: "f", Usage: "Apply the changes without confirmation"},
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("set-env", c)
 			},
@@ -795,6 +961,19 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("update-user-provided-service", c)
 			},
 		},
+		{
+			Name:        "validate-manifest",
+			Description: "Check a manifest and the manifests it inherits from for problems",
+			Usage: fmt.Sprintf("%s validate-manifest [-f MANIFEST]\n\n", cf.Name()) +
+				"   Every problem is listed with its file, line and app, and the command exits\n" +
+				"   with a nonzero status if there are any.",
+			Flags: []cli.Flag{
+				NewStringFlag("f", "Path to manifest"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("validate-manifest", c)
+			},
+		},
 	}
 	return
 }
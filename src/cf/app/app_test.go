@@ -10,6 +10,7 @@ import (
 	"strings"
 	testassert "testhelpers/assert"
 	testconfig "testhelpers/configuration"
+	testcrash "testhelpers/crash"
 	testmanifest "testhelpers/manifest"
 	testreq "testhelpers/requirements"
 	testterm "testhelpers/terminal"
@@ -18,7 +19,7 @@ import (
 
 func availableCmdNames() (names []string) {
 	reqFactory := &testreq.FakeReqFactory{}
-	cmdRunner := commands.NewRunner(nil, reqFactory)
+	cmdRunner := commands.NewRunner(nil, nil, reqFactory)
 	app, _ := NewApp(cmdRunner)
 
 	for _, cliCmd := range app.Commands {
@@ -51,6 +52,7 @@ func TestCommands(t *testing.T) {
 		config := &configuration.Configuration{}
 		configRepo := testconfig.FakeConfigRepository{}
 		manifestRepo := &testmanifest.FakeManifestRepository{}
+		crashRepo := &testcrash.FakeCrashReportRepository{}
 
 		repoLocator := api.NewRepositoryLocator(config, configRepo, map[string]net.Gateway{
 			"auth":             net.NewUAAGateway(),
@@ -58,7 +60,7 @@ func TestCommands(t *testing.T) {
 			"uaa":              net.NewUAAGateway(),
 		})
 
-		cmdFactory := commands.NewFactory(ui, config, configRepo, manifestRepo, repoLocator)
+		cmdFactory := commands.NewFactory(ui, config, configRepo, manifestRepo, crashRepo, repoLocator)
 		cmdRunner := &FakeRunner{cmdFactory: cmdFactory, t: t}
 		app, _ := NewApp(cmdRunner)
 		app.Run([]string{"", cmdName})
@@ -69,7 +71,7 @@ func TestCommands(t *testing.T) {
 
 func TestUsageIncludesCommandName(t *testing.T) {
 	reqFactory := &testreq.FakeReqFactory{}
-	cmdRunner := commands.NewRunner(nil, reqFactory)
+	cmdRunner := commands.NewRunner(nil, nil, reqFactory)
 	app, _ := NewApp(cmdRunner)
 
 	for _, cmd := range app.Commands {
@@ -79,7 +81,7 @@ func TestUsageIncludesCommandName(t *testing.T) {
 
 func TestPushCommandHelpOutput(t *testing.T) {
 	reqFactory := &testreq.FakeReqFactory{}
-	cmdRunner := commands.NewRunner(nil, reqFactory)
+	cmdRunner := commands.NewRunner(nil, nil, reqFactory)
 	app, _ := NewApp(cmdRunner)
 
 	var updateCommand, pushCommand cli.Command
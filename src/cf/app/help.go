@@ -25,11 +25,22 @@ var appHelpTemplate = `{{.Title "NAME:"}}
    {{range .Flags}}{{.}}
    {{end}}
 {{.Title "ENVIRONMENT VARIABLES:"}}
+   CF_ALLOW_PROTECTED_DESTRUCTIVE=true - allow -f when deleting protected orgs, spaces, apps, services or domains (see protect)
    CF_STAGING_TIMEOUT=15 max wait time for buildpack staging, in minutes
    CF_STARTUP_TIMEOUT=5 max wait time for app instance startup, in minutes
    CF_TRACE=true - print API request diagnostics to stdout
    CF_TRACE=path/to/trace.log - append API request diagnostics to a log file
    HTTP_PROXY=http://proxy.example.com:8080 - enable http proxying for API requests
+
+{{.Title "EXIT CODES:"}}
+   0 success
+   1 command failed
+   2 incorrect usage
+   3 not logged in
+   4 resource not found
+   5 permission denied
+   6 server error
+   7 timed out
 `
 
 type groupedCommands struct {
@@ -103,6 +114,7 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 					newCmdPresenter(app, maxNameLen, "logout"),
 					newCmdPresenter(app, maxNameLen, "passwd"),
 					newCmdPresenter(app, maxNameLen, "target"),
+					newCmdPresenter(app, maxNameLen, "protect"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "api"),
 					newCmdPresenter(app, maxNameLen, "auth"),
@@ -119,20 +131,27 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 					newCmdPresenter(app, maxNameLen, "scale"),
 					newCmdPresenter(app, maxNameLen, "delete"),
 					newCmdPresenter(app, maxNameLen, "rename"),
+					newCmdPresenter(app, maxNameLen, "copy-app"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "start"),
 					newCmdPresenter(app, maxNameLen, "stop"),
 					newCmdPresenter(app, maxNameLen, "restart"),
+					newCmdPresenter(app, maxNameLen, "restart-app-instance"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "events"),
 					newCmdPresenter(app, maxNameLen, "files"),
+					newCmdPresenter(app, maxNameLen, "download"),
 					newCmdPresenter(app, maxNameLen, "logs"),
+					newCmdPresenter(app, maxNameLen, "metrics-exporter"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "env"),
 					newCmdPresenter(app, maxNameLen, "set-env"),
 					newCmdPresenter(app, maxNameLen, "unset-env"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "stacks"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "validate-manifest"),
+					newCmdPresenter(app, maxNameLen, "create-app-manifest"),
 				},
 			},
 		}, {
@@ -254,6 +273,8 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 			CommandSubGroups: [][]cmdPresenter{
 				{
 					newCmdPresenter(app, maxNameLen, "curl"),
+					newCmdPresenter(app, maxNameLen, "crash-reports"),
+					newCmdPresenter(app, maxNameLen, "audit-events"),
 				},
 			},
 		},
@@ -4,23 +4,10 @@ import (
 	"crypto/sha1"
 	"fileutils"
 	"fmt"
-	"glob"
 	"os"
-	"path"
 	"path/filepath"
-	"strings"
 )
 
-var DefaultIgnoreFiles = []string{
-	".cfignore",
-	".gitignore",
-	".git",
-	".svn",
-	"_darcs",
-}
-
-type Globs []glob.Glob
-
 func AppFilesInDir(dir string) (appFiles []AppFileFields, err error) {
 	dir, err = filepath.Abs(dir)
 	if err != nil {
@@ -36,9 +23,19 @@ func AppFilesInDir(dir string) (appFiles []AppFileFields, err error) {
 
 		h := sha1.New()
 
-		err = fileutils.CopyPathToWriter(fullPath, h)
-		if err != nil {
-			return
+		// a symlink is uploaded as the path it points to
+		if fileInfo.Mode()&os.ModeSymlink != 0 {
+			var target string
+			target, err = os.Readlink(fullPath)
+			if err != nil {
+				return
+			}
+			h.Write([]byte(target))
+		} else {
+			err = fileutils.CopyPathToWriter(fullPath, h)
+			if err != nil {
+				return
+			}
 		}
 
 		sha1Bytes := h.Sum(nil)
@@ -84,29 +81,68 @@ func CountFiles(directory string) uint64 {
 
 type walkAppFileFunc func(fileName, fullPath string) (err error)
 
+type walkAppEntryFunc func(fileName, fullPath string, fileInfo os.FileInfo) (err error)
+
+// WalkAppFiles calls onEachFile for every regular file and symlink of dir
+// that is not ignored by the defaults or by a .cfignore file.
 func WalkAppFiles(dir string, onEachFile walkAppFileFunc) (err error) {
-	exclusions := readCfIgnore(dir)
+	return WalkAppEntries(dir, func(fileName, fullPath string, fileInfo os.FileInfo) error {
+		if fileInfo.IsDir() {
+			return nil
+		}
+		return onEachFile(fileName, fullPath)
+	})
+}
+
+// WalkAppEntries is like WalkAppFiles, but also calls onEachEntry for the
+// directories that are not ignored. fileInfo describes the entry itself,
+// not what it links to.
+func WalkAppEntries(dir string, onEachEntry walkAppEntryFunc) (err error) {
+	return walkAppDir(dir, onEachEntry, func(string, ignoreRule) {})
+}
+
+// IgnoredAppFiles lists what WalkAppFiles leaves out of dir, along with the
+// rule that excludes it. Directories are listed once, with a trailing slash,
+// rather than file by file.
+func IgnoredAppFiles(dir string) (ignored []IgnoredAppFile, err error) {
+	err = walkAppDir(dir, func(string, string, os.FileInfo) error { return nil }, func(relPath string, rule ignoreRule) {
+		ignored = append(ignored, IgnoredAppFile{Path: relPath, Rule: rule.String()})
+	})
+	return
+}
+
+func walkAppDir(dir string, onEachEntry walkAppEntryFunc, onIgnored func(relPath string, rule ignoreRule)) (err error) {
+	ignores := cfIgnore(defaultIgnoreRules())
+	ignores = append(ignores, readCfIgnore(dir, "")...)
+
 	walkFunc := func(fullPath string, f os.FileInfo, inErr error) (err error) {
 		err = inErr
 		if err != nil {
 			return
 		}
 
-		if f.IsDir() {
+		fileRelativePath, _ := filepath.Rel(dir, fullPath)
+		if fileRelativePath == "." {
 			return
 		}
 
-		if !f.Mode().IsRegular() {
+		fileRelativeUnixPath := filepath.ToSlash(fileRelativePath)
+		if rule, ignored := ignores.match(fileRelativeUnixPath, f.IsDir()); ignored {
+			if f.IsDir() {
+				onIgnored(fileRelativeUnixPath+"/", rule)
+				return filepath.SkipDir
+			}
+			onIgnored(fileRelativeUnixPath, rule)
 			return
 		}
 
-		fileRelativePath, _ := filepath.Rel(dir, fullPath)
-		fileRelativeUnixPath := filepath.ToSlash(fileRelativePath)
-		if fileShouldBeIgnored(exclusions, fileRelativeUnixPath) {
+		if f.IsDir() {
+			ignores = append(ignores, readCfIgnore(fullPath, fileRelativeUnixPath)...)
+		} else if !f.Mode().IsRegular() && f.Mode()&os.ModeSymlink == 0 {
 			return
 		}
 
-		err = onEachFile(fileRelativePath, fullPath)
+		err = onEachEntry(fileRelativePath, fullPath, f)
 
 		return
 	}
@@ -114,50 +150,3 @@ func WalkAppFiles(dir string, onEachFile walkAppFileFunc) (err error) {
 	err = filepath.Walk(dir, walkFunc)
 	return
 }
-
-func fileShouldBeIgnored(exclusions Globs, relPath string) bool {
-	for _, exclusion := range exclusions {
-		if strings.HasPrefix(exclusion.Pattern, "/") && !strings.HasPrefix(relPath, "/") {
-			relPath = "/" + relPath
-		}
-
-		if exclusion.Match(relPath) {
-			return true
-		}
-	}
-	return false
-}
-
-func readCfIgnore(dir string) (exclusions Globs) {
-	cfIgnore, err := os.Open(filepath.Join(dir, ".cfignore"))
-	if err != nil {
-		return
-	}
-
-	ignores := strings.Split(fileutils.ReadFile(cfIgnore), "\n")
-	ignores = append(DefaultIgnoreFiles, ignores...)
-
-	for _, pattern := range ignores {
-		pattern = strings.TrimSpace(pattern)
-		if pattern == "" {
-			continue
-		}
-		pattern = path.Clean(pattern)
-		patternExclusions := exclusionsForPattern(pattern)
-		exclusions = append(exclusions, patternExclusions...)
-	}
-	return
-}
-
-func exclusionsForPattern(cfignorePattern string) (exclusions Globs) {
-	exclusions = append(exclusions, glob.MustCompileGlob(cfignorePattern))
-	exclusions = append(exclusions, glob.MustCompileGlob(path.Join(cfignorePattern, "*")))
-	exclusions = append(exclusions, glob.MustCompileGlob(path.Join(cfignorePattern, "**", "*")))
-
-	if !strings.HasPrefix(cfignorePattern, "/") {
-		exclusions = append(exclusions, glob.MustCompileGlob(path.Join("**", cfignorePattern)))
-		exclusions = append(exclusions, glob.MustCompileGlob(path.Join("**", cfignorePattern, "*")))
-		exclusions = append(exclusions, glob.MustCompileGlob(path.Join("**", cfignorePattern, "**", "*")))
-	}
-	return
-}
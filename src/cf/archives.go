@@ -0,0 +1,387 @@
+package cf
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fileutils"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+	tarMagic   = []byte("ustar")
+)
+
+const tarMagicOffset = 257
+
+// IsTarArchive tells whether path is a tar archive, either plain or
+// compressed with gzip or bzip2. It looks at the contents rather than the
+// extension, the same way zip archives are detected.
+func IsTarArchive(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	reader, err := decompressedReader(file)
+	if err != nil {
+		return false
+	}
+
+	header := make([]byte, tarMagicOffset+len(tarMagic))
+	_, err = io.ReadFull(reader, header)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(header[tarMagicOffset:], tarMagic)
+}
+
+// ExtractTarArchive extracts the tar archive at path into destDir, keeping
+// file modes and symlinks. Entries that would end up outside of destDir,
+// either directly or through a symlink, fail the extraction.
+func ExtractTarArchive(path string, destDir string) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	reader, err := decompressedReader(file)
+	if err != nil {
+		return
+	}
+
+	destDir, err = filepath.Abs(destDir)
+	if err != nil {
+		return
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		var header *tar.Header
+		header, err = tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return
+		}
+
+		err = extractTarEntry(tarReader, header, destDir)
+		if err != nil {
+			return
+		}
+	}
+
+	return checkSymlinksInDir(destDir)
+}
+
+func extractTarEntry(tarReader *tar.Reader, header *tar.Header, destDir string) (err error) {
+	destPath, err := ArchiveEntryPath(destDir, header.Name)
+	if err != nil {
+		return
+	}
+
+	mode := header.FileInfo().Mode()
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return extractDirEntry(destDir, destPath, mode.Perm())
+	case tar.TypeReg, tar.TypeRegA:
+		err = prepareEntryParent(destDir, destPath)
+		if err != nil {
+			return
+		}
+		return writeArchiveFile(tarReader, destPath, mode.Perm())
+	case tar.TypeSymlink:
+		err = prepareEntryParent(destDir, destPath)
+		if err != nil {
+			return
+		}
+		err = removeExistingEntry(destPath)
+		if err != nil {
+			return
+		}
+		return os.Symlink(header.Linkname, destPath)
+	case tar.TypeLink:
+		var linkedPath string
+		linkedPath, err = ArchiveEntryPath(destDir, header.Linkname)
+		if err != nil {
+			return
+		}
+
+		err = prepareEntryParent(destDir, destPath)
+		if err != nil {
+			return
+		}
+
+		// the linked entry may itself be a symlink leading out of destDir
+		var resolvedLinkedPath, resolvedDestDir string
+		resolvedLinkedPath, err = filepath.EvalSymlinks(linkedPath)
+		if err != nil {
+			return
+		}
+		resolvedDestDir, err = filepath.EvalSymlinks(destDir)
+		if err != nil {
+			return
+		}
+		if !isInsideDir(resolvedDestDir, resolvedLinkedPath) {
+			err = errors.New(fmt.Sprintf("Archive entry %s links outside of the app directory", header.Name))
+			return
+		}
+
+		var linkedFile *os.File
+		linkedFile, err = os.Open(linkedPath)
+		if err != nil {
+			return
+		}
+		defer linkedFile.Close()
+		return writeArchiveFile(linkedFile, destPath, mode.Perm())
+	}
+
+	// devices, fifos and the like have no place in an app
+	return
+}
+
+// ExtractZipArchive extracts the zip archive at path into destDir the same
+// way ExtractTarArchive does, restoring directories, modes and symlinks.
+func ExtractZipArchive(path string, destDir string) (err error) {
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		return
+	}
+	defer zipReader.Close()
+
+	destDir, err = filepath.Abs(destDir)
+	if err != nil {
+		return
+	}
+
+	for _, file := range zipReader.File {
+		err = extractZipEntry(file, destDir)
+		if err != nil {
+			return
+		}
+	}
+
+	return checkSymlinksInDir(destDir)
+}
+
+func extractZipEntry(file *zip.File, destDir string) (err error) {
+	destPath, err := ArchiveEntryPath(destDir, file.Name)
+	if err != nil {
+		return
+	}
+
+	mode := file.Mode()
+	if mode.IsDir() {
+		return extractDirEntry(destDir, destPath, mode.Perm())
+	}
+
+	err = prepareEntryParent(destDir, destPath)
+	if err != nil {
+		return
+	}
+
+	contents, err := file.Open()
+	if err != nil {
+		return
+	}
+	defer contents.Close()
+
+	if mode&os.ModeSymlink != 0 {
+		var target []byte
+		target, err = ioutil.ReadAll(contents)
+		if err != nil {
+			return
+		}
+
+		err = removeExistingEntry(destPath)
+		if err != nil {
+			return
+		}
+		return os.Symlink(filepath.FromSlash(string(target)), destPath)
+	}
+
+	return writeArchiveFile(contents, destPath, mode.Perm())
+}
+
+// ArchiveEntryPath returns where the archive entry called name belongs in
+// destDir, or an error when the name would put it outside of destDir.
+func ArchiveEntryPath(destDir string, name string) (destPath string, err error) {
+	cleanName := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleanName) || cleanName == ".." || strings.HasPrefix(cleanName, ".."+string(filepath.Separator)) {
+		err = errors.New(fmt.Sprintf("Archive entry %s would be extracted outside of the app directory", name))
+		return
+	}
+
+	destPath = filepath.Join(destDir, cleanName)
+	return
+}
+
+// prepareEntryParent creates the directory an entry goes in, making sure no
+// symlink extracted earlier leads it outside of destDir.
+func prepareEntryParent(destDir string, destPath string) (err error) {
+	return makeEntryDirs(destDir, filepath.Dir(destPath), destPath, true)
+}
+
+// extractDirEntry creates the directory of a directory entry and sets its
+// mode. Since both follow symlinks, neither the directory nor any directory
+// leading to it may be one.
+func extractDirEntry(destDir string, destPath string, mode os.FileMode) (err error) {
+	err = makeEntryDirs(destDir, destPath, destPath, false)
+	if err != nil {
+		return
+	}
+	return os.Chmod(destPath, mode|0700)
+}
+
+// makeEntryDirs creates dir and the directories leading to it one at a time,
+// checking each before going into it, so that a symlink extracted earlier
+// can't have directories created outside of destDir. Symlinks that stay
+// inside destDir are followed only when allowSymlinks is set.
+func makeEntryDirs(destDir string, dir string, destPath string, allowSymlinks bool) (err error) {
+	resolvedDestDir, err := filepath.EvalSymlinks(destDir)
+	if err != nil {
+		return
+	}
+
+	relDir, err := filepath.Rel(destDir, dir)
+	if err != nil || relDir == "." {
+		return
+	}
+
+	currentDir := destDir
+	for _, part := range strings.Split(relDir, string(filepath.Separator)) {
+		currentDir = filepath.Join(currentDir, part)
+
+		err = os.Mkdir(currentDir, 0755)
+		if err != nil && !os.IsExist(err) {
+			return
+		}
+
+		var info os.FileInfo
+		info, err = os.Lstat(currentDir)
+		if err != nil {
+			return
+		}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			if !info.IsDir() {
+				err = errors.New(fmt.Sprintf("Archive entry %s is inside %s, which is not a directory", entryName(destDir, destPath), entryName(destDir, currentDir)))
+				return
+			}
+			continue
+		}
+
+		if !allowSymlinks {
+			err = errors.New(fmt.Sprintf("Archive entry %s would be extracted through the symlink %s", entryName(destDir, destPath), entryName(destDir, currentDir)))
+			return
+		}
+
+		var resolvedDir string
+		resolvedDir, err = filepath.EvalSymlinks(currentDir)
+		if err != nil || !isInsideDir(resolvedDestDir, resolvedDir) {
+			err = errors.New(fmt.Sprintf("Archive entry %s would be extracted outside of the app directory", entryName(destDir, destPath)))
+			return
+		}
+	}
+	return
+}
+
+func entryName(destDir string, destPath string) string {
+	relPath, _ := filepath.Rel(destDir, destPath)
+	return filepath.ToSlash(relPath)
+}
+
+func writeArchiveFile(src io.Reader, destPath string, mode os.FileMode) (err error) {
+	err = removeExistingEntry(destPath)
+	if err != nil {
+		return
+	}
+
+	err = fileutils.CopyReaderToPath(src, destPath)
+	if err != nil {
+		return
+	}
+	return os.Chmod(destPath, mode)
+}
+
+// removeExistingEntry makes sure a later entry with the same name replaces an
+// earlier symlink instead of writing through it.
+func removeExistingEntry(destPath string) (err error) {
+	info, err := os.Lstat(destPath)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil || info.IsDir() {
+		return
+	}
+	return os.Remove(destPath)
+}
+
+// checkSymlinksInDir fails when a symlink in dir points outside of it, since
+// the files it leads to would be uploaded with the app.
+func checkSymlinksInDir(dir string) (err error) {
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return
+	}
+
+	return filepath.Walk(dir, func(fullPath string, info os.FileInfo, inErr error) (err error) {
+		if inErr != nil {
+			return inErr
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return
+		}
+
+		target, evalErr := filepath.EvalSymlinks(fullPath)
+		if evalErr != nil {
+			// dangling links point at nothing that could be uploaded
+			return
+		}
+
+		if !isInsideDir(resolvedDir, target) {
+			relPath, _ := filepath.Rel(dir, fullPath)
+			err = errors.New(fmt.Sprintf("Archive entry %s links outside of the app directory", filepath.ToSlash(relPath)))
+		}
+		return
+	})
+}
+
+func isInsideDir(dir string, path string) bool {
+	relPath, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
+
+func decompressedReader(file io.Reader) (reader io.Reader, err error) {
+	bufferedReader := bufio.NewReader(file)
+	magic, _ := bufferedReader.Peek(len(bzip2Magic))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		reader, err = gzip.NewReader(bufferedReader)
+	case bytes.HasPrefix(magic, bzip2Magic):
+		reader = bzip2.NewReader(bufferedReader)
+	default:
+		reader = bufferedReader
+	}
+	return
+}
@@ -0,0 +1,307 @@
+package cf
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	mode     int64
+	contents string
+}
+
+func TestIsTarArchive(t *testing.T) {
+	workingDir, err := os.Getwd()
+	assert.NoError(t, err)
+
+	assert.True(t, IsTarArchive(filepath.Join(workingDir, "../fixtures/example-app.tar.gz")))
+	assert.True(t, IsTarArchive(filepath.Join(workingDir, "../fixtures/example-app.tar.bz2")))
+	assert.False(t, IsTarArchive(filepath.Join(workingDir, "../fixtures/example-app.zip")))
+	assert.False(t, IsTarArchive(filepath.Join(workingDir, "../fixtures/hello_world.txt")))
+	assert.False(t, IsTarArchive(filepath.Join(workingDir, "../fixtures/example-app")))
+}
+
+func TestExtractTarArchiveFixtures(t *testing.T) {
+	workingDir, err := os.Getwd()
+	assert.NoError(t, err)
+
+	for _, fixture := range []string{"example-app.tar.gz", "example-app.tar.bz2"} {
+		fileutils.TempDir("extract_test", func(destDir string, err error) {
+			assert.NoError(t, err)
+
+			err = ExtractTarArchive(filepath.Join(workingDir, "../fixtures", fixture), destDir)
+			assert.NoError(t, err)
+
+			appFiles, err := AppFilesInDir(destDir)
+			assert.NoError(t, err)
+			assert.Equal(t, len(appFiles), 5)
+
+			if runtime.GOOS != "windows" {
+				fileInfo, err := os.Stat(filepath.Join(destDir, "app.rb"))
+				assert.NoError(t, err)
+				assert.Equal(t, fileInfo.Mode().Perm(), os.FileMode(0775))
+			}
+		})
+	}
+}
+
+func TestExtractTarArchiveKeepsModesAndSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	withTarArchive(t, []tarEntry{
+		{name: "bin/", typeflag: tar.TypeDir, mode: 0755},
+		{name: "bin/run", typeflag: tar.TypeReg, mode: 0750, contents: "#!/bin/sh"},
+		{name: "lib/current", typeflag: tar.TypeSymlink, linkname: "../bin"},
+		{name: "config.yml", typeflag: tar.TypeReg, mode: 0600, contents: "key: value"},
+		{name: "config-copy.yml", typeflag: tar.TypeLink, linkname: "config.yml", mode: 0644},
+	}, func(archivePath string) {
+		fileutils.TempDir("extract_test", func(destDir string, err error) {
+			err = ExtractTarArchive(archivePath, destDir)
+			assert.NoError(t, err)
+
+			fileInfo, err := os.Stat(filepath.Join(destDir, "bin/run"))
+			assert.NoError(t, err)
+			assert.Equal(t, fileInfo.Mode().Perm(), os.FileMode(0750))
+
+			fileInfo, err = os.Stat(filepath.Join(destDir, "config.yml"))
+			assert.NoError(t, err)
+			assert.Equal(t, fileInfo.Mode().Perm(), os.FileMode(0600))
+
+			linkname, err := os.Readlink(filepath.Join(destDir, "lib/current"))
+			assert.NoError(t, err)
+			assert.Equal(t, linkname, "../bin")
+
+			contents, err := ioutil.ReadFile(filepath.Join(destDir, "config-copy.yml"))
+			assert.NoError(t, err)
+			assert.Equal(t, string(contents), "key: value")
+		})
+	})
+}
+
+func TestExtractTarArchiveRejectsPathTraversal(t *testing.T) {
+	entriesByCase := [][]tarEntry{
+		{{name: "../evil.sh", typeflag: tar.TypeReg, mode: 0755, contents: "rm -rf /"}},
+		{{name: "app/../../evil.sh", typeflag: tar.TypeReg, mode: 0755, contents: "rm -rf /"}},
+		{{name: "/tmp/evil.sh", typeflag: tar.TypeReg, mode: 0755, contents: "rm -rf /"}},
+		{{name: "passwd", typeflag: tar.TypeLink, linkname: "../../etc/passwd"}},
+	}
+
+	for _, entries := range entriesByCase {
+		withTarArchive(t, entries, func(archivePath string) {
+			fileutils.TempDir("extract_test", func(destDir string, err error) {
+				err = ExtractTarArchive(archivePath, destDir)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "outside of the app directory")
+			})
+		})
+	}
+}
+
+func TestExtractTarArchiveRejectsSymlinksLeavingTheApp(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	entriesByCase := [][]tarEntry{
+		{{name: "secrets", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"}},
+		{{name: "lib/up", typeflag: tar.TypeSymlink, linkname: "../.."}},
+		{
+			{name: "escape", typeflag: tar.TypeSymlink, linkname: ".."},
+			{name: "escape/evil.sh", typeflag: tar.TypeReg, mode: 0755, contents: "rm -rf /"},
+		},
+		{
+			{name: "run.sh", typeflag: tar.TypeSymlink, linkname: "../outside.sh"},
+			{name: "run.sh", typeflag: tar.TypeReg, mode: 0755, contents: "echo replaced"},
+			{name: "lib/up", typeflag: tar.TypeSymlink, linkname: "../.."},
+		},
+	}
+
+	for _, entries := range entriesByCase {
+		withTarArchive(t, entries, func(archivePath string) {
+			fileutils.TempDir("extract_test", func(destDir string, err error) {
+				err = ExtractTarArchive(archivePath, destDir)
+				assert.Error(t, err)
+
+				_, statErr := os.Stat(filepath.Join(filepath.Dir(destDir), "outside.sh"))
+				assert.True(t, os.IsNotExist(statErr))
+				_, statErr = os.Stat(filepath.Join(filepath.Dir(destDir), "evil.sh"))
+				assert.True(t, os.IsNotExist(statErr))
+			})
+		})
+	}
+}
+
+func TestExtractTarArchiveRejectsDirectoriesBeneathSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	fileutils.TempDir("extract_test", func(outsideDir string, err error) {
+		assert.NoError(t, err)
+
+		entries := []tarEntry{
+			{name: "evil", typeflag: tar.TypeSymlink, linkname: outsideDir},
+			{name: "evil/.ssh", typeflag: tar.TypeDir, mode: 0777},
+		}
+		withTarArchive(t, entries, func(archivePath string) {
+			fileutils.TempDir("extract_test", func(destDir string, err error) {
+				err = ExtractTarArchive(archivePath, destDir)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "evil/.ssh")
+
+				_, statErr := os.Stat(filepath.Join(outsideDir, ".ssh"))
+				assert.True(t, os.IsNotExist(statErr))
+			})
+		})
+	})
+}
+
+func TestExtractZipArchiveRestoresModesDirectoriesAndSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	withSymlinkedApp(t, func(appDir string) {
+		fileutils.TempFile("zip_test", func(zipFile *os.File, err error) {
+			err = ApplicationZipper{}.WriteZip(appDir, zipFile, ZipOptions{})
+			assert.NoError(t, err)
+
+			fileutils.TempDir("extract_test", func(destDir string, err error) {
+				err = ExtractZipArchive(zipFile.Name(), destDir)
+				assert.NoError(t, err)
+
+				fileInfo, err := os.Stat(filepath.Join(destDir, "bin/run"))
+				assert.NoError(t, err)
+				assert.Equal(t, fileInfo.Mode().Perm(), os.FileMode(0755))
+
+				linkname, err := os.Readlink(filepath.Join(destDir, "node_modules/.bin/run"))
+				assert.NoError(t, err)
+				assert.Equal(t, linkname, "../../bin/run")
+
+				linkname, err = os.Readlink(filepath.Join(destDir, "dangling"))
+				assert.NoError(t, err)
+				assert.Equal(t, linkname, "missing")
+			})
+		})
+	})
+}
+
+func TestExtractZipArchiveRejectsPathTraversal(t *testing.T) {
+	entriesByCase := []map[string]string{
+		{"../evil.sh": "rm -rf /"},
+		{"/tmp/evil.sh": "rm -rf /"},
+	}
+
+	for _, entries := range entriesByCase {
+		fileutils.TempFile("zip_test", func(zipFile *os.File, err error) {
+			writer := zip.NewWriter(zipFile)
+			for name, contents := range entries {
+				part, err := writer.Create(name)
+				assert.NoError(t, err)
+				part.Write([]byte(contents))
+			}
+			assert.NoError(t, writer.Close())
+
+			fileutils.TempDir("extract_test", func(destDir string, err error) {
+				err = ExtractZipArchive(zipFile.Name(), destDir)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "outside of the app directory")
+			})
+		})
+	}
+}
+
+func TestExtractZipArchiveRejectsSymlinksLeavingTheApp(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	fileutils.TempFile("zip_test", func(zipFile *os.File, err error) {
+		writer := zip.NewWriter(zipFile)
+		header := &zip.FileHeader{Name: "secrets"}
+		header.SetMode(os.ModeSymlink | 0777)
+		part, err := writer.CreateHeader(header)
+		assert.NoError(t, err)
+		part.Write([]byte("/etc/passwd"))
+		assert.NoError(t, writer.Close())
+
+		fileutils.TempDir("extract_test", func(destDir string, err error) {
+			err = ExtractZipArchive(zipFile.Name(), destDir)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "links outside of the app directory")
+		})
+	})
+}
+
+func TestExtractZipArchiveRejectsDirectoriesBeneathSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	fileutils.TempDir("extract_test", func(outsideDir string, err error) {
+		assert.NoError(t, err)
+
+		fileutils.TempFile("zip_test", func(zipFile *os.File, err error) {
+			writer := zip.NewWriter(zipFile)
+			header := &zip.FileHeader{Name: "evil"}
+			header.SetMode(os.ModeSymlink | 0777)
+			part, err := writer.CreateHeader(header)
+			assert.NoError(t, err)
+			part.Write([]byte(outsideDir))
+
+			header = &zip.FileHeader{Name: "evil/.ssh/"}
+			header.SetMode(os.ModeDir | 0777)
+			_, err = writer.CreateHeader(header)
+			assert.NoError(t, err)
+			assert.NoError(t, writer.Close())
+
+			fileutils.TempDir("extract_test", func(destDir string, err error) {
+				err = ExtractZipArchive(zipFile.Name(), destDir)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "evil/.ssh")
+
+				_, statErr := os.Stat(filepath.Join(outsideDir, ".ssh"))
+				assert.True(t, os.IsNotExist(statErr))
+			})
+		})
+	})
+}
+
+func withTarArchive(t *testing.T, entries []tarEntry, cb func(archivePath string)) {
+	fileutils.TempFile("tar_test", func(archiveFile *os.File, err error) {
+		assert.NoError(t, err)
+
+		gzipWriter := gzip.NewWriter(archiveFile)
+		tarWriter := tar.NewWriter(gzipWriter)
+		for _, entry := range entries {
+			err = tarWriter.WriteHeader(&tar.Header{
+				Name:     entry.name,
+				Typeflag: entry.typeflag,
+				Linkname: entry.linkname,
+				Mode:     entry.mode,
+				Size:     int64(len(entry.contents)),
+			})
+			assert.NoError(t, err)
+
+			_, err = tarWriter.Write([]byte(entry.contents))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, tarWriter.Close())
+		assert.NoError(t, gzipWriter.Close())
+
+		cb(archiveFile.Name())
+	})
+}
@@ -0,0 +1,163 @@
+package cf
+
+import (
+	"bufio"
+	"fmt"
+	"glob"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultIgnoreFiles are left out of every app, whether it has a .cfignore
+// or not. A .cfignore can still bring them back with a negated pattern.
+var DefaultIgnoreFiles = []string{
+	".cfignore",
+	".gitignore",
+	".git",
+	".svn",
+	"_darcs",
+}
+
+type IgnoredAppFile struct {
+	Path string
+	Rule string
+}
+
+// ignoreRule is one line of a .cfignore, read the way git reads a
+// .gitignore.
+type ignoreRule struct {
+	dir     string // directory holding the .cfignore, relative to the app
+	source  string
+	line    int
+	text    string
+	glob    glob.Glob
+	negated bool
+	dirOnly bool
+}
+
+func (rule ignoreRule) String() string {
+	if rule.line == 0 {
+		return fmt.Sprintf("%s: %s", rule.source, rule.text)
+	}
+	return fmt.Sprintf("%s:%d: %s", rule.source, rule.line, rule.text)
+}
+
+// matches tells whether the rule applies to relPath, a slash separated path
+// relative to the app.
+func (rule ignoreRule) matches(relPath string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+
+	if rule.dir != "" {
+		if !strings.HasPrefix(relPath, rule.dir+"/") {
+			return false
+		}
+		relPath = strings.TrimPrefix(relPath, rule.dir+"/")
+	}
+
+	return rule.glob.Match(relPath)
+}
+
+type cfIgnore []ignoreRule
+
+// match returns the rule deciding whether relPath is ignored. As with git,
+// the last matching rule wins, and rules of nested .cfignore files come after
+// the ones of the directories above them.
+func (ignores cfIgnore) match(relPath string, isDir bool) (rule ignoreRule, ignored bool) {
+	for _, candidate := range ignores {
+		if candidate.matches(relPath, isDir) {
+			rule = candidate
+			ignored = !candidate.negated
+		}
+	}
+	return
+}
+
+func defaultIgnoreRules() (rules []ignoreRule) {
+	for _, pattern := range DefaultIgnoreFiles {
+		rule, ok := parseIgnoreRule(pattern)
+		if ok {
+			rule.source = "default"
+			rules = append(rules, rule)
+		}
+	}
+	return
+}
+
+// readCfIgnore reads the rules of the .cfignore in fullDir, if there is one.
+// relDir is where fullDir is in the app.
+func readCfIgnore(fullDir string, relDir string) (rules []ignoreRule) {
+	cfIgnoreFile, err := os.Open(filepath.Join(fullDir, ".cfignore"))
+	if err != nil {
+		return
+	}
+	defer cfIgnoreFile.Close()
+
+	source := path.Join(relDir, ".cfignore")
+	scanner := bufio.NewScanner(cfIgnoreFile)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		rule, ok := parseIgnoreRule(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		rule.dir = relDir
+		rule.source = source
+		rule.line = lineNumber
+		rules = append(rules, rule)
+	}
+	return
+}
+
+// parseIgnoreRule reads a line of a .cfignore, returning false for blank
+// lines, comments and patterns that can't be compiled.
+func parseIgnoreRule(line string) (rule ignoreRule, ok bool) {
+	line = trimUnescapedTrailingSpaces(strings.TrimRight(line, "\r"))
+	rule.text = line
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+
+	pattern := line
+	if strings.HasPrefix(pattern, "!") {
+		rule.negated = true
+		pattern = pattern[1:]
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		rule.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+
+	// a pattern holding a slash is relative to the .cfignore, any other
+	// matches at any depth below it
+	if strings.Contains(pattern, "/") {
+		pattern = strings.TrimPrefix(pattern, "/")
+	} else {
+		pattern = "**/" + pattern
+	}
+
+	if pattern == "" || pattern == "**/" {
+		return
+	}
+
+	compiled, err := glob.CompileGitignoreGlob(pattern)
+	if err != nil {
+		return
+	}
+
+	rule.glob = compiled
+	ok = true
+	return
+}
+
+func trimUnescapedTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimSuffix(line, " ")
+	}
+	return line
+}
@@ -0,0 +1,130 @@
+package cf
+
+import (
+	"fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func withAppDir(t *testing.T, files map[string]string, cb func(dir string)) {
+	fileutils.TempDir("cfignore_test", func(dir string, err error) {
+		assert.NoError(t, err)
+
+		for name, contents := range files {
+			fullPath := filepath.Join(dir, filepath.FromSlash(name))
+			err = os.MkdirAll(filepath.Dir(fullPath), 0755)
+			assert.NoError(t, err)
+			err = ioutil.WriteFile(fullPath, []byte(contents), 0644)
+			assert.NoError(t, err)
+		}
+
+		cb(dir)
+	})
+}
+
+func appFileNames(t *testing.T, dir string) (names []string) {
+	err := WalkAppFiles(dir, func(fileName, fullPath string) error {
+		names = append(names, filepath.ToSlash(fileName))
+		return nil
+	})
+	assert.NoError(t, err)
+	sort.Strings(names)
+	return
+}
+
+func TestDefaultIgnoreFilesApplyWithoutACfIgnore(t *testing.T) {
+	withAppDir(t, map[string]string{
+		"app.rb":         "",
+		".git/HEAD":      "",
+		".gitignore":     "",
+		"lib/.svn/entry": "",
+	}, func(dir string) {
+		assert.Equal(t, appFileNames(t, dir), []string{"app.rb"})
+	})
+}
+
+func TestCfIgnoreNegationAndDirectoryOnlyPatterns(t *testing.T) {
+	withAppDir(t, map[string]string{
+		".cfignore":       "# logs\n*.log\n!keep.log\nbuild/\n",
+		"app.rb":          "",
+		"dev.log":         "",
+		"keep.log":        "",
+		"logs/keep.log":   "",
+		"build/out.o":     "",
+		"lib/build":       "",
+		"lib/build.rb":    "",
+		"lib/old/dev.log": "",
+	}, func(dir string) {
+		assert.Equal(t, appFileNames(t, dir), []string{"app.rb", "keep.log", "lib/build", "lib/build.rb", "logs/keep.log"})
+	})
+}
+
+func TestCfIgnoreCannotBringBackFilesOfAnIgnoredDirectory(t *testing.T) {
+	withAppDir(t, map[string]string{
+		".cfignore":       "vendor\n!vendor/keep.rb\n",
+		"app.rb":          "",
+		"vendor/keep.rb":  "",
+		"vendor/other.rb": "",
+	}, func(dir string) {
+		assert.Equal(t, appFileNames(t, dir), []string{"app.rb"})
+	})
+}
+
+func TestCfIgnoreAnchoredPatternsAndEscapes(t *testing.T) {
+	withAppDir(t, map[string]string{
+		".cfignore":              "/config/secrets.yml\ndocs/*.md\n\\#scratch\n\\!bang\ntrailing\\ \n",
+		"config/app.yml":         "",
+		"config/secrets.yml":     "",
+		"lib/config/secrets.yml": "",
+		"docs/README.md":         "",
+		"docs/api/index.md":      "",
+		"#scratch":               "",
+		"!bang":                  "",
+		"trailing ":              "",
+		"trailing":               "",
+	}, func(dir string) {
+		assert.Equal(t, appFileNames(t, dir), []string{"config/app.yml", "docs/api/index.md", "lib/config/secrets.yml", "trailing"})
+	})
+}
+
+func TestNestedCfIgnoreFilesApplyBelowTheirDirectory(t *testing.T) {
+	withAppDir(t, map[string]string{
+		".cfignore":        "*.tmp\n",
+		"a.tmp":            "",
+		"a.cache":          "",
+		"sub/.cfignore":    "*.cache\n!b.tmp\n",
+		"sub/a.cache":      "",
+		"sub/b.tmp":        "",
+		"sub/c.tmp":        "",
+		"sub/deep/d.cache": "",
+	}, func(dir string) {
+		assert.Equal(t, appFileNames(t, dir), []string{"a.cache", "sub/b.tmp"})
+	})
+}
+
+func TestIgnoredAppFilesNamesTheRule(t *testing.T) {
+	withAppDir(t, map[string]string{
+		".cfignore":     "\n*.log\nbuild/\n",
+		"app.rb":        "",
+		"dev.log":       "",
+		"build/out.o":   "",
+		".git/HEAD":     "",
+		"sub/.cfignore": "*.rb\n",
+		"sub/lib.rb":    "",
+	}, func(dir string) {
+		ignored, err := IgnoredAppFiles(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, ignored, []IgnoredAppFile{
+			{Path: ".cfignore", Rule: "default: .cfignore"},
+			{Path: ".git/", Rule: "default: .git"},
+			{Path: "build/", Rule: ".cfignore:3: build/"},
+			{Path: "dev.log", Rule: ".cfignore:2: *.log"},
+			{Path: "sub/.cfignore", Rule: "default: .cfignore"},
+			{Path: "sub/lib.rb", Rule: "sub/.cfignore:1: *.rb"},
+		})
+	})
+}
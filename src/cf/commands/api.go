@@ -3,6 +3,7 @@ package commands
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -16,7 +17,7 @@ type Api struct {
 }
 
 type ApiEndpointSetter interface {
-	SetApiEndpoint(endpoint string)
+	SetApiEndpoint(endpoint string) (err errors.Error)
 }
 
 func NewApi(ui terminal.UI, config *configuration.Configuration, endpointRepo api.EndpointRepository) (cmd Api) {
@@ -30,7 +31,7 @@ func (cmd Api) GetRequirements(reqFactory requirements.Factory, c *cli.Context)
 	return
 }
 
-func (cmd Api) Run(c *cli.Context) {
+func (cmd Api) Run(c *cli.Context) (err errors.Error) {
 	if len(c.Args()) == 0 {
 		cmd.ui.Say(
 			"API endpoint: %s (API version: %s)",
@@ -40,10 +41,11 @@ func (cmd Api) Run(c *cli.Context) {
 		return
 	}
 
-	cmd.SetApiEndpoint(c.Args()[0])
+	err = cmd.SetApiEndpoint(c.Args()[0])
+	return
 }
 
-func (cmd Api) SetApiEndpoint(endpoint string) {
+func (cmd Api) SetApiEndpoint(endpoint string) (err errors.Error) {
 	if strings.HasSuffix(endpoint, "/") {
 		endpoint = strings.TrimSuffix(endpoint, "/")
 	}
@@ -52,7 +54,7 @@ func (cmd Api) SetApiEndpoint(endpoint string) {
 
 	endpoint, apiResponse := cmd.endpointRepo.UpdateEndpoint(endpoint)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -64,4 +66,5 @@ func (cmd Api) SetApiEndpoint(endpoint string) {
 	}
 
 	cmd.ui.ShowConfiguration(cmd.config)
+	return
 }
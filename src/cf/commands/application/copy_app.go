@@ -0,0 +1,243 @@
+package application
+
+import (
+	"cf"
+	"cf/api"
+	"cf/commands/service"
+	"cf/configuration"
+	"cf/errors"
+	"cf/formatters"
+	"cf/requirements"
+	"cf/terminal"
+	"fileutils"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"os"
+	"strings"
+)
+
+type CopyApp struct {
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	binder             service.ServiceBinder
+	appRepo            api.ApplicationRepository
+	appBitsRepo        api.ApplicationBitsRepository
+	orgRepo            api.OrganizationRepository
+	spaceRepo          api.SpaceRepository
+	serviceSummaryRepo api.ServiceSummaryRepository
+	appReq             requirements.ApplicationRequirement
+}
+
+func NewCopyApp(ui terminal.UI, config *configuration.Configuration, binder service.ServiceBinder,
+	appRepo api.ApplicationRepository, appBitsRepo api.ApplicationBitsRepository, orgRepo api.OrganizationRepository,
+	spaceRepo api.SpaceRepository, serviceSummaryRepo api.ServiceSummaryRepository) (cmd *CopyApp) {
+	cmd = new(CopyApp)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.binder = binder
+	cmd.appRepo = appRepo
+	cmd.appBitsRepo = appBitsRepo
+	cmd.orgRepo = orgRepo
+	cmd.spaceRepo = spaceRepo
+	cmd.serviceSummaryRepo = serviceSummaryRepo
+	return
+}
+
+func (cmd *CopyApp) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	orgName, spaceName := parseOrgAndSpace(c.String("to-space"), cmd.config.OrganizationFields.Name)
+	if len(c.Args()) != 1 || orgName == "" || spaceName == "" {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "copy-app")
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *CopyApp) Run(c *cli.Context) (err errors.Error) {
+	app := cmd.appReq.GetApplication()
+
+	orgName, spaceName := parseOrgAndSpace(c.String("to-space"), cmd.config.OrganizationFields.Name)
+	newName := c.String("name")
+	if newName == "" {
+		newName = app.Name
+	}
+
+	cmd.ui.Say("Copying app %s to %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(app.Name),
+		terminal.EntityNameColor(newName),
+		terminal.EntityNameColor(orgName),
+		terminal.EntityNameColor(spaceName),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	space, err := cmd.findSpace(orgName, spaceName)
+	if err != nil {
+		return
+	}
+
+	for _, existingApp := range space.Applications {
+		if existingApp.Name == newName {
+			err = cmd.ui.Failed("App %s already exists in org %s / space %s", newName, orgName, spaceName)
+			return
+		}
+	}
+
+	var boundInstances []string
+	if c.Bool("bind-services") {
+		boundInstances, err = cmd.boundServiceInstances(app)
+		if err != nil {
+			return
+		}
+	}
+
+	fileutils.TempFile("copied-app", func(packageFile *os.File, tempErr error) {
+		if tempErr != nil {
+			err = cmd.ui.Failed("Could not create a temporary file for the app package\n%s", tempErr.Error())
+			return
+		}
+
+		cmd.ui.Say("Downloading package of %s...", terminal.EntityNameColor(app.Name))
+		apiResponse := cmd.appBitsRepo.DownloadApp(app.Guid, packageFile)
+		if apiResponse.IsNotSuccessful() {
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+		cmd.ui.Ok()
+
+		cmd.ui.Say("Creating app %s...", terminal.EntityNameColor(newName))
+		newApp, apiResponse := cmd.appRepo.Create(copiedAppParams(app, newName, space.Guid))
+		if apiResponse.IsNotSuccessful() {
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+		cmd.ui.Ok()
+
+		cmd.ui.Say("Uploading %s...", terminal.EntityNameColor(newName))
+		apiResponse = cmd.appBitsRepo.UploadApp(newApp.Guid, packageFile.Name(), cf.ZipOptions{}, terminal.NewProgressBar(), func(zipSize, fileCount uint64) {
+			cmd.ui.Say("Uploading app: %s, %d files", formatters.ByteSize(zipSize), fileCount)
+		})
+		if apiResponse.IsNotSuccessful() {
+			cmd.deleteIncompleteCopy(newApp)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+		cmd.ui.Ok()
+
+		bindErr := cmd.bindServiceInstances(newApp, boundInstances, space)
+		if bindErr != nil {
+			cmd.deleteIncompleteCopy(newApp)
+			err = cmd.ui.FailWithError(bindErr)
+			return
+		}
+
+		cmd.ui.Say("")
+		cmd.ui.Say("TIP: Use '%s' and '%s' to start the copy",
+			terminal.CommandColor(cf.Name()+" target -o "+orgName+" -s "+spaceName),
+			terminal.CommandColor(cf.Name()+" start "+newName),
+		)
+	})
+	return
+}
+
+func (cmd *CopyApp) findSpace(orgName, spaceName string) (space cf.Space, err errors.Error) {
+	org, apiResponse := cmd.orgRepo.FindByName(orgName)
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	space, apiResponse = cmd.spaceRepo.FindByNameInOrg(spaceName, org.Guid)
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+	}
+	return
+}
+
+func (cmd *CopyApp) boundServiceInstances(app cf.Application) (names []string, err errors.Error) {
+	instances, apiResponse := cmd.serviceSummaryRepo.GetSummariesInCurrentSpace()
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	names = boundServiceNames(instances, app.Name)
+	return
+}
+
+// bindServiceInstances binds the copy to the service instances in its space
+// that have the same names as the ones the original app is bound to. The
+// error is left for the caller to print, once it has cleaned up.
+func (cmd *CopyApp) bindServiceInstances(app cf.Application, names []string, space cf.Space) (err errors.Error) {
+	for _, name := range names {
+		cmd.ui.Say("Binding service %s to %s...", terminal.EntityNameColor(name), terminal.EntityNameColor(app.Name))
+
+		instance, found := findServiceInstanceFields(space.ServiceInstances, name)
+		if !found {
+			err = errors.NewNotFoundError(fmt.Sprintf("Service instance %s not found in org %s / space %s", name, space.Organization.Name, space.Name))
+			return
+		}
+
+		apiResponse := cmd.binder.BindApplication(app, cf.ServiceInstance{ServiceInstanceFields: instance})
+		if apiResponse.IsNotSuccessful() {
+			err = apiResponse.ToError()
+			return
+		}
+		cmd.ui.Ok()
+	}
+	return
+}
+
+// deleteIncompleteCopy removes a copy that was created but could not be
+// completed, so that copying the app again doesn't find it already there.
+func (cmd *CopyApp) deleteIncompleteCopy(app cf.Application) {
+	cmd.ui.Say("Deleting incomplete copy %s...", terminal.EntityNameColor(app.Name))
+
+	apiResponse := cmd.appRepo.Delete(app.Guid)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Warn("Could not delete %s: %s\nDelete it with '%s' before copying the app again.",
+			app.Name, apiResponse.Message, terminal.CommandColor(cf.Name()+" delete "+app.Name))
+	}
+}
+
+func findServiceInstanceFields(instances []cf.ServiceInstanceFields, name string) (instance cf.ServiceInstanceFields, found bool) {
+	for _, instance = range instances {
+		if instance.Name == name {
+			found = true
+			return
+		}
+	}
+	return
+}
+
+// copiedAppParams keeps the original app's configuration, but creates the
+// copy stopped so it can be checked before it starts taking traffic.
+func copiedAppParams(app cf.Application, name, spaceGuid string) (params cf.AppParams) {
+	params = app.ToParams()
+	params.Delete("guid")
+	params.Set("name", name)
+	params.Set("space_guid", spaceGuid)
+	params.Set("state", "STOPPED")
+
+	if app.Stack.Guid == "" {
+		params.Delete("stack_guid")
+	}
+	return
+}
+
+// parseOrgAndSpace splits ORG/SPACE, using defaultOrg when only a space is
+// given.
+func parseOrgAndSpace(value, defaultOrg string) (orgName, spaceName string) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) == 1 {
+		return defaultOrg, parts[0]
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,233 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"generic"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+type copyAppDeps struct {
+	reqFactory         *testreq.FakeReqFactory
+	binder             *testcmd.FakeAppBinder
+	appRepo            *testapi.FakeApplicationRepository
+	appBitsRepo        *testapi.FakeApplicationBitsRepository
+	orgRepo            *testapi.FakeOrgRepository
+	spaceRepo          *testapi.FakeSpaceRepository
+	serviceSummaryRepo *testapi.FakeServiceSummaryRepo
+}
+
+func getCopyAppDeps() (deps copyAppDeps) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.Memory = 256
+	app.DiskQuota = 1024
+	app.InstanceCount = 3
+	app.BuildpackUrl = "go_buildpack"
+	app.Command = "./my-app --serve"
+	app.EnvironmentVars = map[string]string{"DATABASE_URL": "mysql://example.com/my-db"}
+	app.Stack.Guid = "my-stack-guid"
+
+	org := cf.Organization{}
+	org.Name = "prod-org"
+	org.Guid = "prod-org-guid"
+
+	otherApp := cf.ApplicationFields{}
+	otherApp.Name = "other-app"
+
+	db := cf.ServiceInstanceFields{}
+	db.Name = "my-db"
+	db.Guid = "prod-db-guid"
+
+	space := cf.Space{}
+	space.Name = "production"
+	space.Guid = "production-guid"
+	space.Organization = org.OrganizationFields
+	space.Applications = []cf.ApplicationFields{otherApp}
+	space.ServiceInstances = []cf.ServiceInstanceFields{db}
+
+	boundDb := cf.ServiceInstance{}
+	boundDb.Name = "my-db"
+	boundDb.ApplicationNames = []string{"other-app", "my-app"}
+	unboundCache := cf.ServiceInstance{}
+	unboundCache.Name = "my-cache"
+	unboundCache.ApplicationNames = []string{"other-app"}
+
+	deps.reqFactory = &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
+	deps.binder = &testcmd.FakeAppBinder{}
+	deps.appRepo = &testapi.FakeApplicationRepository{}
+	deps.appBitsRepo = &testapi.FakeApplicationBitsRepository{DownloadContents: "zip contents"}
+	deps.orgRepo = &testapi.FakeOrgRepository{FindByNameOrganization: org}
+	deps.spaceRepo = &testapi.FakeSpaceRepository{FindByNameInOrgSpace: space}
+	deps.serviceSummaryRepo = &testapi.FakeServiceSummaryRepo{
+		GetSummariesInCurrentSpaceInstances: []cf.ServiceInstance{boundDb, unboundCache},
+	}
+	return
+}
+
+func TestCopyAppFailsWithUsage(t *testing.T) {
+	deps := getCopyAppDeps()
+
+	ui := callCopyApp(t, []string{"my-app"}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCopyApp(t, []string{"--to-space", "prod-org/"}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCopyApp(t, []string{"--to-space", "prod-org/production"}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCopyApp(t, []string{"--to-space", "prod-org/production", "my-app"}, deps)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestCopyAppCreatesAStoppedCopyWithTheSameConfigAndBits(t *testing.T) {
+	deps := getCopyAppDeps()
+
+	ui := callCopyApp(t, []string{"--to-space", "prod-org/production", "--name", "my-app-v2", "my-app"}, deps)
+
+	assert.Equal(t, deps.orgRepo.FindByNameName, "prod-org")
+	assert.Equal(t, deps.spaceRepo.FindByNameInOrgName, "production")
+	assert.Equal(t, deps.spaceRepo.FindByNameInOrgOrgGuid, "prod-org-guid")
+	assert.Equal(t, deps.appBitsRepo.DownloadedAppGuid, "my-app-guid")
+
+	params := deps.appRepo.CreatedAppParams()
+	assert.False(t, params.Has("guid"))
+	assert.Equal(t, params.Get("name"), "my-app-v2")
+	assert.Equal(t, params.Get("space_guid"), "production-guid")
+	assert.Equal(t, params.Get("state"), "STOPPED")
+	assert.Equal(t, params.Get("memory"), uint64(256))
+	assert.Equal(t, params.Get("disk_quota"), uint64(1024))
+	assert.Equal(t, params.Get("instances"), 3)
+	assert.Equal(t, params.Get("buildpack"), "go_buildpack")
+	assert.Equal(t, params.Get("command"), "./my-app --serve")
+	assert.Equal(t, params.Get("stack_guid"), "my-stack-guid")
+	assert.Equal(t, params.Get("env").(generic.Map).Get("DATABASE_URL"), "mysql://example.com/my-db")
+
+	assert.Equal(t, deps.appBitsRepo.UploadedAppGuid, "my-app-v2-guid")
+	assert.NotEqual(t, deps.appBitsRepo.UploadedDir, "")
+	assert.Equal(t, len(deps.binder.AppsToBind), 0)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Copying app", "my-app", "my-app-v2", "prod-org", "production", "my-user"},
+		{"Downloading package", "my-app"},
+		{"OK"},
+		{"Creating app", "my-app-v2"},
+		{"OK"},
+		{"Uploading", "my-app-v2"},
+		{"OK"},
+		{"TIP", "target -o prod-org -s production", "start my-app-v2"},
+	})
+}
+
+func TestCopyAppToASpaceInTheTargetedOrg(t *testing.T) {
+	deps := getCopyAppDeps()
+
+	callCopyApp(t, []string{"--to-space", "production", "my-app"}, deps)
+
+	assert.Equal(t, deps.orgRepo.FindByNameName, "my-org")
+	assert.Equal(t, deps.spaceRepo.FindByNameInOrgName, "production")
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("name"), "my-app")
+}
+
+func TestCopyAppBindsServiceInstancesByName(t *testing.T) {
+	deps := getCopyAppDeps()
+
+	ui := callCopyApp(t, []string{"--to-space", "prod-org/production", "--bind-services", "my-app"}, deps)
+
+	assert.Equal(t, len(deps.binder.AppsToBind), 1)
+	assert.Equal(t, deps.binder.AppsToBind[0].Guid, "my-app-guid")
+	assert.Equal(t, deps.binder.InstancesToBindTo[0].Guid, "prod-db-guid")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Binding service", "my-db", "my-app"},
+		{"OK"},
+	})
+}
+
+func TestCopyAppWhenABoundServiceInstanceIsMissing(t *testing.T) {
+	deps := getCopyAppDeps()
+	deps.spaceRepo.FindByNameInOrgSpace.ServiceInstances = []cf.ServiceInstanceFields{}
+
+	ui := callCopyApp(t, []string{"--to-space", "prod-org/production", "--name", "my-app-copy", "--bind-services", "my-app"}, deps)
+
+	assert.Equal(t, len(deps.binder.AppsToBind), 0)
+	assert.Equal(t, deps.appRepo.DeletedAppGuid, "my-app-copy-guid")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Deleting incomplete copy", "my-app-copy"},
+		{"FAILED"},
+		{"Service instance my-db not found", "prod-org", "production"},
+	})
+}
+
+func TestCopyAppWhenTheAppAlreadyExistsInTheTargetSpace(t *testing.T) {
+	deps := getCopyAppDeps()
+
+	ui := callCopyApp(t, []string{"--to-space", "prod-org/production", "--name", "other-app", "my-app"}, deps)
+
+	assert.Equal(t, deps.appBitsRepo.DownloadedAppGuid, "")
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"App other-app already exists", "prod-org", "production"},
+	})
+}
+
+func TestCopyAppWhenTheDownloadFails(t *testing.T) {
+	deps := getCopyAppDeps()
+	deps.appBitsRepo.DownloadAppErr = true
+
+	ui := callCopyApp(t, []string{"--to-space", "prod-org/production", "my-app"}, deps)
+
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error downloading app"},
+	})
+}
+
+func TestCopyAppWhenTheUploadFails(t *testing.T) {
+	deps := getCopyAppDeps()
+	deps.appBitsRepo.UploadAppErr = true
+
+	ui := callCopyApp(t, []string{"--to-space", "prod-org/production", "--name", "my-app-copy", "my-app"}, deps)
+
+	assert.Equal(t, deps.appBitsRepo.UploadedAppGuid, "my-app-copy-guid")
+	assert.Equal(t, deps.appRepo.DeletedAppGuid, "my-app-copy-guid")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Deleting incomplete copy", "my-app-copy"},
+		{"FAILED"},
+		{"Error uploading app"},
+	})
+}
+
+func callCopyApp(t *testing.T, args []string, deps copyAppDeps) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("copy-app", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewCopyApp(ui, config, deps.binder, deps.appRepo, deps.appBitsRepo, deps.orgRepo, deps.spaceRepo, deps.serviceSummaryRepo)
+	testcmd.RunCommand(cmd, ctxt, deps.reqFactory)
+	return
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/errors"
+	"cf/manifest"
+	"cf/requirements"
+	"cf/terminal"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"os"
+	"strings"
+)
+
+type CreateAppManifest struct {
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	appRepo            api.ApplicationRepository
+	appSummaryRepo     api.AppSummaryRepository
+	serviceSummaryRepo api.ServiceSummaryRepository
+	manifestRepo       manifest.ManifestRepository
+}
+
+func NewCreateAppManifest(ui terminal.UI, config *configuration.Configuration, appRepo api.ApplicationRepository,
+	appSummaryRepo api.AppSummaryRepository, serviceSummaryRepo api.ServiceSummaryRepository, manifestRepo manifest.ManifestRepository) (cmd *CreateAppManifest) {
+	cmd = new(CreateAppManifest)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appRepo = appRepo
+	cmd.appSummaryRepo = appSummaryRepo
+	cmd.serviceSummaryRepo = serviceSummaryRepo
+	cmd.manifestRepo = manifestRepo
+	return
+}
+
+func (cmd *CreateAppManifest) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) < 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "create-app-manifest")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+	}
+	return
+}
+
+func (cmd *CreateAppManifest) Run(c *cli.Context) (err errors.Error) {
+	appNames := c.Args()
+
+	manifestPath := c.String("p")
+	if manifestPath == "" {
+		manifestPath = fmt.Sprintf("%s_manifest.yml", appNames[0])
+	}
+
+	cmd.ui.Say("Creating an app manifest from %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(strings.Join(appNames, ", ")),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	instances, apiResponse := cmd.serviceSummaryRepo.GetSummariesInCurrentSpace()
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	appManifest := manifest.NewAppManifest()
+	for _, appName := range appNames {
+		app, apiResponse := cmd.appRepo.Read(appName)
+		if apiResponse.IsNotSuccessful() {
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+
+		summary, apiResponse := cmd.appSummaryRepo.GetSummary(app.Guid)
+		if apiResponse.IsNotSuccessful() {
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+
+		appManifest.AddApplication(app, summary.RouteSummaries, boundServiceNames(instances, app.Name))
+	}
+
+	writeErr := cmd.manifestRepo.WriteManifest(manifestPath, appManifest, c.Bool("f"))
+	if os.IsExist(writeErr) {
+		var confirmed bool
+		confirmed, err = terminal.Confirm(cmd.ui, "%s already exists. Are you sure you want to overwrite it?", terminal.EntityNameColor(manifestPath))
+		if !confirmed {
+			return
+		}
+		writeErr = cmd.manifestRepo.WriteManifest(manifestPath, appManifest, true)
+	}
+	if writeErr != nil {
+		err = cmd.ui.Failed("Error creating manifest file:\n%s", writeErr)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("Manifest file created successfully at %s", terminal.EntityNameColor(manifestPath))
+	return
+}
+
+func boundServiceNames(instances []cf.ServiceInstance, appName string) (names []string) {
+	for _, instance := range instances {
+		for _, boundAppName := range instance.ApplicationNames {
+			if boundAppName == appName {
+				names = append(names, instance.Name)
+				break
+			}
+		}
+	}
+	return
+}
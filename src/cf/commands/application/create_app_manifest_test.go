@@ -0,0 +1,216 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testmanifest "testhelpers/manifest"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+type createAppManifestDeps struct {
+	reqFactory         *testreq.FakeReqFactory
+	appRepo            *testapi.FakeApplicationRepository
+	appSummaryRepo     *testapi.FakeAppSummaryRepo
+	serviceSummaryRepo *testapi.FakeServiceSummaryRepo
+	manifestRepo       *testmanifest.FakeManifestRepository
+}
+
+func getCreateAppManifestDeps() (deps createAppManifestDeps) {
+	web := cf.Application{}
+	web.Name = "web"
+	web.Guid = "web-guid"
+	web.Memory = 512
+	web.DiskQuota = 1024
+	web.InstanceCount = 3
+	web.Stack.Name = "lucid64"
+	web.EnvironmentVars = map[string]string{"RACK_ENV": "production"}
+
+	worker := cf.Application{}
+	worker.Name = "worker"
+	worker.Guid = "worker-guid"
+	worker.Memory = 256
+	worker.Command = "rake jobs:work"
+
+	route := cf.RouteSummary{}
+	route.Host = "www"
+	route.Domain.Name = "example.com"
+
+	db := cf.ServiceInstance{}
+	db.Name = "db"
+	db.ApplicationNames = []string{"web", "worker"}
+
+	cache := cf.ServiceInstance{}
+	cache.Name = "cache"
+	cache.ApplicationNames = []string{"web"}
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	deps.appRepo = &testapi.FakeApplicationRepository{
+		ReadAppsByName: map[string]cf.Application{"web": web, "worker": worker},
+	}
+	deps.appSummaryRepo = &testapi.FakeAppSummaryRepo{
+		GetSummariesByGuid: map[string]cf.AppSummary{
+			"web-guid": cf.AppSummary{RouteSummaries: []cf.RouteSummary{route}},
+		},
+	}
+	deps.serviceSummaryRepo = &testapi.FakeServiceSummaryRepo{
+		GetSummariesInCurrentSpaceInstances: []cf.ServiceInstance{db, cache},
+	}
+	deps.manifestRepo = &testmanifest.FakeManifestRepository{}
+	return
+}
+
+func TestCreateAppManifestRequirements(t *testing.T) {
+	deps := getCreateAppManifestDeps()
+	ui := callCreateAppManifest(t, []string{}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	deps.reqFactory.LoginSuccess = false
+	callCreateAppManifest(t, []string{"web"}, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	deps = getCreateAppManifestDeps()
+	deps.reqFactory.TargetedSpaceSuccess = false
+	callCreateAppManifest(t, []string{"web"}, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	deps = getCreateAppManifestDeps()
+	callCreateAppManifest(t, []string{"web"}, deps)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestCreateAppManifestForOneApp(t *testing.T) {
+	deps := getCreateAppManifestDeps()
+	ui := callCreateAppManifest(t, []string{"web"}, deps)
+
+	assert.Equal(t, deps.appRepo.ReadName, "web")
+	assert.Equal(t, deps.appSummaryRepo.GetSummaryAppGuid, "web-guid")
+	assert.Equal(t, deps.manifestRepo.WriteManifestPath, "web_manifest.yml")
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Creating an app manifest from", "web", "my-org", "my-space", "my-user"},
+		{"OK"},
+		{"Manifest file created successfully at", "web_manifest.yml"},
+	})
+
+	yaml := string(deps.manifestRepo.WriteManifestManifest.YAML())
+	assert.Contains(t, yaml, "- name: web\n")
+	assert.Contains(t, yaml, "  memory: 512M\n")
+	assert.Contains(t, yaml, "  disk_quota: 1024M\n")
+	assert.Contains(t, yaml, "  instances: 3\n")
+	assert.Contains(t, yaml, "  stack: lucid64\n")
+	assert.Contains(t, yaml, "  env:\n    RACK_ENV: production\n")
+	assert.Contains(t, yaml, "  services:\n  - db\n  - cache\n")
+	assert.Contains(t, yaml, "  routes:\n  - www.example.com\n")
+	assert.NotContains(t, yaml, "worker")
+}
+
+func TestCreateAppManifestForSeveralAppsInOneFile(t *testing.T) {
+	deps := getCreateAppManifestDeps()
+	ui := callCreateAppManifest(t, []string{"-p", "apps.yml", "web", "worker"}, deps)
+
+	assert.Equal(t, deps.manifestRepo.WriteManifestPath, "apps.yml")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Creating an app manifest from", "web, worker"},
+		{"OK"},
+	})
+
+	yaml := string(deps.manifestRepo.WriteManifestManifest.YAML())
+	workerIndex := strings.Index(yaml, "- name: worker\n")
+	assert.True(t, strings.Index(yaml, "- name: web\n") < workerIndex)
+
+	worker := yaml[workerIndex:]
+	assert.Contains(t, worker, "  command: \"rake jobs:work\"\n")
+	assert.Contains(t, worker, "  services:\n  - db\n")
+	assert.Contains(t, worker, "  no-route: true\n")
+	assert.NotContains(t, worker, "cache")
+}
+
+func TestCreateAppManifestWhenAnAppIsNotFound(t *testing.T) {
+	deps := getCreateAppManifestDeps()
+	deps.appRepo.ReadNotFound = true
+	ui := callCreateAppManifest(t, []string{"missing-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"missing-app", "not found"},
+	})
+}
+
+func TestCreateAppManifestWhenTheFileExists(t *testing.T) {
+	deps := getCreateAppManifestDeps()
+	deps.manifestRepo.WriteManifestExists = true
+	ui := callCreateAppManifestWithInputs(t, []string{"web"}, []string{"n"}, deps)
+
+	testassert.SliceContains(t, ui.Prompts, testassert.Lines{
+		{"web_manifest.yml", "already exists", "overwrite"},
+	})
+	assert.Nil(t, deps.manifestRepo.WriteManifestManifest)
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{{"OK"}})
+
+	deps = getCreateAppManifestDeps()
+	deps.manifestRepo.WriteManifestExists = true
+	ui = callCreateAppManifestWithInputs(t, []string{"web"}, []string{"y"}, deps)
+
+	assert.True(t, deps.manifestRepo.WriteManifestOverwrite)
+	assert.NotNil(t, deps.manifestRepo.WriteManifestManifest)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"OK"}})
+}
+
+func TestCreateAppManifestWithForceOverwritesWithoutConfirmation(t *testing.T) {
+	deps := getCreateAppManifestDeps()
+	deps.manifestRepo.WriteManifestExists = true
+	ui := callCreateAppManifest(t, []string{"-f", "web"}, deps)
+
+	assert.Empty(t, ui.Prompts)
+	assert.True(t, deps.manifestRepo.WriteManifestOverwrite)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"OK"}})
+}
+
+func TestCreateAppManifestWhenTheFileCannotBeWritten(t *testing.T) {
+	deps := getCreateAppManifestDeps()
+	deps.manifestRepo.WriteManifestErr = errors.New("permission denied")
+	ui := callCreateAppManifest(t, []string{"web"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error creating manifest file"},
+		{"permission denied"},
+	})
+}
+
+func callCreateAppManifest(t *testing.T, args []string, deps createAppManifestDeps) (ui *testterm.FakeUI) {
+	return callCreateAppManifestWithInputs(t, args, []string{}, deps)
+}
+
+func callCreateAppManifestWithInputs(t *testing.T, args, inputs []string, deps createAppManifestDeps) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{Inputs: inputs}
+	ctxt := testcmd.NewContext("create-app-manifest", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewCreateAppManifest(ui, config, deps.appRepo, deps.appSummaryRepo, deps.serviceSummaryRepo, deps.manifestRepo)
+	testcmd.RunCommand(cmd, ctxt, deps.reqFactory)
+	return
+}
@@ -3,9 +3,9 @@ package application
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -34,17 +34,23 @@ func (cmd *DeleteApp) GetRequirements(reqFactory requirements.Factory, c *cli.Co
 	return
 }
 
-func (cmd *DeleteApp) Run(c *cli.Context) {
+func (cmd *DeleteApp) Run(c *cli.Context) (err errors.Error) {
 	appName := c.Args()[0]
 	force := c.Bool("f")
 
-	if !force {
-		response := cmd.ui.Confirm(
+	var confirmed bool
+	if cmd.config.IsSpaceProtected(cmd.config.OrganizationFields.Name, cmd.config.SpaceFields.Name) {
+		confirmed, err = cmd.confirmProtected(appName, force)
+		if !confirmed {
+			return
+		}
+	} else if !force {
+		confirmed, err = terminal.Confirm(cmd.ui,
 			"Really delete %s?%s",
 			terminal.EntityNameColor(appName),
 			terminal.PromptColor(">"),
 		)
-		if !response {
+		if !confirmed {
 			return
 		}
 	}
@@ -59,7 +65,7 @@ func (cmd *DeleteApp) Run(c *cli.Context) {
 	app, apiResponse := cmd.appRepo.Read(appName)
 
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -71,10 +77,24 @@ func (cmd *DeleteApp) Run(c *cli.Context) {
 
 	apiResponse = cmd.appRepo.Delete(app.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 	return
 }
+
+func (cmd *DeleteApp) confirmProtected(appName string, force bool) (confirmed bool, err errors.Error) {
+	cascades := []string{}
+	app, apiResponse := cmd.appRepo.Read(appName)
+	if apiResponse.IsSuccessful() {
+		urls := []string{}
+		for _, route := range app.Routes {
+			urls = append(urls, route.URL())
+		}
+		cascades = append(cascades, terminal.CascadeLine("routes to unmap", urls))
+	}
+
+	return terminal.ConfirmProtectedDeletion(cmd.ui, "app", appName, cascades, force)
+}
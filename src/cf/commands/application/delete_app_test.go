@@ -96,6 +96,57 @@ func TestDeleteCommandFailsWithUsage(t *testing.T) {
 	assert.False(t, ui.FailedWithUsage)
 }
 
+func TestDeleteAppInProtectedSpace(t *testing.T) {
+	route := cf.RouteSummary{}
+	route.Host = "my-host"
+	route.Domain.Name = "example.com"
+	app := cf.Application{}
+	app.Name = "app-to-delete"
+	app.Guid = "app-to-delete-guid"
+	app.Routes = []cf.RouteSummary{route}
+
+	ui, appRepo := deleteAppInProtectedSpace(app, "y", []string{"app-to-delete"})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"app-to-delete", "is protected"},
+		{"routes", "my-host.example.com"},
+		{"did not match"},
+	})
+	assert.Equal(t, appRepo.DeletedAppGuid, "")
+
+	ui, appRepo = deleteAppInProtectedSpace(app, "app-to-delete", []string{"app-to-delete"})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Deleting", "app-to-delete"},
+		{"OK"},
+	})
+	assert.Equal(t, appRepo.DeletedAppGuid, "app-to-delete-guid")
+
+	ui, appRepo = deleteAppInProtectedSpace(app, "", []string{"-f", "app-to-delete"})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"app-to-delete", "protected", "-f"},
+	})
+	assert.Equal(t, appRepo.DeletedAppGuid, "")
+}
+
+func deleteAppInProtectedSpace(app cf.Application, confirmation string, args []string) (ui *testterm.FakeUI, appRepo *testapi.FakeApplicationRepository) {
+	appRepo = &testapi.FakeApplicationRepository{ReadApp: app}
+	ui = &testterm.FakeUI{Inputs: []string{confirmation}}
+
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		ProtectedSpaces:    []string{"my-org/my-space"},
+	}
+
+	cmd := NewDeleteApp(ui, config, appRepo)
+	testcmd.RunCommand(cmd, testcmd.NewContext("delete", args), &testreq.FakeReqFactory{})
+	return
+}
+
 func deleteApp(t *testing.T, confirmation string, args []string) (ui *testterm.FakeUI, reqFactory *testreq.FakeReqFactory, appRepo *testapi.FakeApplicationRepository) {
 
 	app := cf.Application{}
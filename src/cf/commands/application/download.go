@@ -0,0 +1,194 @@
+package application
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/errors"
+	"cf/requirements"
+	"cf/terminal"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type Download struct {
+	ui           terminal.UI
+	config       *configuration.Configuration
+	appFilesRepo api.AppFilesRepository
+	appReq       requirements.ApplicationRequirement
+}
+
+func NewDownload(ui terminal.UI, config *configuration.Configuration, appFilesRepo api.AppFilesRepository) (cmd *Download) {
+	cmd = new(Download)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appFilesRepo = appFilesRepo
+	return
+}
+
+func (cmd *Download) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) < 2 || len(c.Args()) > 3 || c.Int("i") < 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "download")
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *Download) Run(c *cli.Context) (err errors.Error) {
+	app := cmd.appReq.GetApplication()
+	instance := c.Int("i")
+	remotePath := c.Args()[1]
+
+	localPath := path.Base(strings.TrimRight(remotePath, "/"))
+	if len(c.Args()) > 2 {
+		localPath = c.Args()[2]
+	}
+
+	cmd.ui.Say("Downloading %s from instance #%d of app %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(remotePath),
+		instance,
+		terminal.EntityNameColor(app.Name),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	isDir, downloadErr := cmd.isDirectory(app, instance, remotePath)
+	if downloadErr != nil {
+		err = cmd.ui.FailWithError(downloadErr)
+		return
+	}
+
+	fileCount := 0
+	if isDir {
+		downloadErr = cmd.downloadDirectory(app, instance, remotePath, localPath, &fileCount)
+	} else {
+		downloadErr = cmd.downloadFile(app, instance, remotePath, localPath, &fileCount)
+	}
+	if downloadErr != nil {
+		err = cmd.ui.FailWithError(downloadErr)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("Downloaded %d file(s) to %s", fileCount, terminal.EntityNameColor(localPath))
+	return
+}
+
+// isDirectory looks the remote path up in its parent's listing, since the
+// files endpoint answers with either file contents or a directory listing
+// and gives no other way to tell the two apart.
+func (cmd *Download) isDirectory(app cf.Application, instance int, remotePath string) (isDir bool, err error) {
+	cleanPath := path.Clean("/" + remotePath)
+	if cleanPath == "/" || strings.HasSuffix(remotePath, "/") {
+		isDir = true
+		return
+	}
+
+	listing, apiResponse := cmd.appFilesRepo.ListFiles(app.Guid, instance, path.Dir(cleanPath)+"/")
+	if apiResponse.IsNotSuccessful() {
+		err = apiResponse.ToError()
+		return
+	}
+
+	name := path.Base(cleanPath)
+	for _, entry := range parseFileListing(listing) {
+		if strings.TrimSuffix(entry, "/") == name {
+			isDir = strings.HasSuffix(entry, "/")
+			return
+		}
+	}
+
+	err = errors.NewNotFoundError(fmt.Sprintf("File %s not found on instance #%d of app %s", remotePath, instance, app.Name))
+	return
+}
+
+func (cmd *Download) downloadDirectory(app cf.Application, instance int, remoteDir, localDir string, fileCount *int) (err error) {
+	remoteDir = strings.TrimRight(remoteDir, "/") + "/"
+
+	listing, apiResponse := cmd.appFilesRepo.ListFiles(app.Guid, instance, remoteDir)
+	if apiResponse.IsNotSuccessful() {
+		err = apiResponse.ToError()
+		return
+	}
+
+	err = os.MkdirAll(localDir, os.ModeDir|os.ModePerm)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range parseFileListing(listing) {
+		name := strings.TrimSuffix(entry, "/")
+		if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+			continue
+		}
+
+		if strings.HasSuffix(entry, "/") {
+			err = cmd.downloadDirectory(app, instance, remoteDir+name, filepath.Join(localDir, name), fileCount)
+		} else {
+			err = cmd.downloadFile(app, instance, remoteDir+name, filepath.Join(localDir, name), fileCount)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (cmd *Download) downloadFile(app cf.Application, instance int, remotePath, localPath string, fileCount *int) (err error) {
+	cmd.ui.Say("  %s", remotePath)
+
+	err = os.MkdirAll(filepath.Dir(localPath), os.ModeDir|os.ModePerm)
+	if err != nil {
+		return
+	}
+
+	file, err := os.Create(localPath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	apiResponse := cmd.appFilesRepo.DownloadFile(app.Guid, instance, remotePath, file)
+	if apiResponse.IsNotSuccessful() {
+		file.Close()
+		os.Remove(localPath)
+		err = apiResponse.ToError()
+		return
+	}
+
+	*fileCount++
+	return
+}
+
+// parseFileListing returns the entry names of a directory listing, where
+// directories keep their trailing slash and sizes are dropped. The size is
+// the last column, so names may contain spaces.
+func parseFileListing(listing string) (entries []string) {
+	for _, line := range strings.Split(listing, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		sizeStart := strings.LastIndexAny(line, " \t")
+		if sizeStart != -1 {
+			line = strings.TrimRight(line[:sizeStart], " \t")
+		}
+		entries = append(entries, line)
+	}
+	return
+}
@@ -0,0 +1,201 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"cf/errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestDownloadRequirements(t *testing.T) {
+	args := []string{"my-app", "app/config.yml"}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callDownload(t, args, reqFactory, &testapi.FakeAppFilesRepo{})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callDownload(t, args, reqFactory, &testapi.FakeAppFilesRepo{})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	callDownload(t, args, reqFactory, &testapi.FakeAppFilesRepo{Listings: map[string]string{}})
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.ApplicationName, "my-app")
+}
+
+func TestDownloadFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui := callDownload(t, []string{"my-app"}, reqFactory, &testapi.FakeAppFilesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callDownload(t, []string{"my-app", "remote", "local", "extra"}, reqFactory, &testapi.FakeAppFilesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callDownload(t, []string{"-i", "-2", "my-app", "remote"}, reqFactory, &testapi.FakeAppFilesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+}
+
+func TestDownloadingAFile(t *testing.T) {
+	contents := []byte{'P', 'K', 0x03, 0x04, 0x00, 0xff, '\n'}
+	appFilesRepo := &testapi.FakeAppFilesRepo{
+		Listings: map[string]string{
+			"/app/": "config.yml            1.1K\nlib/                     -\n",
+		},
+		Files: map[string][]byte{
+			"app/config.yml": contents,
+		},
+	}
+
+	withDownloadDir(t, func(dir string) {
+		localPath := filepath.Join(dir, "downloaded.yml")
+		ui := callDownload(t, []string{"-i", "2", "my-app", "app/config.yml", localPath}, downloadReqFactory(), appFilesRepo)
+
+		assert.Equal(t, appFilesRepo.AppGuid, "my-app-guid")
+		assert.Equal(t, appFilesRepo.Instance, 2)
+		assert.Equal(t, appFilesRepo.DownloadedPaths, []string{"app/config.yml"})
+
+		downloaded, err := ioutil.ReadFile(localPath)
+		assert.NoError(t, err)
+		assert.Equal(t, downloaded, contents)
+
+		testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+			{"Downloading", "app/config.yml", "#2", "my-app", "my-org", "my-space", "my-user"},
+			{"OK"},
+			{"Downloaded 1 file(s)", localPath},
+		})
+	})
+}
+
+func TestDownloadingADirectoryRecursively(t *testing.T) {
+	appFilesRepo := &testapi.FakeAppFilesRepo{
+		Listings: map[string]string{
+			"app/":     "config.yml            1.1K\nlib/                     -\n../                      -\n",
+			"app/lib/": "helper.rb              12B\n",
+		},
+		Files: map[string][]byte{
+			"app/config.yml":    []byte("name: my-app\n"),
+			"app/lib/helper.rb": []byte("puts 'hi'\n"),
+		},
+	}
+
+	withDownloadDir(t, func(dir string) {
+		localPath := filepath.Join(dir, "app")
+		ui := callDownload(t, []string{"my-app", "app/", localPath}, downloadReqFactory(), appFilesRepo)
+
+		assert.Equal(t, appFilesRepo.DownloadedPaths, []string{"app/config.yml", "app/lib/helper.rb"})
+
+		config, err := ioutil.ReadFile(filepath.Join(localPath, "config.yml"))
+		assert.NoError(t, err)
+		assert.Equal(t, string(config), "name: my-app\n")
+
+		helper, err := ioutil.ReadFile(filepath.Join(localPath, "lib", "helper.rb"))
+		assert.NoError(t, err)
+		assert.Equal(t, string(helper), "puts 'hi'\n")
+
+		testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+			{"OK"},
+			{"Downloaded 2 file(s)", localPath},
+		})
+	})
+}
+
+func TestDownloadingADirectoryWithSpacesInNames(t *testing.T) {
+	appFilesRepo := &testapi.FakeAppFilesRepo{
+		Listings: map[string]string{
+			"app/":           "release notes.txt     1.1K\nmy assets/               -\n",
+			"app/my assets/": "logo 2x.png            12B\n",
+		},
+		Files: map[string][]byte{
+			"app/release notes.txt":     []byte("notes\n"),
+			"app/my assets/logo 2x.png": []byte("png\n"),
+		},
+	}
+
+	withDownloadDir(t, func(dir string) {
+		localPath := filepath.Join(dir, "app")
+		ui := callDownload(t, []string{"my-app", "app/", localPath}, downloadReqFactory(), appFilesRepo)
+
+		assert.Equal(t, appFilesRepo.DownloadedPaths, []string{"app/release notes.txt", "app/my assets/logo 2x.png"})
+
+		logo, err := ioutil.ReadFile(filepath.Join(localPath, "my assets", "logo 2x.png"))
+		assert.NoError(t, err)
+		assert.Equal(t, string(logo), "png\n")
+
+		testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+			{"Downloaded 2 file(s)", localPath},
+		})
+	})
+}
+
+func TestDownloadingAFileThatDoesNotExist(t *testing.T) {
+	appFilesRepo := &testapi.FakeAppFilesRepo{
+		Listings: map[string]string{
+			"/app/": "config.yml            1.1K\n",
+		},
+	}
+
+	withDownloadDir(t, func(dir string) {
+		ui := callDownload(t, []string{"my-app", "app/missing.yml", filepath.Join(dir, "missing.yml")}, downloadReqFactory(), appFilesRepo)
+
+		assert.Equal(t, len(appFilesRepo.DownloadedPaths), 0)
+		testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+			{"FAILED"},
+			{"app/missing.yml", "not found"},
+		})
+		assert.Equal(t, ui.FailedWithError.ExitCode(), errors.EXIT_NOT_FOUND)
+
+		_, err := os.Stat(filepath.Join(dir, "missing.yml"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func downloadReqFactory() *testreq.FakeReqFactory {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	return &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
+}
+
+func withDownloadDir(t *testing.T, cb func(dir string)) {
+	dir, err := ioutil.TempDir("", "download-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cb(dir)
+}
+
+func callDownload(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, appFilesRepo *testapi.FakeAppFilesRepo) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+	ctxt := testcmd.NewContext("download", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewDownload(ui, config, appFilesRepo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
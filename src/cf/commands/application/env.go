@@ -1,28 +1,45 @@
 package application
 
 import (
+	"bytes"
+	"cf"
+	"cf/api"
 	"cf/configuration"
+	"cf/dotenv"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
+	"encoding/json"
+	"fmt"
 	"github.com/codegangsta/cli"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	HiddenCredentialValue = "[PRIVATE DATA HIDDEN]"
+	EXPORT_FORMAT_DOTENV  = "dotenv"
 )
 
 type Env struct {
-	ui     terminal.UI
-	config *configuration.Configuration
-	appReq requirements.ApplicationRequirement
+	ui         terminal.UI
+	config     *configuration.Configuration
+	appEnvRepo api.AppEnvRepository
+	appReq     requirements.ApplicationRequirement
 }
 
-func NewEnv(ui terminal.UI, config *configuration.Configuration) (cmd *Env) {
+func NewEnv(ui terminal.UI, config *configuration.Configuration, appEnvRepo api.AppEnvRepository) (cmd *Env) {
 	cmd = new(Env)
 	cmd.ui = ui
 	cmd.config = config
+	cmd.appEnvRepo = appEnvRepo
 	return
 }
 
 func (cmd *Env) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
-	if len(c.Args()) < 1 {
+	export := c.String("export")
+	if len(c.Args()) < 1 || (export != "" && export != EXPORT_FORMAT_DOTENV) || (export != "" && c.Bool("json")) {
 		err = errors.New("Incorrect Usage")
 		cmd.ui.FailWithUsage(c, "env")
 		return
@@ -37,25 +54,210 @@ func (cmd *Env) GetRequirements(reqFactory requirements.Factory, c *cli.Context)
 	return
 }
 
-func (cmd *Env) Run(c *cli.Context) {
+func (cmd *Env) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
+	asJson := c.Bool("json")
+	export := c.String("export")
+
+	// machine readable output is kept free of anything else so it can be
+	// redirected to a file
+	if !asJson && export == "" {
+		cmd.ui.Say("Getting env variables for app %s in org %s / space %s as %s...",
+			terminal.EntityNameColor(app.Name),
+			terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+			terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+			terminal.EntityNameColor(cmd.config.Username()),
+		)
+	}
+
+	env, apiResponse := cmd.appEnvRepo.GetEnv(app.Guid)
+	if apiResponse.StatusCode == http.StatusNotFound {
+		// older Cloud Controllers have no env endpoint, so fall back to what
+		// the app itself records
+		if !asJson && export == "" {
+			cmd.ui.Warn("This Cloud Controller does not provide the system env, only showing user-provided env variables")
+		}
+		env = cf.AppEnvironment{UserProvided: map[string]interface{}{}}
+		for key, value := range app.EnvironmentVars {
+			env.UserProvided[key] = value
+		}
+	} else if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	if export == EXPORT_FORMAT_DOTENV {
+		err = cmd.displayDotenv(env.UserProvided)
+		return
+	}
+
+	hidden := false
+	if !c.Bool("reveal") {
+		env.SystemProvided, hidden = hideCredentials(env.SystemProvided)
+	}
 
-	cmd.ui.Say("Getting env variables for app %s in org %s / space %s as %s...",
-		terminal.EntityNameColor(app.Name),
-		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
-		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
-		terminal.EntityNameColor(cmd.config.Username()),
-	)
-	envVars := app.EnvironmentVars
+	if asJson {
+		err = cmd.displayJson(env)
+		return
+	}
 
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
-	if len(envVars) == 0 {
+	if isEmptyEnv(env) {
 		cmd.ui.Say("No env variables exist")
 		return
 	}
-	for key, value := range envVars {
-		cmd.ui.Say("%s: %s", key, terminal.EntityNameColor(value))
+
+	cmd.displaySection("System-Provided:", env.SystemProvided)
+	cmd.displaySection("", env.ApplicationProvided)
+	cmd.displaySection("User-Provided:", env.UserProvided)
+	cmd.displaySection("Running Environment Variable Groups:", env.Running)
+	cmd.displaySection("Staging Environment Variable Groups:", env.Staging)
+
+	if hidden {
+		cmd.ui.Say("Credentials are hidden, use %s to show them", terminal.CommandColor("--reveal"))
+	}
+	return
+}
+
+func (cmd *Env) displayJson(env cf.AppEnvironment) (err errors.Error) {
+	output, jsonErr := json.MarshalIndent(map[string]map[string]interface{}{
+		"environment_json":     env.UserProvided,
+		"system_env_json":      env.SystemProvided,
+		"application_env_json": env.ApplicationProvided,
+		"running_env_json":     env.Running,
+		"staging_env_json":     env.Staging,
+	}, "", "  ")
+	if jsonErr != nil {
+		err = cmd.ui.Failed("Could not encode env as JSON: %s", jsonErr.Error())
+		return
+	}
+	cmd.ui.Say("%s", output)
+	return
+}
+
+func (cmd *Env) displayDotenv(vars map[string]interface{}) (err errors.Error) {
+	stringVars := map[string]string{}
+	for key, value := range vars {
+		if stringValue, ok := value.(string); ok {
+			stringVars[key] = stringValue
+			continue
+		}
+
+		encoded, jsonErr := json.Marshal(value)
+		if jsonErr != nil {
+			err = cmd.ui.Failed("Could not encode env variable %s: %s", key, jsonErr.Error())
+			return
+		}
+		stringVars[key] = string(encoded)
+	}
+
+	buffer := &bytes.Buffer{}
+	dotenv.Write(buffer, stringVars)
+	cmd.ui.Say("%s", strings.TrimSuffix(buffer.String(), "\n"))
+	return
+}
+
+func (cmd *Env) displaySection(title string, vars map[string]interface{}) {
+	if len(vars) == 0 {
+		return
+	}
+
+	if title != "" {
+		cmd.ui.Say(terminal.HeaderColor(title))
+	}
+
+	for _, key := range sortedEnvKeys(vars) {
+		cmd.ui.Say("%s: %s", key, formatEnvValue(vars[key]))
+	}
+	cmd.ui.Say("")
+}
+
+func formatEnvValue(value interface{}) string {
+	if stringValue, ok := value.(string); ok {
+		return terminal.EntityNameColor(stringValue)
+	}
+
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(output)
+}
+
+func sortedEnvKeys(vars map[string]interface{}) (keys []string) {
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
+func isEmptyEnv(env cf.AppEnvironment) bool {
+	return len(env.UserProvided) == 0 &&
+		len(env.SystemProvided) == 0 &&
+		len(env.ApplicationProvided) == 0 &&
+		len(env.Running) == 0 &&
+		len(env.Staging) == 0
+}
+
+// hideCredentials returns a copy of vars in which everything under a
+// "credentials" key is replaced by a placeholder, and whether anything was.
+func hideCredentials(vars map[string]interface{}) (hiddenVars map[string]interface{}, hidden bool) {
+	if vars == nil {
+		return
+	}
+
+	value, hidden := hideCredentialsIn(vars)
+	hiddenVars = value.(map[string]interface{})
+	return
+}
+
+func hideCredentialsIn(value interface{}) (hiddenValue interface{}, hidden bool) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := map[string]interface{}{}
+		for key, nested := range value {
+			if key == "credentials" {
+				copied[key] = hideAllValues(nested)
+				hidden = true
+				continue
+			}
+
+			var nestedHidden bool
+			copied[key], nestedHidden = hideCredentialsIn(nested)
+			hidden = hidden || nestedHidden
+		}
+		hiddenValue = copied
+	case []interface{}:
+		copied := []interface{}{}
+		for _, nested := range value {
+			hiddenNested, nestedHidden := hideCredentialsIn(nested)
+			copied = append(copied, hiddenNested)
+			hidden = hidden || nestedHidden
+		}
+		hiddenValue = copied
+	default:
+		hiddenValue = value
+	}
+	return
+}
+
+func hideAllValues(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := map[string]interface{}{}
+		for key, nested := range value {
+			copied[key] = hideAllValues(nested)
+		}
+		return copied
+	case []interface{}:
+		copied := []interface{}{}
+		for _, nested := range value {
+			copied = append(copied, hideAllValues(nested))
+		}
+		return copied
 	}
+	return HiddenCredentialValue
 }
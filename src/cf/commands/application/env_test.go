@@ -4,7 +4,12 @@ import (
 	"cf"
 	. "cf/commands/application"
 	"cf/configuration"
+	"cf/net"
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
 	testcmd "testhelpers/commands"
 	testconfig "testhelpers/configuration"
@@ -17,18 +22,18 @@ func TestEnvRequirements(t *testing.T) {
 	reqFactory := getEnvDependencies()
 
 	reqFactory.LoginSuccess = true
-	callEnv(t, []string{"my-app"}, reqFactory)
+	callEnv(t, []string{"my-app"}, reqFactory, &testapi.FakeAppEnvRepo{})
 	assert.True(t, testcmd.CommandDidPassRequirements)
 	assert.Equal(t, reqFactory.ApplicationName, "my-app")
 
 	reqFactory.LoginSuccess = false
-	callEnv(t, []string{"my-app"}, reqFactory)
+	callEnv(t, []string{"my-app"}, reqFactory, &testapi.FakeAppEnvRepo{})
 	assert.False(t, testcmd.CommandDidPassRequirements)
 }
 
 func TestEnvFailsWithUsage(t *testing.T) {
 	reqFactory := getEnvDependencies()
-	ui := callEnv(t, []string{}, reqFactory)
+	ui := callEnv(t, []string{}, reqFactory, &testapi.FakeAppEnvRepo{})
 
 	assert.True(t, ui.FailedWithUsage)
 	assert.False(t, testcmd.CommandDidPassRequirements)
@@ -36,17 +41,129 @@ func TestEnvFailsWithUsage(t *testing.T) {
 
 func TestEnvListsEnvironmentVariables(t *testing.T) {
 	reqFactory := getEnvDependencies()
-	reqFactory.Application.EnvironmentVars = map[string]string{
-		"my-key":  "my-value",
-		"my-key2": "my-value2",
+	appEnvRepo := &testapi.FakeAppEnvRepo{
+		Env: cf.AppEnvironment{
+			UserProvided: map[string]interface{}{
+				"my-key":  "my-value",
+				"my-key2": "my-value2",
+			},
+		},
 	}
 
-	ui := callEnv(t, []string{"my-app"}, reqFactory)
+	ui := callEnv(t, []string{"my-app"}, reqFactory, appEnvRepo)
 
+	assert.Equal(t, appEnvRepo.AppGuid, "my-app-guid")
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
 		{"Getting env variables for app", "my-app", "my-org", "my-space", "my-user"},
 		{"OK"},
-		{"my-key", "my-value", "my-key2", "my-value2"},
+		{"User-Provided:"},
+		{"my-key", "my-value"},
+		{"my-key2", "my-value2"},
+	})
+}
+
+func TestEnvListsSystemProvidedEnvWithCredentialsHidden(t *testing.T) {
+	ui := callEnv(t, []string{"my-app"}, getEnvDependencies(), &testapi.FakeAppEnvRepo{Env: envWithServices()})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"System-Provided:"},
+		{"VCAP_SERVICES"},
+		{"password", "[PRIVATE DATA HIDDEN]"},
+		{"name", "my-db"},
+		{"VCAP_APPLICATION"},
+		{"application_name", "my-app"},
+		{"User-Provided:"},
+		{"my-key", "my-value"},
+		{"Running Environment Variable Groups:"},
+		{"RUNNING_KEY", "running-value"},
+		{"Staging Environment Variable Groups:"},
+		{"STAGING_KEY", "staging-value"},
+		{"Credentials are hidden", "--reveal"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"secret"},
+	})
+}
+
+func TestEnvRevealsCredentials(t *testing.T) {
+	ui := callEnv(t, []string{"--reveal", "my-app"}, getEnvDependencies(), &testapi.FakeAppEnvRepo{Env: envWithServices()})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"VCAP_SERVICES"},
+		{"password", "secret"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"PRIVATE DATA HIDDEN"},
+		{"Credentials are hidden"},
+	})
+}
+
+func TestEnvAsJson(t *testing.T) {
+	ui := callEnv(t, []string{"--json", "my-app"}, getEnvDependencies(), &testapi.FakeAppEnvRepo{Env: envWithServices()})
+
+	output := map[string]map[string]interface{}{}
+	err := json.Unmarshal([]byte(strings.Join(ui.Outputs, "\n")), &output)
+	assert.NoError(t, err)
+
+	assert.Equal(t, output["environment_json"]["my-key"], "my-value")
+	assert.Equal(t, output["running_env_json"]["RUNNING_KEY"], "running-value")
+	services := output["system_env_json"]["VCAP_SERVICES"].(map[string]interface{})
+	credentials := services["p-mysql"].([]interface{})[0].(map[string]interface{})["credentials"].(map[string]interface{})
+	assert.Equal(t, credentials["password"], "[PRIVATE DATA HIDDEN]")
+}
+
+func TestEnvExportsUserProvidedEnvAsDotenv(t *testing.T) {
+	appEnvRepo := &testapi.FakeAppEnvRepo{
+		Env: cf.AppEnvironment{
+			UserProvided: map[string]interface{}{
+				"PLAIN":   "value",
+				"SPACES":  "two words",
+				"NUMBERS": []interface{}{1.0, 2.0},
+			},
+			SystemProvided: map[string]interface{}{"VCAP_SERVICES": map[string]interface{}{}},
+		},
+	}
+
+	ui := callEnv(t, []string{"--export", "dotenv", "my-app"}, getEnvDependencies(), appEnvRepo)
+
+	assert.Equal(t, ui.Outputs, []string{
+		"NUMBERS=[1,2]",
+		"PLAIN=value",
+		`SPACES="two words"`,
+	})
+}
+
+func TestEnvExportFailsWithUsage(t *testing.T) {
+	ui := callEnv(t, []string{"--export", "yaml", "my-app"}, getEnvDependencies(), &testapi.FakeAppEnvRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callEnv(t, []string{"--export", "dotenv", "--json", "my-app"}, getEnvDependencies(), &testapi.FakeAppEnvRepo{})
+	assert.True(t, ui.FailedWithUsage)
+}
+
+func TestEnvFallsBackToUserProvidedEnvWhenTheEndpointIsMissing(t *testing.T) {
+	reqFactory := getEnvDependencies()
+	reqFactory.Application.EnvironmentVars = map[string]string{"my-key": "my-value"}
+	appEnvRepo := &testapi.FakeAppEnvRepo{ApiResponse: net.NewApiResponse("Unknown request", "10000", http.StatusNotFound)}
+
+	ui := callEnv(t, []string{"my-app"}, reqFactory, appEnvRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"does not provide the system env"},
+		{"OK"},
+		{"User-Provided:"},
+		{"my-key", "my-value"},
+	})
+}
+
+func TestEnvWhenGettingTheEnvFails(t *testing.T) {
+	appEnvRepo := &testapi.FakeAppEnvRepo{ApiResponse: net.NewApiResponse("Server error", "10001", http.StatusInternalServerError)}
+
+	ui := callEnv(t, []string{"my-app"}, getEnvDependencies(), appEnvRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Server error"},
 	})
 }
 
@@ -54,7 +171,7 @@ func TestEnvShowsEmptyMessage(t *testing.T) {
 	reqFactory := getEnvDependencies()
 	reqFactory.Application.EnvironmentVars = map[string]string{}
 
-	ui := callEnv(t, []string{"my-app"}, reqFactory)
+	ui := callEnv(t, []string{"my-app"}, reqFactory, &testapi.FakeAppEnvRepo{})
 
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
 		{"Getting env variables for app", "my-app"},
@@ -63,7 +180,7 @@ func TestEnvShowsEmptyMessage(t *testing.T) {
 	})
 }
 
-func callEnv(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory) (ui *testterm.FakeUI) {
+func callEnv(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, appEnvRepo *testapi.FakeAppEnvRepo) (ui *testterm.FakeUI) {
 	ui = &testterm.FakeUI{}
 	ctxt := testcmd.NewContext("env", args)
 
@@ -81,7 +198,7 @@ func callEnv(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory) (u
 		AccessToken:        token,
 	}
 
-	cmd := NewEnv(ui, config)
+	cmd := NewEnv(ui, config, appEnvRepo)
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
 	return
@@ -90,6 +207,28 @@ func callEnv(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory) (u
 func getEnvDependencies() (reqFactory *testreq.FakeReqFactory) {
 	app := cf.Application{}
 	app.Name = "my-app"
+	app.Guid = "my-app-guid"
 	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, Application: app}
 	return
 }
+
+func envWithServices() cf.AppEnvironment {
+	return cf.AppEnvironment{
+		UserProvided: map[string]interface{}{"my-key": "my-value"},
+		SystemProvided: map[string]interface{}{
+			"VCAP_SERVICES": map[string]interface{}{
+				"p-mysql": []interface{}{
+					map[string]interface{}{
+						"name":        "my-db",
+						"credentials": map[string]interface{}{"password": "secret"},
+					},
+				},
+			},
+		},
+		ApplicationProvided: map[string]interface{}{
+			"VCAP_APPLICATION": map[string]interface{}{"application_name": "my-app"},
+		},
+		Running: map[string]interface{}{"RUNNING_KEY": "running-value"},
+		Staging: map[string]interface{}{"STAGING_KEY": "staging-value"},
+	}
+}
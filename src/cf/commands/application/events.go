@@ -3,9 +3,9 @@ package application
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 	"strconv"
 )
@@ -42,7 +42,7 @@ func (cmd *Events) GetRequirements(reqFactory requirements.Factory, c *cli.Conte
 	return
 }
 
-func (cmd *Events) Run(c *cli.Context) {
+func (cmd *Events) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
 
 	cmd.ui.Say("Getting events for app %s in org %s / space %s as %s...\n",
@@ -73,11 +73,12 @@ func (cmd *Events) Run(c *cli.Context) {
 
 	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
-		cmd.ui.Failed("Failed fetching events.\n%s", apiStatus.Message)
+		err = cmd.ui.Failed("Failed fetching events.\n%s", apiStatus.Message)
 		return
 	}
 	if noEvents {
 		cmd.ui.Say("No events for app %s", terminal.EntityNameColor(app.Name))
 		return
 	}
+	return
 }
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"cf"
+	"cf/terminal"
+	"fmt"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	FailureReportCrashCount = 5
+	FailureReportLogLines   = 20
+)
+
+// printFailureReport gathers what is usually needed to understand why an app
+// did not start: its latest crashes, its latest non staging log lines and the
+// state of its instances. Sections that cannot be fetched are left out, since
+// the report is printed on the way to failing anyway.
+func (cmd Start) printFailureReport(app cf.Application, instances []cf.AppInstanceFields) {
+	cmd.ui.Say("")
+	cmd.ui.Say("Gathering diagnostics for app %s...", terminal.EntityNameColor(app.Name))
+
+	cmd.printRecentCrashes(app)
+	cmd.printRecentLogs(app)
+
+	if len(instances) > 0 {
+		cmd.ui.Say("\n%s", terminal.HeaderColor("Instances:"))
+
+		table := [][]string{
+			[]string{"", "state", "since", "cpu", "memory", "disk"},
+		}
+		now := time.Now()
+		for index, instance := range instances {
+			table = append(table, instanceRow(index, instance, instances, now))
+		}
+		cmd.ui.DisplayTable(table)
+	}
+
+	cmd.ui.Say("")
+}
+
+func (cmd Start) printRecentCrashes(app cf.Application) {
+	eventChan, statusChan := cmd.appEventsRepo.ListEvents(app.Guid)
+	events := []cf.EventFields{}
+	for page := range eventChan {
+		events = append(events, page...)
+	}
+
+	apiStatus := <-statusChan
+	if apiStatus.IsNotSuccessful() {
+		cmd.ui.Say("\nCould not fetch crash events: %s", apiStatus.Message)
+		return
+	}
+
+	cmd.ui.Say("\n%s", terminal.HeaderColor("Recent crashes:"))
+	if len(events) == 0 {
+		cmd.ui.Say("none")
+		return
+	}
+
+	sort.Sort(eventsByNewest(events))
+	if len(events) > FailureReportCrashCount {
+		events = events[:FailureReportCrashCount]
+	}
+
+	table := [][]string{
+		[]string{"time", "instance", "description", "exit status"},
+	}
+	for _, event := range events {
+		table = append(table, []string{
+			event.Timestamp.Local().Format(TIMESTAMP_FORMAT),
+			strconv.Itoa(event.InstanceIndex),
+			event.ExitDescription,
+			strconv.Itoa(event.ExitStatus),
+		})
+	}
+	cmd.ui.DisplayTable(table)
+}
+
+func (cmd Start) printRecentLogs(app cf.Application) {
+	logChan := make(chan *logmessage.Message, 1000)
+
+	var err error
+	go func() {
+		defer close(logChan)
+		err = cmd.logRepo.RecentLogsFor(app.Guid, func() {}, logChan)
+	}()
+
+	lines := []string{}
+	for msg := range logChan {
+		if msg.GetLogMessage().GetSourceName() == LogMessageTypeStaging {
+			continue
+		}
+		lines = append(lines, logMessageOutput(msg))
+	}
+
+	if err != nil {
+		cmd.ui.Say("\nCould not fetch recent logs: %s", err.Error())
+		return
+	}
+
+	if len(lines) > FailureReportLogLines {
+		lines = lines[len(lines)-FailureReportLogLines:]
+	}
+
+	cmd.ui.Say("\n%s", terminal.HeaderColor(fmt.Sprintf("Last %d log lines:", len(lines))))
+	for _, line := range lines {
+		cmd.ui.Say(line)
+	}
+}
+
+type eventsByNewest []cf.EventFields
+
+func (events eventsByNewest) Len() int      { return len(events) }
+func (events eventsByNewest) Swap(i, j int) { events[i], events[j] = events[j], events[i] }
+
+func (events eventsByNewest) Less(i, j int) bool {
+	return events[i].Timestamp.After(events[j].Timestamp)
+}
@@ -1,19 +1,27 @@
 package application
 
 import (
+	"bytes"
+	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
+	"strings"
+	"time"
 )
 
+const DefaultTailInterval = 1 * time.Second
+
 type Files struct {
 	ui           terminal.UI
 	config       *configuration.Configuration
 	appFilesRepo api.AppFilesRepository
 	appReq       requirements.ApplicationRequirement
+
+	TailInterval time.Duration
 }
 
 func NewFiles(ui terminal.UI, config *configuration.Configuration, appFilesRepo api.AppFilesRepository) (cmd *Files) {
@@ -21,11 +29,18 @@ func NewFiles(ui terminal.UI, config *configuration.Configuration, appFilesRepo
 	cmd.ui = ui
 	cmd.config = config
 	cmd.appFilesRepo = appFilesRepo
+	cmd.TailInterval = DefaultTailInterval
 	return
 }
 
 func (cmd *Files) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
-	if len(c.Args()) < 1 {
+	if len(c.Args()) < 1 || c.Int("i") < 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "files")
+		return
+	}
+
+	if c.Bool("tail") && len(c.Args()) < 2 {
 		err = errors.New("Incorrect Usage")
 		cmd.ui.FailWithUsage(c, "files")
 		return
@@ -41,9 +56,21 @@ func (cmd *Files) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 	return
 }
 
-func (cmd *Files) Run(c *cli.Context) {
+func (cmd *Files) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
 
+	instance := c.Int("i")
+
+	path := "/"
+	if len(c.Args()) > 1 {
+		path = c.Args()[1]
+	}
+
+	if c.Bool("tail") {
+		err = cmd.tailFile(app, instance, path)
+		return
+	}
+
 	cmd.ui.Say("Getting files for app %s in org %s / space %s as %s...",
 		terminal.EntityNameColor(app.Name),
 		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
@@ -51,18 +78,63 @@ func (cmd *Files) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
-	path := "/"
-	if len(c.Args()) > 1 {
-		path = c.Args()[1]
-	}
-
-	list, apiResponse := cmd.appFilesRepo.ListFiles(app.Guid, path)
+	list, apiResponse := cmd.appFilesRepo.ListFiles(app.Guid, instance, path)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 	cmd.ui.Say("%s", list)
+	return
+}
+
+// tailFile prints the file as it grows, polling for the bytes past what has
+// already been printed until the file can no longer be read.
+func (cmd *Files) tailFile(app cf.Application, instance int, path string) (err errors.Error) {
+	cmd.ui.Say("Tailing %s on instance #%d of app %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(path),
+		instance,
+		terminal.EntityNameColor(app.Name),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+	cmd.ui.Say("")
+
+	var offset int64
+	pending := &bytes.Buffer{}
+
+	for {
+		newOffset, apiResponse := cmd.appFilesRepo.DownloadFileFrom(app.Guid, instance, path, offset, pending)
+		if apiResponse.IsNotSuccessful() {
+			if pending.Len() > 0 {
+				cmd.ui.Say("%s", pending.String())
+			}
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+		offset = newOffset
+
+		cmd.sayCompleteLines(pending)
+		cmd.ui.Wait(cmd.TailInterval)
+	}
+}
+
+// sayCompleteLines prints every full line in pending and keeps the trailing
+// partial line for the next poll.
+func (cmd *Files) sayCompleteLines(pending *bytes.Buffer) {
+	contents := pending.String()
+	lastNewline := strings.LastIndex(contents, "\n")
+	if lastNewline == -1 {
+		return
+	}
+
+	for _, line := range strings.Split(contents[:lastNewline], "\n") {
+		cmd.ui.Say("%s", line)
+	}
+
+	pending.Reset()
+	pending.WriteString(contents[lastNewline+1:])
 }
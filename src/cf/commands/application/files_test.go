@@ -12,6 +12,7 @@ import (
 	testreq "testhelpers/requirements"
 	testterm "testhelpers/terminal"
 	"testing"
+	"time"
 )
 
 func TestFilesRequirements(t *testing.T) {
@@ -77,6 +78,60 @@ func TestListingFilesWithTemplateTokens(t *testing.T) {
 	})
 }
 
+func TestListingFilesOfAnInstance(t *testing.T) {
+	app := cf.Application{}
+	app.Guid = "my-app-guid"
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	appFilesRepo := &testapi.FakeAppFilesRepo{FileList: "file 1"}
+
+	callFiles(t, []string{"-i", "3", "my-app", "/foo"}, reqFactory, appFilesRepo)
+
+	assert.Equal(t, appFilesRepo.Instance, 3)
+	assert.Equal(t, appFilesRepo.Path, "/foo")
+}
+
+func TestFilesFailsWithUsageForANegativeInstance(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: cf.Application{}}
+	ui := callFiles(t, []string{"-i", "-1", "my-app"}, reqFactory, &testapi.FakeAppFilesRepo{})
+
+	assert.True(t, ui.FailedWithUsage)
+}
+
+func TestTailingFilesRequiresAPath(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: cf.Application{}}
+	ui := callFiles(t, []string{"--tail", "my-app"}, reqFactory, &testapi.FakeAppFilesRepo{})
+
+	assert.True(t, ui.FailedWithUsage)
+}
+
+func TestTailingAFile(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-found-app"
+	app.Guid = "my-app-guid"
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	appFilesRepo := &testapi.FakeAppFilesRepo{
+		TailChunks: []string{"line 1\nline", "", " 2\nline 3\n", "partial"},
+	}
+
+	ui := callFiles(t, []string{"--tail", "-i", "1", "my-app", "logs/stdout.log"}, reqFactory, appFilesRepo)
+
+	assert.Equal(t, appFilesRepo.Instance, 1)
+	assert.Equal(t, appFilesRepo.Path, "logs/stdout.log")
+	assert.Equal(t, appFilesRepo.TailOffsets, []int64{0, 11, 11, 21, 28})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Tailing", "logs/stdout.log", "#1", "my-found-app", "my-org", "my-space", "my-user"},
+		{"line 1"},
+		{"line 2"},
+		{"line 3"},
+		{"partial"},
+		{"FAILED"},
+		{"not found"},
+	})
+}
+
 func callFiles(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, appFilesRepo *testapi.FakeAppFilesRepo) (ui *testterm.FakeUI) {
 	ui = &testterm.FakeUI{}
 	ctxt := testcmd.NewContext("files", args)
@@ -96,6 +151,7 @@ func callFiles(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory,
 	}
 
 	cmd := NewFiles(ui, config, appFilesRepo)
+	cmd.TailInterval = time.Millisecond
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
 	return
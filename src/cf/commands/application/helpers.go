@@ -12,7 +12,8 @@ import (
 )
 
 const (
-	TIMESTAMP_FORMAT = "2006-01-02T15:04:05.00-0700"
+	TIMESTAMP_FORMAT    = "2006-01-02T15:04:05.00-0700"
+	RECENT_START_WINDOW = 15 * time.Minute
 )
 
 func NewLogMessage(msgText, appGuid, sourceName string, timestamp time.Time) (msg *logmessage.Message) {
@@ -158,3 +159,22 @@ func coloredInstanceState(instance cf.AppInstanceFields) (colored string) {
 
 	return
 }
+
+// recentlyStarted reports whether the instance came up within the last
+// RECENT_START_WINDOW, at least a minute after the app's oldest instance.
+// An instance started alongside the rest of the app is not counted. The
+// instance may have been restarted, recovered from a crash or been added by
+// scaling; the instance state doesn't say which.
+func recentlyStarted(instance cf.AppInstanceFields, instances []cf.AppInstanceFields, now time.Time) bool {
+	if instance.Since.IsZero() || now.Sub(instance.Since) > RECENT_START_WINDOW {
+		return false
+	}
+
+	oldest := instance.Since
+	for _, other := range instances {
+		if !other.Since.IsZero() && other.Since.Before(oldest) {
+			oldest = other.Since
+		}
+	}
+	return instance.Since.Sub(oldest) > time.Minute
+}
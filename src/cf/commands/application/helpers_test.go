@@ -1,6 +1,7 @@
 package application
 
 import (
+	"cf"
 	"cf/terminal"
 	"code.google.com/p/gogoprotobuf/proto"
 	"fmt"
@@ -90,3 +91,24 @@ func createMessage(t *testing.T, protoMsg *logmessage.LogMessage, sourceName *st
 
 	return
 }
+
+func TestRecentlyStarted(t *testing.T) {
+	now := time.Now()
+
+	original := cf.AppInstanceFields{}
+	original.Since = now.Add(-1 * time.Hour)
+	late := cf.AppInstanceFields{}
+	late.Since = now.Add(-2 * time.Minute)
+	instances := []cf.AppInstanceFields{original, late}
+
+	assert.False(t, recentlyStarted(original, instances, now))
+	assert.True(t, recentlyStarted(late, instances, now))
+
+	longAgo := cf.AppInstanceFields{}
+	longAgo.Since = now.Add(-30 * time.Minute)
+	assert.False(t, recentlyStarted(longAgo, []cf.AppInstanceFields{original, longAgo}, now))
+
+	freshlyStarted := cf.AppInstanceFields{}
+	freshlyStarted.Since = now.Add(-2 * time.Minute)
+	assert.False(t, recentlyStarted(freshlyStarted, []cf.AppInstanceFields{freshlyStarted, freshlyStarted}, now))
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/formatters"
 	"cf/requirements"
 	"cf/terminal"
@@ -31,7 +32,7 @@ func (cmd ListApps) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 	return
 }
 
-func (cmd ListApps) Run(c *cli.Context) {
+func (cmd ListApps) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting apps in org %s / space %s as %s...",
 		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
 		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
@@ -41,7 +42,7 @@ func (cmd ListApps) Run(c *cli.Context) {
 	apps, apiResponse := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -74,4 +75,5 @@ func (cmd ListApps) Run(c *cli.Context) {
 	}
 
 	cmd.ui.DisplayTable(table)
+	return
 }
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/codegangsta/cli"
 	"time"
@@ -44,23 +44,29 @@ func (cmd *Logs) GetRequirements(reqFactory requirements.Factory, c *cli.Context
 	return
 }
 
-func (cmd *Logs) Run(c *cli.Context) {
+func (cmd *Logs) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
 	logChan := make(chan *logmessage.Message, 1000)
 
+	var logsErr error
 	go func() {
 		defer close(logChan)
 		if c.Bool("recent") {
-			cmd.recentLogsFor(app, logChan)
+			logsErr = cmd.recentLogsFor(app, logChan)
 		} else {
-			cmd.tailLogsFor(app, logChan)
+			logsErr = cmd.tailLogsFor(app, logChan)
 		}
 	}()
 
 	cmd.displayLogMessages(logChan)
+
+	if logsErr != nil {
+		err = cmd.ui.Failed(logsErr.Error())
+	}
+	return
 }
 
-func (cmd *Logs) recentLogsFor(app cf.Application, logChan chan *logmessage.Message) {
+func (cmd *Logs) recentLogsFor(app cf.Application, logChan chan *logmessage.Message) error {
 	onConnect := func() {
 		cmd.ui.Say("Connected, dumping recent logs for app %s in org %s / space %s as %s...\n",
 			terminal.EntityNameColor(app.Name),
@@ -70,14 +76,10 @@ func (cmd *Logs) recentLogsFor(app cf.Application, logChan chan *logmessage.Mess
 		)
 	}
 
-	err := cmd.logsRepo.RecentLogsFor(app.Guid, onConnect, logChan)
-	if err != nil {
-		cmd.ui.Failed(err.Error())
-		return
-	}
+	return cmd.logsRepo.RecentLogsFor(app.Guid, onConnect, logChan)
 }
 
-func (cmd *Logs) tailLogsFor(app cf.Application, logChan chan *logmessage.Message) {
+func (cmd *Logs) tailLogsFor(app cf.Application, logChan chan *logmessage.Message) error {
 	onConnect := func() {
 		cmd.ui.Say("Connected, tailing logs for app %s in org %s / space %s as %s...\n",
 			terminal.EntityNameColor(app.Name),
@@ -91,11 +93,7 @@ func (cmd *Logs) tailLogsFor(app cf.Application, logChan chan *logmessage.Messag
 	stopLoggingChan := make(chan bool)
 	defer close(stopLoggingChan)
 
-	err := cmd.logsRepo.TailLogsFor(app.Guid, onConnect, logChan, stopLoggingChan, 5*time.Second)
-	if err != nil {
-		cmd.ui.Failed(err.Error())
-		return
-	}
+	return cmd.logsRepo.TailLogsFor(app.Guid, onConnect, logChan, stopLoggingChan, 5*time.Second)
 }
 
 func (cmd *Logs) displayLogMessages(logChan chan *logmessage.Message) {
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/errors"
+	"cf/metrics"
+	"cf/requirements"
+	"cf/terminal"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMetricsListenAddress = ":9100"
+	DefaultMetricsInterval      = 15 * time.Second
+)
+
+type MetricsExporter struct {
+	ui               terminal.UI
+	config           *configuration.Configuration
+	appSummaryRepo   api.AppSummaryRepository
+	appInstancesRepo api.AppInstancesRepository
+}
+
+func NewMetricsExporter(ui terminal.UI, config *configuration.Configuration, appSummaryRepo api.AppSummaryRepository, appInstancesRepo api.AppInstancesRepository) (cmd *MetricsExporter) {
+	cmd = new(MetricsExporter)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appSummaryRepo = appSummaryRepo
+	cmd.appInstancesRepo = appInstancesRepo
+	return
+}
+
+func (cmd *MetricsExporter) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 0 || c.Int("interval") < 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "metrics-exporter")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+	}
+	return
+}
+
+func (cmd *MetricsExporter) Run(c *cli.Context) (err errors.Error) {
+	listenAddress := c.String("listen")
+	if listenAddress == "" {
+		listenAddress = DefaultMetricsListenAddress
+	}
+
+	interval := DefaultMetricsInterval
+	if c.Int("interval") > 0 {
+		interval = time.Duration(c.Int("interval")) * time.Second
+	}
+
+	appNames := []string{}
+	for _, name := range strings.Split(c.String("apps"), ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			appNames = append(appNames, name)
+		}
+	}
+
+	cmd.ui.Say("Exporting metrics for %s in org %s / space %s as %s...",
+		describeExportedApps(appNames),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	exporter := metrics.NewExporter()
+
+	snapshot, err := cmd.Collect(appNames)
+	if err != nil {
+		err = cmd.ui.FailWithError(err)
+		return
+	}
+	exporter.Update(snapshot, time.Now())
+
+	listener, listenErr := net.Listen("tcp", listenAddress)
+	if listenErr != nil {
+		err = cmd.ui.Failed("Could not listen on %s: %s", listenAddress, listenErr.Error())
+		return
+	}
+	defer listener.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	go http.Serve(listener, mux)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("Serving metrics on %s, refreshing every %s", terminal.EntityNameColor("http://"+listener.Addr().String()+"/metrics"), interval)
+
+	for {
+		cmd.ui.Wait(interval)
+
+		snapshot, err = cmd.Collect(appNames)
+		if err != nil {
+			cmd.ui.Warn("Could not refresh metrics: %s", err.Error())
+			exporter.UpdateFailed(time.Now())
+			continue
+		}
+		exporter.Update(snapshot, time.Now())
+	}
+}
+
+// Collect fetches the stats of every instance of the named apps, or of every
+// app in the space when no names are given. Stopped apps are reported
+// without instances. An app whose instances can't be fetched is warned about
+// and reported in FailedApps, so the rest of the space is still exported.
+func (cmd *MetricsExporter) Collect(appNames []string) (snapshot metrics.Snapshot, err errors.Error) {
+	snapshot.Org = cmd.config.OrganizationFields.Name
+	snapshot.Space = cmd.config.SpaceFields.Name
+
+	summaries, apiResponse := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+	if apiResponse.IsNotSuccessful() {
+		err = apiResponse.ToError()
+		return
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range appNames {
+		wanted[name] = true
+	}
+
+	for _, summary := range summaries {
+		if len(appNames) > 0 && !wanted[summary.Name] {
+			continue
+		}
+		delete(wanted, summary.Name)
+
+		stats := metrics.AppStats{Name: summary.Name, Guid: summary.Guid, State: summary.State}
+		if summary.State == "started" {
+			stats.Instances, apiResponse = cmd.appInstancesRepo.GetInstances(summary.Guid)
+			if apiResponse.IsNotSuccessful() {
+				cmd.ui.Warn("Could not get the instances of app %s: %s", summary.Name, apiResponse.Message)
+				stats.Instances = nil
+				snapshot.FailedApps = append(snapshot.FailedApps, stats)
+				continue
+			}
+		}
+		snapshot.Apps = append(snapshot.Apps, stats)
+	}
+
+	for _, name := range appNames {
+		if wanted[name] {
+			err = errors.NewNotFoundError(fmt.Sprintf("App %s not found", name))
+			return
+		}
+	}
+	return
+}
+
+func describeExportedApps(appNames []string) string {
+	if len(appNames) == 0 {
+		return "all apps"
+	}
+	return "apps " + terminal.EntityNameColor(strings.Join(appNames, ", "))
+}
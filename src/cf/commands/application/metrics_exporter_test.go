@@ -0,0 +1,167 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"cf/errors"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestMetricsExporterRequirements(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callMetricsExporter(t, []string{"--listen", "bad-address"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callMetricsExporter(t, []string{"--listen", "bad-address"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestMetricsExporterFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui, _ := callMetricsExporter(t, []string{"my-app"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui, _ = callMetricsExporter(t, []string{"--interval", "-5"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+}
+
+func TestMetricsExporterCollectsTheNamedApps(t *testing.T) {
+	appSummaryRepo, appInstancesRepo := metricsExporterRepos()
+	_, cmd := createMetricsExporter(t, appSummaryRepo, appInstancesRepo)
+
+	snapshot, err := cmd.Collect([]string{"my-app", "stopped-app"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot.Org, "my-org")
+	assert.Equal(t, snapshot.Space, "my-space")
+	assert.Equal(t, len(snapshot.Apps), 2)
+	assert.Equal(t, snapshot.Apps[0].Name, "my-app")
+	assert.Equal(t, snapshot.Apps[0].Instances[0].State, cf.InstanceState(cf.InstanceRunning))
+	assert.Equal(t, snapshot.Apps[1].Name, "stopped-app")
+	assert.Equal(t, len(snapshot.Apps[1].Instances), 0)
+	assert.Equal(t, appInstancesRepo.GetInstancesAppGuid, "my-app-guid")
+}
+
+func TestMetricsExporterCollectsTheWholeSpace(t *testing.T) {
+	appSummaryRepo, appInstancesRepo := metricsExporterRepos()
+	_, cmd := createMetricsExporter(t, appSummaryRepo, appInstancesRepo)
+
+	snapshot, err := cmd.Collect([]string{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(snapshot.Apps), 3)
+}
+
+func TestMetricsExporterFailsWhenANamedAppDoesNotExist(t *testing.T) {
+	appSummaryRepo, appInstancesRepo := metricsExporterRepos()
+	_, cmd := createMetricsExporter(t, appSummaryRepo, appInstancesRepo)
+
+	_, err := cmd.Collect([]string{"my-app", "missing-app"})
+
+	assert.Equal(t, err.Error(), "App missing-app not found")
+	assert.Equal(t, err.ExitCode(), errors.EXIT_NOT_FOUND)
+}
+
+func TestMetricsExporterSkipsAnAppWhoseInstancesCannotBeFetched(t *testing.T) {
+	appSummaryRepo, appInstancesRepo := metricsExporterRepos()
+	appSummaryRepo.GetSummariesInCurrentSpaceApps[1].State = "started"
+	instance := cf.AppInstanceFields{}
+	instance.State = cf.InstanceRunning
+	appInstancesRepo.GetInstancesResponses = [][]cf.AppInstanceFields{nil, []cf.AppInstanceFields{instance}}
+	appInstancesRepo.GetInstancesErrorCodes = []string{"10001", ""}
+	ui, cmd := createMetricsExporter(t, appSummaryRepo, appInstancesRepo)
+
+	snapshot, err := cmd.Collect([]string{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(snapshot.Apps), 2)
+	assert.Equal(t, snapshot.Apps[0].Name, "other-app")
+	assert.Equal(t, len(snapshot.Apps[0].Instances), 1)
+	assert.Equal(t, snapshot.Apps[1].Name, "stopped-app")
+	assert.Equal(t, len(snapshot.FailedApps), 1)
+	assert.Equal(t, snapshot.FailedApps[0].Name, "my-app")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Could not get the instances of app my-app"},
+	})
+}
+
+func TestMetricsExporterFailsWhenItCannotListen(t *testing.T) {
+	appSummaryRepo, appInstancesRepo := metricsExporterRepos()
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui, _ := callMetricsExporter(t, []string{"--listen", "bad-address", "--apps", "my-app"}, reqFactory, appSummaryRepo, appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Exporting metrics", "my-app", "my-org", "my-space", "my-user"},
+		{"FAILED"},
+		{"Could not listen on bad-address"},
+	})
+}
+
+func metricsExporterRepos() (appSummaryRepo *testapi.FakeAppSummaryRepo, appInstancesRepo *testapi.FakeAppInstancesRepo) {
+	myApp := cf.AppSummary{}
+	myApp.Name = "my-app"
+	myApp.Guid = "my-app-guid"
+	myApp.State = "started"
+
+	otherApp := cf.AppSummary{}
+	otherApp.Name = "other-app"
+	otherApp.Guid = "other-app-guid"
+	otherApp.State = "stopped"
+
+	stoppedApp := cf.AppSummary{}
+	stoppedApp.Name = "stopped-app"
+	stoppedApp.Guid = "stopped-app-guid"
+	stoppedApp.State = "stopped"
+
+	appSummaryRepo = &testapi.FakeAppSummaryRepo{
+		GetSummariesInCurrentSpaceApps: []cf.AppSummary{myApp, otherApp, stoppedApp},
+	}
+
+	instance := cf.AppInstanceFields{}
+	instance.State = cf.InstanceRunning
+	appInstancesRepo = &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{instance},
+		},
+	}
+	return
+}
+
+func callMetricsExporter(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, appSummaryRepo *testapi.FakeAppSummaryRepo, appInstancesRepo *testapi.FakeAppInstancesRepo) (ui *testterm.FakeUI, cmd *MetricsExporter) {
+	ui, cmd = createMetricsExporter(t, appSummaryRepo, appInstancesRepo)
+	ctxt := testcmd.NewContext("metrics-exporter", args)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
+
+func createMetricsExporter(t *testing.T, appSummaryRepo *testapi.FakeAppSummaryRepo, appInstancesRepo *testapi.FakeAppInstancesRepo) (ui *testterm.FakeUI, cmd *MetricsExporter) {
+	ui = &testterm.FakeUI{}
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd = NewMetricsExporter(ui, config, appSummaryRepo, appInstancesRepo)
+	return
+}
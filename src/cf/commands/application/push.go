@@ -5,12 +5,13 @@ import (
 	"cf/api"
 	"cf/commands/service"
 	"cf/configuration"
+	"cf/errors"
 	"cf/formatters"
 	"cf/manifest"
 	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
+	"crypto/rand"
 	"generic"
 	"github.com/codegangsta/cli"
 	"os"
@@ -63,24 +64,51 @@ func (cmd *Push) GetRequirements(reqFactory requirements.Factory, c *cli.Context
 	return
 }
 
-func (cmd *Push) Run(c *cli.Context) {
-	appSet := cmd.findAndValidateAppsToPush(c)
+func (cmd *Push) Run(c *cli.Context) (err errors.Error) {
+	appSet, m, err := cmd.findAndValidateAppsToPush(c)
+	if err != nil {
+		return
+	}
+
+	if c.Bool("dry-run") || net.IsDryRun() {
+		err = cmd.showPlan(c, appSet, m)
+		return
+	}
 
 	for _, appParams := range appSet {
-		cmd.fetchStackGuid(appParams)
+		err = cmd.fetchStackGuid(appParams)
+		if err != nil {
+			return
+		}
 
-		app, didCreate := cmd.app(appParams)
+		var app cf.Application
+		var didCreate bool
+		app, didCreate, err = cmd.app(appParams)
+		if err != nil {
+			return
+		}
 		if !didCreate {
-			cmd.updateApp(&app, appParams)
+			err = cmd.updateApp(&app, appParams)
+			if err != nil {
+				return
+			}
 		}
 
-		cmd.bindAppToRoute(app, appParams, c)
+		err = cmd.bindAppToRoutes(app, appParams, c)
+		if err != nil {
+			return
+		}
 
 		cmd.ui.Say("Uploading %s...", terminal.EntityNameColor(app.Name))
 
-		apiResponse := cmd.appBitsRepo.UploadApp(app.Guid, appParams.Get("path").(string), cmd.describeUploadOperation)
+		if c.Bool("show-ignored") {
+			cmd.showIgnoredFiles(appParams.Get("path").(string))
+		}
+
+		zipOptions := cf.ZipOptions{DereferenceSymlinks: c.Bool("dereference-symlinks")}
+		apiResponse := cmd.appBitsRepo.UploadApp(app.Guid, appParams.Get("path").(string), zipOptions, terminal.NewProgressBar(), cmd.describeUploadOperation)
 		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Failed(apiResponse.Message)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
 			return
 		}
 		cmd.ui.Ok()
@@ -89,10 +117,9 @@ func (cmd *Push) Run(c *cli.Context) {
 			services := appParams.Get("services").([]string)
 
 			for _, serviceName := range services {
-				serviceInstance, response := cmd.serviceRepo.FindInstanceByName(serviceName)
-
-				if response.IsNotSuccessful() {
-					cmd.ui.Failed("Could not find service %s to bind to %s", serviceName, appParams.Get("name").(string))
+				var serviceInstance cf.ServiceInstance
+				serviceInstance, err = cmd.findOrCreateServiceInstance(serviceName, appParams)
+				if err != nil {
 					return
 				}
 
@@ -101,14 +128,84 @@ func (cmd *Push) Run(c *cli.Context) {
 				cmd.ui.Ok()
 
 				if bindResponse.IsNotSuccessful() && bindResponse.ErrorCode != service.AppAlreadyBoundErrorCode {
-					cmd.ui.Failed("Could not find to service %s\nError: %s", serviceName, bindResponse.Message)
+					err = cmd.ui.Failed("Could not find to service %s\nError: %s", serviceName, bindResponse.Message)
 					return
 				}
 			}
 		}
 
-		cmd.restart(app, appParams, c)
+		err = cmd.restart(app, appParams, c)
+		if err != nil {
+			return
+		}
 	}
+	return
+}
+
+// findOrCreateServiceInstance finds the service instance called serviceName,
+// creating it first when it's missing and the manifest says what to create it
+// from.
+func (cmd *Push) findOrCreateServiceInstance(serviceName string, appParams cf.AppParams) (instance cf.ServiceInstance, err errors.Error) {
+	appName := appParams.Get("name").(string)
+
+	instance, apiResponse := cmd.serviceRepo.FindInstanceByName(serviceName)
+	if apiResponse.IsSuccessful() {
+		return
+	}
+
+	declaration, declared := declaredService(appParams, serviceName)
+	if !apiResponse.IsNotFound() || !declared {
+		err = cmd.ui.Failed("Could not find service %s to bind to %s", serviceName, appName)
+		return
+	}
+
+	cmd.ui.Say("Creating service %s from %s plan %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(serviceName),
+		terminal.EntityNameColor(declaration.Offering),
+		terminal.EntityNameColor(declaration.Plan),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	offerings, apiResponse := cmd.serviceRepo.GetServiceOfferings()
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	plan, planErr := service.FindServicePlan(offerings, declaration.Offering, declaration.Plan)
+	if planErr != nil {
+		err = cmd.ui.Failed("Could not create service %s for %s\n%s", serviceName, appName, planErr.Error())
+		return
+	}
+
+	_, apiResponse = cmd.serviceRepo.CreateServiceInstance(serviceName, plan.Guid)
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+	cmd.ui.Ok()
+
+	instance, apiResponse = cmd.serviceRepo.FindInstanceByName(serviceName)
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.Failed("Could not find service %s to bind to %s", serviceName, appName)
+	}
+	return
+}
+
+func declaredService(appParams cf.AppParams, serviceName string) (declaration cf.ServiceInstanceDeclaration, found bool) {
+	if !appParams.Has("declared_services") {
+		return
+	}
+
+	for _, declaration = range appParams.Get("declared_services").([]cf.ServiceInstanceDeclaration) {
+		if declaration.Name == serviceName {
+			found = true
+			return
+		}
+	}
+	return
 }
 
 func (cmd *Push) describeUploadOperation(zipFileBytes, fileCount uint64) {
@@ -116,7 +213,34 @@ func (cmd *Push) describeUploadOperation(zipFileBytes, fileCount uint64) {
 	cmd.ui.Say("Uploading app: %s, %d files", humanReadableBytes, fileCount)
 }
 
-func (cmd *Push) fetchStackGuid(appParams cf.AppParams) {
+func (cmd *Push) showIgnoredFiles(appPath string) {
+	fileInfo, err := os.Stat(appPath)
+	if err != nil || !fileInfo.IsDir() {
+		cmd.ui.Say("Ignored files are only listed for app directories")
+		return
+	}
+
+	ignored, err := cf.IgnoredAppFiles(appPath)
+	if err != nil {
+		cmd.ui.Warn("Could not list ignored files: %s", err.Error())
+		return
+	}
+
+	if len(ignored) == 0 {
+		cmd.ui.Say("No files ignored")
+		return
+	}
+
+	table := [][]string{
+		[]string{"ignored", "rule"},
+	}
+	for _, ignoredFile := range ignored {
+		table = append(table, []string{ignoredFile.Path, ignoredFile.Rule})
+	}
+	cmd.ui.DisplayTable(table)
+}
+
+func (cmd *Push) fetchStackGuid(appParams cf.AppParams) (err errors.Error) {
 	if !appParams.Has("stack") {
 		return
 	}
@@ -126,63 +250,293 @@ func (cmd *Push) fetchStackGuid(appParams cf.AppParams) {
 
 	stack, apiResponse := cmd.stackRepo.FindByName(stackName)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 	appParams.Set("stack_guid", stack.Guid)
+	return
+}
+
+// appRoute is a route push was asked to bind, by host name and domain.
+type appRoute struct {
+	hostName string
+	domain   cf.Domain
+}
+
+func (route appRoute) URL() string {
+	return route.domain.UrlForHost(route.hostName)
 }
 
-func (cmd *Push) bindAppToRoute(app cf.Application, params cf.AppParams, c *cli.Context) {
+// routeParams are the params declaring which routes an app is bound to.
+var routeParams = []string{"host", "hosts", "domain", "domains", "routes"}
+
+func (cmd *Push) bindAppToRoutes(app cf.Application, params cf.AppParams, c *cli.Context) (err errors.Error) {
 	if c.Bool("no-route") {
+		err = cmd.unbindUndeclaredRoutes(app, []cf.Route{}, c)
 		return
 	}
 
 	if params.Has("no-route") && params.Get("no-route") == true {
 		cmd.ui.Say("App %s is a worker, skipping route creation", terminal.EntityNameColor(app.Name))
+		err = cmd.unbindUndeclaredRoutes(app, []cf.Route{}, c)
 		return
 	}
 
-	routeFlagsPresent := c.String("n") != "" || c.String("d") != "" || c.Bool("no-hostname")
-	if len(app.Routes) > 0 && !routeFlagsPresent {
+	if !needsRoute(app, params, c) {
 		return
 	}
 
-	var defaultHostname string
-	if params.Has("host") {
-		defaultHostname = params.Get("host").(string)
-	} else {
-		defaultHostname = hostNameForString(app.Name)
+	appRoutes, err := cmd.routesToBind(app, params, c)
+	if err != nil {
+		return
 	}
 
-	var domainName string
-	if params.Has("domain") {
-		domainName = params.Get("domain").(string)
-	} else {
-		domainName = c.String("d")
+	declaredRoutes := []cf.Route{}
+	for _, appRoute := range appRoutes {
+		var route cf.Route
+		route, err = cmd.route(appRoute.hostName, appRoute.domain.DomainFields)
+		if err != nil {
+			return
+		}
+		declaredRoutes = append(declaredRoutes, route)
+
+		if isRouteBound(app, route.Guid) {
+			continue
+		}
+
+		cmd.ui.Say("Binding %s to %s...", terminal.EntityNameColor(appRoute.URL()), terminal.EntityNameColor(app.Name))
+
+		apiResponse := cmd.routeRepo.Bind(route.Guid, app.Guid)
+		if apiResponse.IsNotSuccessful() {
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+	}
+
+	err = cmd.unbindUndeclaredRoutes(app, declaredRoutes, c)
+	return
+}
+
+// unbindUndeclaredRoutes unbinds the routes of app that push wasn't asked to
+// bind, when --prune-routes is given.
+func (cmd *Push) unbindUndeclaredRoutes(app cf.Application, declaredRoutes []cf.Route, c *cli.Context) (err errors.Error) {
+	if !c.Bool("prune-routes") {
+		return
+	}
+
+	for _, boundRoute := range undeclaredRoutes(app, declaredRoutes) {
+		cmd.ui.Say("Unbinding %s from %s...", terminal.EntityNameColor(boundRoute.URL()), terminal.EntityNameColor(app.Name))
+
+		apiResponse := cmd.routeRepo.Unbind(boundRoute.Guid, app.Guid)
+		if apiResponse.IsNotSuccessful() {
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+
+		cmd.ui.Ok()
+		cmd.ui.Say("")
 	}
+	return
+}
 
-	hostName := cmd.hostname(c, defaultHostname)
-	domain := cmd.domain(c, domainName)
-	route := cmd.route(hostName, domain.DomainFields)
+func undeclaredRoutes(app cf.Application, declaredRoutes []cf.Route) (routes []cf.RouteSummary) {
+	for _, boundRoute := range app.Routes {
+		declared := false
+		for _, route := range declaredRoutes {
+			if route.Guid == boundRoute.Guid {
+				declared = true
+				break
+			}
+		}
 
+		if !declared {
+			routes = append(routes, boundRoute)
+		}
+	}
+	return
+}
+
+func isRouteBound(app cf.Application, routeGuid string) bool {
 	for _, boundRoute := range app.Routes {
-		if boundRoute.Guid == route.Guid {
+		if boundRoute.Guid == routeGuid {
+			return true
+		}
+	}
+	return false
+}
+
+// needsRoute tells whether push should look for routes to bind. Apps that
+// already have routes keep them unless routes are declared in the manifest
+// or with flags.
+func needsRoute(app cf.Application, params cf.AppParams, c *cli.Context) bool {
+	if len(app.Routes) == 0 || c.String("n") != "" || c.String("d") != "" || c.Bool("no-hostname") {
+		return true
+	}
+
+	for _, key := range routeParams {
+		if params.Has(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// routesToBind returns every route declared for app: each host name on each
+// domain, plus the full URLs listed in routes.
+func (cmd *Push) routesToBind(app cf.Application, params cf.AppParams, c *cli.Context) (routes []appRoute, err errors.Error) {
+	urls := paramStrings(params, "routes")
+	onlyURLs := len(urls) > 0 && c.String("n") == "" && c.String("d") == "" && !c.Bool("no-hostname")
+	for _, key := range []string{"host", "hosts", "domain", "domains"} {
+		onlyURLs = onlyURLs && !params.Has(key)
+	}
+
+	if !onlyURLs {
+		var domains []cf.Domain
+		domains, err = cmd.domains(params, c)
+		if err != nil {
 			return
 		}
+
+		hostNames := cmd.hostNames(app, params, c)
+		for _, domain := range domains {
+			for _, hostName := range hostNames {
+				routes = appendRoute(routes, appRoute{hostName: hostName, domain: domain})
+			}
+		}
 	}
 
-	cmd.ui.Say("Binding %s to %s...", terminal.EntityNameColor(domain.UrlForHost(hostName)), terminal.EntityNameColor(app.Name))
+	for _, url := range urls {
+		var route appRoute
+		route, err = cmd.routeForURL(url)
+		if err != nil {
+			return
+		}
+		routes = appendRoute(routes, route)
+	}
+	return
+}
 
-	apiResponse := cmd.routeRepo.Bind(route.Guid, app.Guid)
-	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+func appendRoute(routes []appRoute, route appRoute) []appRoute {
+	for _, existingRoute := range routes {
+		if existingRoute.URL() == route.URL() {
+			return routes
+		}
+	}
+	return append(routes, route)
+}
+
+func (cmd *Push) hostNames(app cf.Application, params cf.AppParams, c *cli.Context) (hostNames []string) {
+	if c.Bool("no-hostname") {
+		return []string{""}
+	}
+
+	if c.String("n") != "" {
+		return []string{c.String("n")}
+	}
+
+	hostNames = append(paramStrings(params, "host"), paramStrings(params, "hosts")...)
+	if len(hostNames) > 0 {
 		return
 	}
 
-	cmd.ui.Ok()
-	cmd.ui.Say("")
+	if params.Has("random-route") && params.Get("random-route") == true {
+		return []string{randomHostName(app.Name)}
+	}
+	return []string{hostNameForString(app.Name)}
+}
+
+func (cmd *Push) domains(params cf.AppParams, c *cli.Context) (domains []cf.Domain, err errors.Error) {
+	domainNames := append(paramStrings(params, "domain"), paramStrings(params, "domains")...)
+	if c.String("d") != "" {
+		domainNames = []string{c.String("d")}
+	}
+
+	if len(domainNames) == 0 {
+		domainNames = []string{""}
+	}
+
+	for _, domainName := range domainNames {
+		var domain cf.Domain
+		domain, err = cmd.domain(c, domainName)
+		if err != nil {
+			return
+		}
+		domains = append(domains, domain)
+	}
+	return
+}
+
+// routeForURL splits a full route URL into a host name and a domain of the
+// space. The whole URL is tried as a domain first, so routes can point at the
+// root of a domain.
+func (cmd *Push) routeForURL(url string) (route appRoute, err errors.Error) {
+	routeURL := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	routeURL = strings.TrimSuffix(routeURL, "/")
+	if strings.ContainsAny(routeURL, "/:") {
+		err = cmd.ui.Failed("Route %s can only have a host and a domain", url)
+		return
+	}
+
+	domain, apiResponse := cmd.domainRepo.FindByNameInCurrentSpace(routeURL)
+	if apiResponse.IsSuccessful() {
+		route.domain = domain
+		return
+	}
+	if apiResponse.IsError() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	parts := strings.SplitN(routeURL, ".", 2)
+	if len(parts) == 2 {
+		domain, apiResponse = cmd.domainRepo.FindByNameInCurrentSpace(parts[1])
+		if apiResponse.IsSuccessful() {
+			route.hostName = parts[0]
+			route.domain = domain
+			return
+		}
+	}
+
+	err = cmd.ui.Failed("Could not find a domain for route %s", url)
+	return
+}
+
+// paramStrings returns the param at key as a list, whether it holds a single
+// string or a list of them.
+func paramStrings(params cf.AppParams, key string) []string {
+	if !params.Has(key) {
+		return []string{}
+	}
+
+	switch value := params.Get(key).(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	}
+	return []string{}
+}
+
+const randomHostChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// randomHostName makes a host name for appName that's unlikely to be taken,
+// for apps pushed with random-route.
+func randomHostName(appName string) string {
+	suffix := make([]byte, 8)
+	_, err := rand.Read(suffix)
+	if err != nil {
+		return hostNameForString(appName)
+	}
+
+	for index, value := range suffix {
+		suffix[index] = randomHostChars[int(value)%len(randomHostChars)]
+	}
+	return hostNameForString(appName) + "-" + string(suffix)
 }
 
 var forbiddenHostCharRegex = regexp.MustCompile("[^a-z0-9-]")
@@ -195,10 +549,13 @@ func hostNameForString(name string) string {
 	return string(nameBytes)
 }
 
-func (cmd *Push) restart(app cf.Application, params cf.AppParams, c *cli.Context) {
+func (cmd *Push) restart(app cf.Application, params cf.AppParams, c *cli.Context) (err errors.Error) {
 	if app.State != "stopped" {
 		cmd.ui.Say("")
-		app, _ = cmd.stopper.ApplicationStop(app)
+		app, err = cmd.stopper.ApplicationStop(app)
+		if err != nil {
+			return
+		}
 	}
 
 	cmd.ui.Say("")
@@ -212,17 +569,18 @@ func (cmd *Push) restart(app cf.Application, params cf.AppParams, c *cli.Context
 		cmd.starter.SetStartTimeoutSeconds(timeout)
 	}
 
-	cmd.starter.ApplicationStart(app)
+	_, err = cmd.starter.ApplicationStart(app)
+	return
 }
 
-func (cmd *Push) route(hostName string, domain cf.DomainFields) (route cf.Route) {
+func (cmd *Push) route(hostName string, domain cf.DomainFields) (route cf.Route, err errors.Error) {
 	route, apiResponse := cmd.routeRepo.FindByHostAndDomain(hostName, domain.Name)
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Say("Creating route %s...", terminal.EntityNameColor(domain.UrlForHost(hostName)))
 
 		route, apiResponse = cmd.routeRepo.Create(hostName, domain.Guid)
 		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Failed(apiResponse.Message)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
 			return
 		}
 
@@ -235,13 +593,13 @@ func (cmd *Push) route(hostName string, domain cf.DomainFields) (route cf.Route)
 	return
 }
 
-func (cmd *Push) domain(c *cli.Context, domainName string) (domain cf.Domain) {
+func (cmd *Push) domain(c *cli.Context, domainName string) (domain cf.Domain, err errors.Error) {
 	var apiResponse net.ApiResponse
 
 	if domainName != "" {
 		domain, apiResponse = cmd.domainRepo.FindByNameInCurrentSpace(domainName)
 		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Failed(apiResponse.Message)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
 		}
 		return
 	}
@@ -252,46 +610,34 @@ func (cmd *Push) domain(c *cli.Context, domainName string) (domain cf.Domain) {
 	}))
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
-	}
-
-	if domain.Guid == "" {
-		cmd.ui.Failed("No default domain exists")
-	}
-
-	return
-}
-
-func (cmd *Push) hostname(c *cli.Context, defaultName string) (hostName string) {
-	if c.Bool("no-hostname") {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
-	hostName = c.String("n")
-	if hostName == "" {
-		hostName = defaultName
+	if domain.Guid == "" {
+		err = cmd.ui.Failed("No default domain exists")
 	}
 
 	return
 }
 
-func (cmd *Push) app(appParams cf.AppParams) (app cf.Application, didCreate bool) {
+func (cmd *Push) app(appParams cf.AppParams) (app cf.Application, didCreate bool, err errors.Error) {
 	if !appParams.Has("name") {
-		cmd.ui.Failed("Error: No name found for app")
+		err = cmd.ui.Failed("Error: No name found for app")
 		return
 	}
 
 	appName := appParams.Get("name").(string)
 	app, apiResponse := cmd.appRepo.Read(appName)
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	if apiResponse.IsNotFound() {
 		app, apiResponse = cmd.createApp(appParams)
 		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Failed(apiResponse.Message)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
 			return
 		}
 		didCreate = true
@@ -312,7 +658,6 @@ func (cmd *Push) createApp(appParams cf.AppParams) (app cf.Application, apiRespo
 
 	app, apiResponse = cmd.appRepo.Create(appParams)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
 		return
 	}
 
@@ -322,7 +667,7 @@ func (cmd *Push) createApp(appParams cf.AppParams) (app cf.Application, apiRespo
 	return
 }
 
-func (cmd *Push) updateApp(app *cf.Application, appParams cf.AppParams) {
+func (cmd *Push) updateApp(app *cf.Application, appParams cf.AppParams) (err errors.Error) {
 	cmd.ui.Say("Updating app %s in org %s / space %s as %s...",
 		terminal.EntityNameColor(app.Name),
 		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
@@ -333,7 +678,7 @@ func (cmd *Push) updateApp(app *cf.Application, appParams cf.AppParams) {
 	var apiResponse net.ApiResponse
 	*app, apiResponse = cmd.appRepo.Update(app.Guid, appParams)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -343,39 +688,45 @@ func (cmd *Push) updateApp(app *cf.Application, appParams cf.AppParams) {
 	return
 }
 
-func (cmd *Push) findAndValidateAppsToPush(c *cli.Context) (appSet cf.AppSet) {
-	baseManifestPath, manifestFilename := cmd.manifestPathFromContext(c)
-	m := cmd.instantiateManifest(c, filepath.Join(baseManifestPath, manifestFilename))
+func (cmd *Push) findAndValidateAppsToPush(c *cli.Context) (appSet cf.AppSet, m *manifest.Manifest, err errors.Error) {
+	baseManifestPath, manifestFilename, err := cmd.manifestPathFromContext(c)
+	if err != nil {
+		return
+	}
 
-	appParams, err := cf.NewAppParamsFromContext(c)
+	m, err = cmd.instantiateManifest(c, filepath.Join(baseManifestPath, manifestFilename))
 	if err != nil {
-		cmd.ui.Failed("Error: %s", err)
 		return
 	}
 
-	baseAppPath := cmd.appPathFromContext(c)
-	appParams.Set("path", baseAppPath)
+	appParams, paramsErr := cf.NewAppParamsFromContext(c)
+	if paramsErr != nil {
+		err = cmd.ui.Failed("Error: %s", paramsErr)
+		return
+	}
 
-	appSet, err = cmd.createAppSetFromContextAndManifest(c, appParams, baseAppPath, m)
+	baseAppPath, err := cmd.appPathFromContext(c)
 	if err != nil {
-		cmd.ui.Failed("Error: %s", err)
+		return
 	}
+	appParams.Set("path", baseAppPath)
 
+	appSet, err = cmd.createAppSetFromContextAndManifest(c, appParams, baseAppPath, m)
 	return
 }
 
-func (cmd *Push) appPathFromContext(c *cli.Context) (appPath string) {
+func (cmd *Push) appPathFromContext(c *cli.Context) (appPath string, err errors.Error) {
 	if c.String("p") != "" {
-		var err error
-		appPath, err = filepath.Abs(c.String("p"))
-		if err != nil {
-			cmd.ui.Failed("Error finding app path: %s", err)
+		var pathErr error
+		appPath, pathErr = filepath.Abs(c.String("p"))
+		if pathErr != nil {
+			err = cmd.ui.Failed("Error finding app path: %s", pathErr)
 			return
 		}
 	} else {
-		cwd, err := os.Getwd()
-		if err != nil {
-			cmd.ui.Failed("Error reading current working directory: %s", err)
+		cwd, cwdErr := os.Getwd()
+		if cwdErr != nil {
+			err = cmd.ui.Failed("Error reading current working directory: %s", cwdErr)
 			return
 		}
 
@@ -385,19 +736,18 @@ func (cmd *Push) appPathFromContext(c *cli.Context) (appPath string) {
 	return
 }
 
-func (cmd *Push) manifestPathFromContext(c *cli.Context) (basePath, manifestFilename string) {
-	var err error
-	basePath, manifestFilename, err = cmd.manifestRepo.ManifestPath(c.String("f"))
+func (cmd *Push) manifestPathFromContext(c *cli.Context) (basePath, manifestFilename string, err errors.Error) {
+	basePath, manifestFilename, pathErr := cmd.manifestRepo.ManifestPath(c.String("f"))
 
-	if err != nil {
-		cmd.ui.Failed("%s", err)
+	if pathErr != nil {
+		err = cmd.ui.Failed("%s", pathErr)
 		return
 	}
 
 	return
 }
 
-func (cmd *Push) instantiateManifest(c *cli.Context, manifestPath string) (m *manifest.Manifest) {
+func (cmd *Push) instantiateManifest(c *cli.Context, manifestPath string) (m *manifest.Manifest, err errors.Error) {
 	if c.Bool("no-manifest") {
 		m = manifest.NewEmptyManifest()
 		return
@@ -410,7 +760,7 @@ func (cmd *Push) instantiateManifest(c *cli.Context, manifestPath string) (m *ma
 			m = manifest.NewEmptyManifest()
 			return
 		} else {
-			cmd.ui.Failed("Error reading manifest file:\n%s", errs)
+			err = cmd.ui.Failed("Error reading manifest file:\n%s", errs)
 			return
 		}
 	}
@@ -419,9 +769,9 @@ func (cmd *Push) instantiateManifest(c *cli.Context, manifestPath string) (m *ma
 	return
 }
 
-func (cmd *Push) createAppSetFromContextAndManifest(c *cli.Context, contextParams cf.AppParams, rootAppPath string, m *manifest.Manifest) (appSet cf.AppSet, err error) {
+func (cmd *Push) createAppSetFromContextAndManifest(c *cli.Context, contextParams cf.AppParams, rootAppPath string, m *manifest.Manifest) (appSet cf.AppSet, err errors.Error) {
 	if contextParams.Has("name") && len(m.Applications) > 1 {
-		err = errors.New("APP_NAME command line argument is not allowed when pushing multiple apps from a manifest file.")
+		err = cmd.ui.Failed("Error: APP_NAME command line argument is not allowed when pushing multiple apps from a manifest file.")
 		return
 	}
 
@@ -429,6 +779,7 @@ func (cmd *Push) createAppSetFromContextAndManifest(c *cli.Context, contextParam
 	if len(m.Applications) == 0 {
 		if !contextParams.Has("name") || contextParams.Get("name") == "" {
 			cmd.ui.FailWithUsage(c, "push")
+			err = errors.NewUsageError("Incorrect Usage.")
 			return
 		}
 		appSet = append(appSet, contextParams)
@@ -453,7 +804,8 @@ func (cmd *Push) createAppSetFromContextAndManifest(c *cli.Context, contextParam
 
 	for _, appParams := range appSet {
 		if !appParams.Has("name") {
-			err = errors.New("app name is a required field")
+			err = cmd.ui.Failed("Error: app name is a required field")
+			return
 		}
 	}
 
@@ -0,0 +1,256 @@
+package application
+
+import (
+	"cf"
+	"cf/errors"
+	"cf/formatters"
+	"cf/manifest"
+	"cf/terminal"
+	"fmt"
+	"generic"
+	"github.com/codegangsta/cli"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// pushFlagsByParam maps the app params push takes from the command line to
+// the flag setting them.
+var pushFlagsByParam = map[string]string{
+	"buildpack":            "b",
+	"command":              "c",
+	"instances":            "i",
+	"memory":               "m",
+	"stack":                "s",
+	"health_check_timeout": "t",
+}
+
+// internalPushParams are set by push itself while it runs and are left out of
+// the plan.
+var internalPushParams = map[string]bool{
+	"declared_services": true,
+	"space_guid":        true,
+	"stack_guid":        true,
+}
+
+// showPlan describes what push would do for each app without changing
+// anything. It only makes requests that read from the Cloud Controller.
+func (cmd *Push) showPlan(c *cli.Context, appSet cf.AppSet, m *manifest.Manifest) (err errors.Error) {
+	cmd.ui.Say("Dry run: showing what push would do in org %s / space %s as %s, nothing will be changed",
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	for index, appParams := range appSet {
+		cmd.ui.Say("")
+		appName := appParams.Get("name").(string)
+
+		app, apiResponse := cmd.appRepo.Read(appName)
+		if apiResponse.IsError() {
+			err = cmd.ui.FailWithError(apiResponse.ToError())
+			return
+		}
+
+		if apiResponse.IsNotFound() {
+			app = cf.Application{}
+			app.Name = appName
+			cmd.ui.Say("App %s would be created", terminal.EntityNameColor(appName))
+		} else {
+			cmd.ui.Say("App %s would be updated", terminal.EntityNameColor(appName))
+		}
+
+		cmd.showPlannedParams(c, m, index, appParams)
+		err = cmd.showPlannedRoutes(app, appParams, c)
+		if err != nil {
+			return
+		}
+		cmd.showPlannedServices(appParams)
+		err = cmd.showPlannedFiles(appParams.Get("path").(string))
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (cmd *Push) showPlannedParams(c *cli.Context, m *manifest.Manifest, index int, appParams cf.AppParams) {
+	keys := []string{}
+	generic.Each(appParams, func(key, _ interface{}) {
+		if !internalPushParams[key.(string)] {
+			keys = append(keys, key.(string))
+		}
+	})
+	sort.Strings(keys)
+
+	table := [][]string{
+		[]string{"param", "value", "source"},
+	}
+	for _, key := range keys {
+		table = append(table, []string{
+			key,
+			formatPlannedParam(key, appParams.Get(key)),
+			pushParamSource(c, m, index, key),
+		})
+	}
+	cmd.ui.DisplayTable(table)
+}
+
+func pushParamSource(c *cli.Context, m *manifest.Manifest, index int, key string) string {
+	manifestHasApps := len(m.Applications) > index
+
+	switch key {
+	case "name":
+		if len(c.Args()) > 0 {
+			return "command line (APP_NAME)"
+		}
+	case "path":
+		if manifestHasApps && m.Applications[index].Has("path") {
+			return m.SourceOf(index, key)
+		}
+		if c.String("p") != "" {
+			return "command line (-p)"
+		}
+		return "current directory"
+	default:
+		flagName, ok := pushFlagsByParam[key]
+		if ok && c.String(flagName) != "" {
+			return fmt.Sprintf("command line (-%s)", flagName)
+		}
+	}
+
+	if manifestHasApps {
+		return m.SourceOf(index, key)
+	}
+	return ""
+}
+
+func formatPlannedParam(key string, value interface{}) string {
+	switch key {
+	case "memory":
+		return formatters.ByteSize(value.(uint64) * formatters.MEGABYTE)
+	case "env":
+		// only the names, values tend to hold credentials
+		names := []string{}
+		generic.Each(generic.NewMap(value), func(name, _ interface{}) {
+			names = append(names, fmt.Sprintf("%v", name))
+		})
+		sort.Strings(names)
+		return strings.Join(names, ", ")
+	}
+
+	stringValues, ok := value.([]string)
+	if ok {
+		return strings.Join(stringValues, ", ")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func (cmd *Push) showPlannedRoutes(app cf.Application, params cf.AppParams, c *cli.Context) (err errors.Error) {
+	if c.Bool("no-route") || (params.Has("no-route") && params.Get("no-route") == true) {
+		cmd.ui.Say("Routes: none, no-route is set")
+		cmd.showPlannedUnbinds(app, []cf.Route{}, c)
+		return
+	}
+
+	if !needsRoute(app, params, c) {
+		cmd.ui.Say("Routes: keeps its existing routes")
+		return
+	}
+
+	cmd.ui.Say("Routes:")
+	appRoutes, err := cmd.routesToBind(app, params, c)
+	if err != nil {
+		return
+	}
+
+	declaredRoutes := []cf.Route{}
+	for _, appRoute := range appRoutes {
+		url := terminal.EntityNameColor(appRoute.URL())
+
+		route, apiResponse := cmd.routeRepo.FindByHostAndDomain(appRoute.hostName, appRoute.domain.Name)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Say("  %s would be created and bound", url)
+			continue
+		}
+
+		declaredRoutes = append(declaredRoutes, route)
+		if isRouteBound(app, route.Guid) {
+			cmd.ui.Say("  %s is already bound", url)
+		} else {
+			cmd.ui.Say("  %s would be bound", url)
+		}
+	}
+
+	cmd.showPlannedUnbinds(app, declaredRoutes, c)
+	return
+}
+
+func (cmd *Push) showPlannedUnbinds(app cf.Application, declaredRoutes []cf.Route, c *cli.Context) {
+	if !c.Bool("prune-routes") {
+		return
+	}
+
+	for _, boundRoute := range undeclaredRoutes(app, declaredRoutes) {
+		cmd.ui.Say("  %s would be unbound", terminal.EntityNameColor(boundRoute.URL()))
+	}
+}
+
+func (cmd *Push) showPlannedServices(params cf.AppParams) {
+	if !params.Has("services") || len(params.Get("services").([]string)) == 0 {
+		cmd.ui.Say("Services: none")
+		return
+	}
+
+	cmd.ui.Say("Services:")
+	for _, serviceName := range params.Get("services").([]string) {
+		_, apiResponse := cmd.serviceRepo.FindInstanceByName(serviceName)
+		if apiResponse.IsNotSuccessful() {
+			declaration, declared := declaredService(params, serviceName)
+			if apiResponse.IsNotFound() && declared {
+				cmd.ui.Say("  %s would be created from %s plan %s and bound",
+					terminal.EntityNameColor(serviceName), declaration.Offering, declaration.Plan)
+				continue
+			}
+			cmd.ui.Say("  %s could not be found, push would fail", terminal.EntityNameColor(serviceName))
+			continue
+		}
+		cmd.ui.Say("  %s would be bound", terminal.EntityNameColor(serviceName))
+	}
+}
+
+func (cmd *Push) showPlannedFiles(appPath string) (err errors.Error) {
+	fileInfo, statErr := os.Stat(appPath)
+	if statErr != nil {
+		err = cmd.ui.Failed("Error reading app path: %s", statErr)
+		return
+	}
+
+	if !fileInfo.IsDir() {
+		cmd.ui.Say("Files: %s would be uploaded, %s",
+			terminal.EntityNameColor(filepath.Base(appPath)),
+			formatters.ByteSize(uint64(fileInfo.Size())),
+		)
+		return
+	}
+
+	appFiles, filesErr := cf.AppFilesInDir(appPath)
+	if filesErr != nil {
+		err = cmd.ui.Failed("Error reading app files: %s", filesErr)
+		return
+	}
+
+	var totalSize int64
+	table := [][]string{
+		[]string{"file", "size"},
+	}
+	for _, appFile := range appFiles {
+		totalSize += appFile.Size
+		table = append(table, []string{appFile.Path, formatters.ByteSize(uint64(appFile.Size))})
+	}
+
+	cmd.ui.Say("Files: %d files, %s after .cfignore", len(appFiles), formatters.ByteSize(uint64(totalSize)))
+	cmd.ui.DisplayTable(table)
+	return
+}
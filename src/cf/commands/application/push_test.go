@@ -6,8 +6,10 @@ import (
 	"cf/configuration"
 	"cf/manifest"
 	"errors"
+	"fileutils"
 	"generic"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -443,6 +445,108 @@ func TestPushWithServicesThatAreNotFound(t *testing.T) {
 	})
 }
 
+func manifestWithDeclaredServices(t *testing.T) *manifest.Manifest {
+	m, errs := manifest.NewManifest(generic.NewMap(map[string]interface{}{
+		"applications": []interface{}{
+			map[string]interface{}{
+				"name": "app1",
+				"services": []interface{}{
+					"existing-service",
+					map[string]interface{}{"name": "db", "service": "p-mysql", "plan": "100mb"},
+				},
+			},
+		},
+	}))
+	assert.True(t, errs.Empty())
+	return m
+}
+
+func serviceOfferingsWithPlans() []cf.ServiceOffering {
+	offering := cf.ServiceOffering{}
+	offering.Label = "p-mysql"
+	plan := cf.ServicePlanFields{}
+	plan.Name = "100mb"
+	plan.Guid = "p-mysql-100mb-guid"
+	offering.Plans = []cf.ServicePlanFields{plan}
+	return []cf.ServiceOffering{offering}
+}
+
+func TestPushingCreatesMissingDeclaredServices(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.manifestRepo.ReadManifestManifest = manifestWithDeclaredServices(t)
+	deps.serviceRepo.ServiceOfferings = serviceOfferingsWithPlans()
+	deps.serviceRepo.FindInstanceByNameMissing = []string{"db"}
+	deps.serviceRepo.FindInstanceByNameMap = generic.NewMap(map[interface{}]interface{}{
+		"existing-service": maker.NewServiceInstance("existing-service"),
+		"db":               maker.NewServiceInstance("db"),
+	})
+
+	ui := callPush(t, []string{}, deps)
+
+	assert.Equal(t, deps.serviceRepo.CreateServiceInstanceName, "db")
+	assert.Equal(t, deps.serviceRepo.CreateServiceInstancePlanGuid, "p-mysql-100mb-guid")
+	assert.Equal(t, len(deps.binder.InstancesToBindTo), 2)
+	assert.Equal(t, deps.binder.InstancesToBindTo[0].Name, "existing-service")
+	assert.Equal(t, deps.binder.InstancesToBindTo[1].Name, "db")
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Binding service", "existing-service", "app1"},
+		{"OK"},
+		{"Creating service", "db", "p-mysql", "100mb", "my-org", "my-space", "my-user"},
+		{"OK"},
+		{"Binding service", "db", "app1"},
+		{"OK"},
+	})
+}
+
+func TestPushingDoesNotCreateDeclaredServicesThatExist(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.manifestRepo.ReadManifestManifest = manifestWithDeclaredServices(t)
+	deps.serviceRepo.ServiceOfferings = serviceOfferingsWithPlans()
+
+	ui := callPush(t, []string{}, deps)
+
+	assert.Equal(t, deps.serviceRepo.CreateServiceInstanceName, "")
+	assert.Equal(t, len(deps.binder.InstancesToBindTo), 2)
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"Creating service"},
+	})
+}
+
+func TestPushingWithADeclaredServiceWhosePlanDoesNotExist(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.manifestRepo.ReadManifestManifest = manifestWithDeclaredServices(t)
+	deps.serviceRepo.FindInstanceByNameMissing = []string{"db"}
+
+	ui := callPush(t, []string{}, deps)
+
+	assert.Equal(t, deps.serviceRepo.CreateServiceInstanceName, "")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Could not create service", "db", "app1"},
+		{"Could not find offering", "p-mysql"},
+	})
+}
+
+func TestPushingDryRunShowsDeclaredServicesThatWouldBeCreated(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.manifestRepo.ReadManifestManifest = manifestWithDeclaredServices(t)
+	deps.serviceRepo.FindInstanceByNameMissing = []string{"db"}
+
+	ui := callPush(t, []string{"--dry-run"}, deps)
+
+	assert.Equal(t, deps.serviceRepo.CreateServiceInstanceName, "")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Services:"},
+		{"existing-service", "would be bound"},
+		{"db", "would be created from p-mysql plan 100mb and bound"},
+	})
+}
+
 func TestPushingAppWithPath(t *testing.T) {
 	deps := getPushDependencies()
 	deps.appRepo.ReadNotFound = true
@@ -458,6 +562,42 @@ func TestPushingAppWithPath(t *testing.T) {
 	assert.Equal(t, deps.appBitsRepo.UploadedDir, absPath)
 }
 
+func TestPushingAppWithShowIgnored(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	fileutils.TempDir("show-ignored", func(dir string, err error) {
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".cfignore"), []byte("*.log\n"), 0644))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.rb"), []byte(""), 0644))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "dev.log"), []byte(""), 0644))
+
+		ui := callPush(t, []string{"--show-ignored", "-p", dir, "app-with-path"}, deps)
+
+		testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+			{"Uploading", "app-with-path"},
+			{"ignored", "rule"},
+			{".cfignore", "default: .cfignore"},
+			{"dev.log", ".cfignore:1: *.log"},
+		})
+		assert.Equal(t, deps.appBitsRepo.UploadedDir, dir)
+	})
+}
+
+func TestPushingAppWithShowIgnoredAndAnArchive(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	absPath, err := filepath.Abs("../../../fixtures/example-app.zip")
+	assert.NoError(t, err)
+
+	ui := callPush(t, []string{"--show-ignored", "-p", absPath, "app-with-path"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Ignored files are only listed for app directories"},
+	})
+}
+
 func TestPushingAppWithPathToZipFile(t *testing.T) {
 	deps := getPushDependencies()
 	deps.appRepo.ReadNotFound = true
@@ -484,6 +624,276 @@ func TestPushingWithDefaultAppPath(t *testing.T) {
 	assert.Equal(t, deps.appBitsRepo.UploadedDir, dir)
 }
 
+func TestPushingWithDereferencedSymlinks(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	callPush(t, []string{"app-with-default-path"}, deps)
+	assert.False(t, deps.appBitsRepo.UploadedZipOptions.DereferenceSymlinks)
+
+	deps = getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	callPush(t, []string{"--dereference-symlinks", "app-with-default-path"}, deps)
+	assert.True(t, deps.appBitsRepo.UploadedZipOptions.DereferenceSymlinks)
+}
+
+func TestPushingWithDryRunShowsThePlanWithoutChangingAnything(t *testing.T) {
+	deps := getPushDependencies()
+
+	sharedDomain := cf.Domain{}
+	sharedDomain.Name = "foo.cf-app.com"
+	sharedDomain.Shared = true
+	sharedDomain.Guid = "foo-domain-guid"
+
+	deps.domainRepo.ListSharedDomainsDomains = []cf.Domain{sharedDomain}
+	deps.routeRepo.FindByHostAndDomainErr = true
+	deps.appRepo.ReadNotFound = true
+
+	ui := callPush(t, []string{
+		"--dry-run",
+		"-m", "512M",
+		"-p", "../../../fixtures/example-app",
+		"my-new-app",
+	}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Dry run", "my-org", "my-space", "nothing will be changed"},
+		{"my-new-app", "would be created"},
+		{"param", "value", "source"},
+		{"memory", "512M", "command line (-m)"},
+		{"name", "my-new-app", "command line (APP_NAME)"},
+		{"path", "example-app", "command line (-p)"},
+		{"Routes"},
+		{"my-new-app.foo.cf-app.com", "would be created and bound"},
+		{"Services", "none"},
+		{"Files", "5 files", "after .cfignore"},
+		{"file", "size"},
+		{"app.rb"},
+	})
+
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+	assert.Equal(t, deps.appRepo.UpdateAppGuid, "")
+	assert.Equal(t, deps.routeRepo.CreatedHost, "")
+	assert.Equal(t, deps.routeRepo.BoundRouteGuid, "")
+	assert.Equal(t, deps.appBitsRepo.UploadedAppGuid, "")
+	assert.Equal(t, deps.starter.AppToStart.Guid, "")
+}
+
+func TestPushingWithDryRunShowsWhereManifestParamsCameFrom(t *testing.T) {
+	deps := getPushDependencies()
+
+	existingRoute := cf.RouteSummary{}
+	existingRoute.Guid = "existing-route-guid"
+	existingApp := cf.Application{}
+	existingApp.Name = "app1"
+	existingApp.Guid = "existing-app-guid"
+	existingApp.Routes = []cf.RouteSummary{existingRoute}
+	deps.appRepo.ReadApp = existingApp
+
+	m := manifestWithServicesAndEnv()
+	m.Sources = []manifest.ParamSources{
+		{"name": "manifest.yml", "services": "base.yml (global), manifest.yml", "env": "manifest.yml"},
+	}
+	m.Applications = m.Applications[:1]
+	deps.manifestRepo.ReadManifestManifest = m
+	deps.serviceRepo.FindInstanceByNameMap = generic.NewMap(map[interface{}]interface{}{
+		"app1-service": maker.NewServiceInstance("app1-service"),
+	})
+
+	ui := callPush(t, []string{"--dry-run", "-i", "3"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"app1", "would be updated"},
+		{"env", "SOMETHING", "manifest.yml"},
+		{"instances", "3", "command line (-i)"},
+		{"name", "app1", "manifest.yml"},
+		{"services", "app1-service, global-service", "base.yml (global), manifest.yml"},
+		{"Routes", "keeps its existing routes"},
+		{"Services"},
+		{"app1-service", "would be bound"},
+		{"global-service", "would be bound"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"definitely-something"},
+	})
+
+	assert.Equal(t, deps.appRepo.UpdateAppGuid, "")
+	assert.Equal(t, len(deps.binder.AppsToBind), 0)
+	assert.Equal(t, deps.appBitsRepo.UploadedAppGuid, "")
+}
+
+func routeTestDomains() map[string]cf.Domain {
+	domains := map[string]cf.Domain{}
+	for _, name := range []string{"example.com", "private.example.org"} {
+		domain := cf.Domain{}
+		domain.Name = name
+		domain.Guid = name + "-guid"
+		domains[name] = domain
+	}
+	return domains
+}
+
+func TestPushingAppWithManyHostsAndDomainsBindsEveryRoute(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.domainRepo.FindByNameInCurrentSpaceDomains = routeTestDomains()
+	deps.routeRepo.FindByHostAndDomainRoutes = map[string]cf.Route{}
+	deps.manifestRepo.ReadManifestManifest = &manifest.Manifest{
+		Applications: []cf.AppParams{
+			cf.NewAppParams(generic.NewMap(map[interface{}]interface{}{
+				"name":    "my-app",
+				"hosts":   []string{"www", "api"},
+				"domains": []string{"example.com", "private.example.org"},
+			})),
+		},
+	}
+
+	ui := callPush(t, []string{}, deps)
+
+	assert.Equal(t, deps.routeRepo.CreatedHosts, []string{"www", "api", "www", "api"})
+	assert.Equal(t, deps.routeRepo.CreatedDomainGuids, []string{
+		"example.com-guid", "example.com-guid",
+		"private.example.org-guid", "private.example.org-guid",
+	})
+	assert.Equal(t, len(deps.routeRepo.BoundRouteGuids), 4)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Binding", "www.example.com"},
+		{"Binding", "api.example.com"},
+		{"Binding", "www.private.example.org"},
+		{"Binding", "api.private.example.org"},
+	})
+}
+
+func TestPushingAppWithRouteURLs(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.domainRepo.FindByNameInCurrentSpaceDomains = routeTestDomains()
+	deps.routeRepo.FindByHostAndDomainRoutes = map[string]cf.Route{}
+	deps.manifestRepo.ReadManifestManifest = &manifest.Manifest{
+		Applications: []cf.AppParams{
+			cf.NewAppParams(generic.NewMap(map[interface{}]interface{}{
+				"name":   "my-app",
+				"routes": []string{"shop.example.com", "https://private.example.org/"},
+			})),
+		},
+	}
+
+	ui := callPush(t, []string{}, deps)
+
+	assert.Equal(t, deps.routeRepo.CreatedHosts, []string{"shop", ""})
+	assert.Equal(t, deps.routeRepo.CreatedDomainGuids, []string{"example.com-guid", "private.example.org-guid"})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Binding", "shop.example.com"},
+		{"Binding", "private.example.org"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"Binding", "my-app.example.com"},
+	})
+}
+
+func TestPushingAppWithRouteURLInAnUnknownDomain(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.domainRepo.FindByNameInCurrentSpaceDomains = routeTestDomains()
+	deps.manifestRepo.ReadManifestManifest = &manifest.Manifest{
+		Applications: []cf.AppParams{
+			cf.NewAppParams(generic.NewMap(map[interface{}]interface{}{
+				"name":   "my-app",
+				"routes": []string{"shop.example.net"},
+			})),
+		},
+	}
+
+	ui := callPush(t, []string{}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Could not find a domain for route shop.example.net"},
+	})
+}
+
+func TestPushingAppWithRandomRoute(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.domainRepo.ListSharedDomainsDomains = []cf.Domain{routeTestDomains()["example.com"]}
+	deps.routeRepo.FindByHostAndDomainErr = true
+	deps.manifestRepo.ReadManifestManifest = &manifest.Manifest{
+		Applications: []cf.AppParams{
+			cf.NewAppParams(generic.NewMap(map[interface{}]interface{}{
+				"name":         "my-app",
+				"random-route": true,
+			})),
+		},
+	}
+
+	callPush(t, []string{}, deps)
+	firstHost := deps.routeRepo.CreatedHost
+
+	callPush(t, []string{}, deps)
+	secondHost := deps.routeRepo.CreatedHost
+
+	assert.Equal(t, len(firstHost), len("my-app-")+8)
+	assert.Contains(t, firstHost, "my-app-")
+	assert.NotEqual(t, firstHost, secondHost)
+}
+
+func TestPushingExistingAppBindsMissingRoutesAndPrunesUndeclaredOnes(t *testing.T) {
+	deps := getPushDependencies()
+	deps.domainRepo.FindByNameInCurrentSpaceDomains = routeTestDomains()
+
+	wwwRoute := cf.Route{}
+	wwwRoute.Guid = "www-route-guid"
+	wwwRoute.Host = "www"
+	apiRoute := cf.Route{}
+	apiRoute.Guid = "api-route-guid"
+	apiRoute.Host = "api"
+	deps.routeRepo.FindByHostAndDomainRoutes = map[string]cf.Route{
+		"www.example.com": wwwRoute,
+		"api.example.com": apiRoute,
+	}
+
+	boundWww := cf.RouteSummary{}
+	boundWww.Guid = "www-route-guid"
+	boundWww.Host = "www"
+	boundWww.Domain.Name = "example.com"
+	boundOld := cf.RouteSummary{}
+	boundOld.Guid = "old-route-guid"
+	boundOld.Host = "old"
+	boundOld.Domain.Name = "example.com"
+
+	existingApp := cf.Application{}
+	existingApp.Name = "my-app"
+	existingApp.Guid = "my-app-guid"
+	existingApp.Routes = []cf.RouteSummary{boundWww, boundOld}
+	deps.appRepo.ReadApp = existingApp
+	deps.appRepo.UpdateAppResult = existingApp
+
+	deps.manifestRepo.ReadManifestManifest = &manifest.Manifest{
+		Applications: []cf.AppParams{
+			cf.NewAppParams(generic.NewMap(map[interface{}]interface{}{
+				"name":   "my-app",
+				"hosts":  []string{"www", "api"},
+				"domain": "example.com",
+			})),
+		},
+	}
+
+	ui := callPush(t, []string{}, deps)
+	assert.Equal(t, deps.routeRepo.BoundRouteGuids, []string{"api-route-guid"})
+	assert.Equal(t, len(deps.routeRepo.UnboundRouteGuids), 0)
+
+	deps.routeRepo.BoundRouteGuids = nil
+	ui = callPush(t, []string{"--prune-routes"}, deps)
+	assert.Equal(t, deps.routeRepo.BoundRouteGuids, []string{"api-route-guid"})
+	assert.Equal(t, deps.routeRepo.UnboundRouteGuids, []string{"old-route-guid"})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Binding", "api.example.com", "my-app"},
+		{"Unbinding", "old.example.com", "my-app"},
+	})
+}
+
 func TestPushingWithRelativeAppPath(t *testing.T) {
 	deps := getPushDependencies()
 	deps.appRepo.ReadNotFound = true
@@ -1063,6 +1473,7 @@ func getPushDependencies() (deps pushDependencies) {
 	deps.binder = &testcmd.FakeAppBinder{}
 	deps.appRepo = &testapi.FakeApplicationRepository{}
 	deps.domainRepo = &testapi.FakeDomainRepository{}
+	deps.domainRepo.ListSharedDomainsDomains = []cf.Domain{defaultSharedDomain()}
 	deps.routeRepo = &testapi.FakeRouteRepository{}
 	deps.stackRepo = &testapi.FakeStackRepository{}
 	deps.appBitsRepo = &testapi.FakeApplicationBitsRepository{}
@@ -1071,6 +1482,13 @@ func getPushDependencies() (deps pushDependencies) {
 	return
 }
 
+func defaultSharedDomain() (domain cf.Domain) {
+	domain.Name = "default.cf-app.com"
+	domain.Shared = true
+	domain.Guid = "default-domain-guid"
+	return
+}
+
 func callPush(t *testing.T, args []string, deps pushDependencies) (ui *testterm.FakeUI) {
 
 	ui = new(testterm.FakeUI)
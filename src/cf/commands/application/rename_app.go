@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -39,7 +39,7 @@ func (cmd *RenameApp) GetRequirements(reqFactory requirements.Factory, c *cli.Co
 	return
 }
 
-func (cmd *RenameApp) Run(c *cli.Context) {
+func (cmd *RenameApp) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
 	new_name := c.Args()[1]
 
@@ -56,8 +56,9 @@ func (cmd *RenameApp) Run(c *cli.Context) {
 
 	_, apiResponse := cmd.appRepo.Update(app.Guid, params)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 	cmd.ui.Ok()
+	return
 }
@@ -2,28 +2,39 @@ package application
 
 import (
 	"cf"
+	"cf/api"
+	"cf/errors"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
+	"fmt"
 	"github.com/codegangsta/cli"
+	"time"
 )
 
+const DefaultRollingBatchSize = 1
+
 type Restart struct {
-	ui      terminal.UI
-	starter ApplicationStarter
-	stopper ApplicationStopper
-	appReq  requirements.ApplicationRequirement
+	ui               terminal.UI
+	starter          ApplicationStarter
+	stopper          ApplicationStopper
+	appInstancesRepo api.AppInstancesRepository
+	waiter           ApplicationInstanceWaiter
+	appReq           requirements.ApplicationRequirement
 }
 
 type ApplicationRestarter interface {
-	ApplicationRestart(app cf.Application)
+	ApplicationRestart(app cf.Application) (err errors.Error)
+	ApplicationRollingRestart(app cf.Application, batchSize int, pause time.Duration) (err errors.Error)
 }
 
-func NewRestart(ui terminal.UI, starter ApplicationStarter, stopper ApplicationStopper) (cmd *Restart) {
+func NewRestart(ui terminal.UI, starter ApplicationStarter, stopper ApplicationStopper, appInstancesRepo api.AppInstancesRepository, waiter ApplicationInstanceWaiter) (cmd *Restart) {
 	cmd = new(Restart)
 	cmd.ui = ui
 	cmd.starter = starter
 	cmd.stopper = stopper
+	cmd.appInstancesRepo = appInstancesRepo
+	cmd.waiter = waiter
 	return
 }
 
@@ -34,6 +45,12 @@ func (cmd *Restart) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 		return
 	}
 
+	if !validRollingFlags(c) {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "restart")
+		return
+	}
+
 	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
 
 	reqs = []requirements.Requirement{
@@ -44,23 +61,121 @@ func (cmd *Restart) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 	return
 }
 
-func (cmd *Restart) Run(c *cli.Context) {
+func (cmd *Restart) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
-	cmd.ApplicationRestart(app)
+
+	if c.Bool("rolling") {
+		err = cmd.ApplicationRollingRestart(app, rollingBatchSize(c), rollingPause(c))
+		return
+	}
+
+	err = cmd.ApplicationRestart(app)
+	return
 }
 
-func (cmd *Restart) ApplicationRestart(app cf.Application) {
+func (cmd *Restart) ApplicationRestart(app cf.Application) (err errors.Error) {
 	stoppedApp, err := cmd.stopper.ApplicationStop(app)
 	if err != nil {
-		cmd.ui.Failed(err.Error())
 		return
 	}
 
 	cmd.ui.Say("")
 
 	_, err = cmd.starter.ApplicationStart(stoppedApp)
-	if err != nil {
-		cmd.ui.Failed(err.Error())
+	return
+}
+
+// ApplicationRollingRestart cycles the app's instances batchSize at a time,
+// waiting for each batch to be running again before moving on to the next,
+// so that the rest of the instances keep serving traffic. It stops at the
+// first batch with a crashing instance.
+func (cmd *Restart) ApplicationRollingRestart(app cf.Application, batchSize int, pause time.Duration) (err errors.Error) {
+	if app.State != "started" {
+		cmd.ui.Say(terminal.WarningColor("App " + app.Name + " is not started, restarting it all at once"))
+		err = cmd.ApplicationRestart(app)
+		return
+	}
+
+	instances, apiResponse := cmd.appInstancesRepo.GetInstances(app.Guid)
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
+
+	totalCount := len(instances)
+
+	for start := 0; start < totalCount; start += batchSize {
+		end := start + batchSize
+		if end > totalCount {
+			end = totalCount
+		}
+
+		cmd.ui.Say("Restarting %s of app %s (%d of %d)...",
+			instanceRange(start, end),
+			terminal.EntityNameColor(app.Name),
+			end,
+			totalCount,
+		)
+
+		previousSinces := map[int]time.Time{}
+		for index := start; index < end; index++ {
+			apiResponse = cmd.appInstancesRepo.DeleteInstance(app.Guid, index)
+			if apiResponse.IsNotSuccessful() {
+				err = cmd.ui.FailWithError(apiResponse.ToError())
+				return
+			}
+			previousSinces[index] = instances[index].Since
+		}
+
+		cmd.ui.Ok()
+
+		if net.IsDryRun() {
+			continue
+		}
+
+		err = cmd.waiter.WaitForInstancesRestart(app.Guid, previousSinces)
+		if err != nil {
+			cmd.ui.Say("")
+			cmd.ui.Warn("Rolling restart stopped with %d of %d instances restarted", start, totalCount)
+			err = cmd.ui.FailWithError(err)
+			return
+		}
+
+		if end < totalCount && pause > 0 {
+			cmd.ui.Say("Waiting %s before the next batch...", pause)
+			cmd.ui.Wait(pause)
+		}
+		cmd.ui.Say("")
+	}
+
+	cmd.ui.Say(terminal.HeaderColor(fmt.Sprintf("\nApp %s restarted\n", app.Name)))
+	return
+}
+
+func instanceRange(start, end int) string {
+	if end-start == 1 {
+		return fmt.Sprintf("instance #%d", start)
+	}
+	return fmt.Sprintf("instances #%d-#%d", start, end-1)
+}
+
+func validRollingFlags(c *cli.Context) bool {
+	if !c.Bool("rolling") {
+		return c.Int("batch-size") == -1 && c.Int("pause") == -1
+	}
+	return c.Int("batch-size") == -1 || c.Int("batch-size") > 0
+}
+
+func rollingBatchSize(c *cli.Context) int {
+	if c.Int("batch-size") == -1 {
+		return DefaultRollingBatchSize
+	}
+	return c.Int("batch-size")
+}
+
+func rollingPause(c *cli.Context) time.Duration {
+	if c.Int("pause") <= 0 {
+		return 0
+	}
+	return time.Duration(c.Int("pause")) * time.Second
 }
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/errors"
+	"cf/net"
+	"cf/requirements"
+	"cf/terminal"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"strconv"
+	"time"
+)
+
+type RestartAppInstance struct {
+	ui               terminal.UI
+	config           *configuration.Configuration
+	appInstancesRepo api.AppInstancesRepository
+	waiter           ApplicationInstanceWaiter
+	appReq           requirements.ApplicationRequirement
+	index            int
+}
+
+func NewRestartAppInstance(ui terminal.UI, config *configuration.Configuration, appInstancesRepo api.AppInstancesRepository, waiter ApplicationInstanceWaiter) (cmd *RestartAppInstance) {
+	cmd = new(RestartAppInstance)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appInstancesRepo = appInstancesRepo
+	cmd.waiter = waiter
+	return
+}
+
+func (cmd *RestartAppInstance) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 2 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "restart-app-instance")
+		return
+	}
+
+	cmd.index, err = strconv.Atoi(c.Args()[1])
+	if err != nil || cmd.index < 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "restart-app-instance")
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *RestartAppInstance) Run(c *cli.Context) (err errors.Error) {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say("Restarting instance #%d of app %s in org %s / space %s as %s...",
+		cmd.index,
+		terminal.EntityNameColor(app.Name),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	instances, apiResponse := cmd.appInstancesRepo.GetInstances(app.Guid)
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	if cmd.index >= len(instances) {
+		err = cmd.ui.FailWithError(errors.NewNotFoundError(fmt.Sprintf("App %s has no instance #%d, it has %d instances.", app.Name, cmd.index, len(instances))))
+		return
+	}
+
+	apiResponse = cmd.appInstancesRepo.DeleteInstance(app.Guid, cmd.index)
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	cmd.ui.Ok()
+
+	if net.IsDryRun() {
+		return
+	}
+
+	cmd.ui.Say("")
+	err = cmd.waiter.WaitForInstancesRestart(app.Guid, map[int]time.Time{cmd.index: instances[cmd.index].Since})
+	if err != nil {
+		cmd.ui.Say("")
+		err = cmd.ui.FailWithError(err)
+		return
+	}
+
+	cmd.ui.Say(terminal.HeaderColor(fmt.Sprintf("\nInstance #%d restarted\n", cmd.index)))
+	return
+}
@@ -0,0 +1,144 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"cf/errors"
+	"cf/net"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+	"time"
+)
+
+func TestRestartAppInstanceFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui, _, _ := callRestartAppInstance(t, []string{}, reqFactory, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui, _, _ = callRestartAppInstance(t, []string{"my-app"}, reqFactory, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui, _, _ = callRestartAppInstance(t, []string{"my-app", "first"}, reqFactory, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui, _, _ = callRestartAppInstance(t, []string{"my-app", "0"}, reqFactory, &testapi.FakeAppInstancesRepo{})
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestRestartAppInstanceRequirements(t *testing.T) {
+	args := []string{"my-app", "0"}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callRestartAppInstance(t, args, reqFactory, &testapi.FakeAppInstancesRepo{})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callRestartAppInstance(t, args, reqFactory, &testapi.FakeAppInstancesRepo{})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	callRestartAppInstance(t, args, reqFactory, &testapi.FakeAppInstancesRepo{})
+	assert.Equal(t, reqFactory.ApplicationName, "my-app")
+}
+
+func TestRestartAppInstance(t *testing.T) {
+	since := time.Now().Add(-1 * time.Hour)
+	instance := cf.AppInstanceFields{}
+	instance.State = cf.InstanceRunning
+	instance.Since = since
+
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{instance, instance},
+		},
+	}
+
+	ui, waiter, reqFactory := callRestartAppInstance(t, []string{"my-app", "1"}, restartAppInstanceReqFactory(), appInstancesRepo)
+
+	assert.Equal(t, appInstancesRepo.DeleteInstanceAppGuid, reqFactory.Application.Guid)
+	assert.Equal(t, appInstancesRepo.DeleteInstanceIndex, 1)
+
+	assert.Equal(t, waiter.AppGuid, "my-app-guid")
+	assert.Equal(t, waiter.PreviousSinces, []map[int]time.Time{{1: since}})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Restarting instance #1", "my-app", "my-org", "my-space", "my-user"},
+		{"OK"},
+		{"Instance #1 restarted"},
+	})
+}
+
+func TestRestartAppInstanceWhenTheIndexDoesNotExist(t *testing.T) {
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{cf.AppInstanceFields{}},
+		},
+	}
+
+	ui, waiter, _ := callRestartAppInstance(t, []string{"my-app", "3"}, restartAppInstanceReqFactory(), appInstancesRepo)
+
+	assert.Equal(t, appInstancesRepo.DeleteInstanceAppGuid, "")
+	assert.Equal(t, waiter.AppGuid, "")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"my-app", "no instance #3", "1 instances"},
+	})
+	assert.Equal(t, ui.FailedWithError.ExitCode(), errors.EXIT_NOT_FOUND)
+}
+
+func TestRestartAppInstanceWhenTerminatingFails(t *testing.T) {
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{cf.AppInstanceFields{}},
+		},
+		DeleteInstanceApiResponse: net.NewApiResponse("You are not authorized to perform the requested action", "10003", 403),
+	}
+
+	ui, waiter, _ := callRestartAppInstance(t, []string{"my-app", "0"}, restartAppInstanceReqFactory(), appInstancesRepo)
+
+	assert.Equal(t, waiter.AppGuid, "")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"not authorized"},
+	})
+	assert.Equal(t, ui.FailedWithError.ExitCode(), errors.EXIT_PERMISSION)
+}
+
+func restartAppInstanceReqFactory() *testreq.FakeReqFactory {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	return &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
+}
+
+func callRestartAppInstance(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, appInstancesRepo *testapi.FakeAppInstancesRepo) (ui *testterm.FakeUI, waiter *testcmd.FakeAppInstanceWaiter, factory *testreq.FakeReqFactory) {
+	ui = new(testterm.FakeUI)
+	waiter = &testcmd.FakeAppInstanceWaiter{}
+	factory = reqFactory
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewRestartAppInstance(ui, config, appInstancesRepo, waiter)
+	testcmd.RunCommand(cmd, testcmd.NewContext("restart-app-instance", args), reqFactory)
+	return
+}
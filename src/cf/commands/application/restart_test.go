@@ -3,11 +3,15 @@ package application_test
 import (
 	"cf"
 	. "cf/commands/application"
+	"cf/errors"
 	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
 	testcmd "testhelpers/commands"
 	testreq "testhelpers/requirements"
 	testterm "testhelpers/terminal"
 	"testing"
+	"time"
 )
 
 func TestRestartCommandFailsWithUsage(t *testing.T) {
@@ -54,11 +58,120 @@ func TestRestartApplication(t *testing.T) {
 	assert.Equal(t, starter.AppToStart, app)
 }
 
+func TestRestartCommandFailsWithUsageForRollingFlags(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	starter := &testcmd.FakeAppStarter{}
+	stopper := &testcmd.FakeAppStopper{}
+
+	ui := callRestart([]string{"--batch-size", "2", "my-app"}, reqFactory, starter, stopper)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRestart([]string{"--rolling", "--batch-size", "0", "my-app"}, reqFactory, starter, stopper)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRestart([]string{"--rolling", "--batch-size", "2", "--pause", "10", "my-app"}, reqFactory, starter, stopper)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestRollingRestartApplication(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.State = "started"
+
+	since := time.Now().Add(-1 * time.Hour)
+	instance := cf.AppInstanceFields{}
+	instance.State = cf.InstanceRunning
+	instance.Since = since
+
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{instance, instance, instance},
+		},
+	}
+	waiter := &testcmd.FakeAppInstanceWaiter{}
+	starter := &testcmd.FakeAppStarter{}
+	stopper := &testcmd.FakeAppStopper{}
+	reqFactory := &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui := callRollingRestart([]string{"--rolling", "--batch-size", "2", "my-app"}, reqFactory, starter, stopper, appInstancesRepo, waiter)
+
+	assert.Equal(t, stopper.AppToStop.Guid, "")
+	assert.Equal(t, starter.AppToStart.Guid, "")
+	assert.Equal(t, appInstancesRepo.DeleteInstanceIndexes, []int{0, 1, 2})
+	assert.Equal(t, waiter.PreviousSinces, []map[int]time.Time{
+		{0: since, 1: since},
+		{2: since},
+	})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Restarting instances #0-#1", "my-app", "2 of 3"},
+		{"OK"},
+		{"Restarting instance #2", "my-app", "3 of 3"},
+		{"OK"},
+		{"App my-app restarted"},
+	})
+}
+
+func TestRollingRestartStopsWhenAnInstanceIsFlapping(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.State = "started"
+
+	instance := cf.AppInstanceFields{}
+	instance.State = cf.InstanceRunning
+	instance.Since = time.Now().Add(-1 * time.Hour)
+
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{instance, instance, instance},
+		},
+	}
+	waiter := &testcmd.FakeAppInstanceWaiter{
+		WaitErrors: []errors.Error{nil, errors.New("Instance #1 is crashing")},
+	}
+	reqFactory := &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui := callRollingRestart([]string{"--rolling", "my-app"}, reqFactory, &testcmd.FakeAppStarter{}, &testcmd.FakeAppStopper{}, appInstancesRepo, waiter)
+
+	assert.Equal(t, appInstancesRepo.DeleteInstanceIndexes, []int{0, 1})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Rolling restart stopped", "1 of 3"},
+		{"FAILED"},
+		{"Instance #1 is crashing"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"Restarting instance #2"},
+	})
+}
+
+func TestRollingRestartOfAStoppedApplicationStartsIt(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.State = "stopped"
+
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{}
+	starter := &testcmd.FakeAppStarter{}
+	stopper := &testcmd.FakeAppStopper{}
+	reqFactory := &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	callRollingRestart([]string{"--rolling", "my-app"}, reqFactory, starter, stopper, appInstancesRepo, &testcmd.FakeAppInstanceWaiter{})
+
+	assert.Equal(t, stopper.AppToStop, app)
+	assert.Equal(t, starter.AppToStart, app)
+	assert.Equal(t, len(appInstancesRepo.DeleteInstanceIndexes), 0)
+}
+
 func callRestart(args []string, reqFactory *testreq.FakeReqFactory, starter ApplicationStarter, stopper ApplicationStopper) (ui *testterm.FakeUI) {
+	return callRollingRestart(args, reqFactory, starter, stopper, &testapi.FakeAppInstancesRepo{}, &testcmd.FakeAppInstanceWaiter{})
+}
+
+func callRollingRestart(args []string, reqFactory *testreq.FakeReqFactory, starter ApplicationStarter, stopper ApplicationStopper, appInstancesRepo *testapi.FakeAppInstancesRepo, waiter *testcmd.FakeAppInstanceWaiter) (ui *testterm.FakeUI) {
 	ui = new(testterm.FakeUI)
 	ctxt := testcmd.NewContext("restart", args)
 
-	cmd := NewRestart(ui, starter, stopper)
+	cmd := NewRestart(ui, starter, stopper, appInstancesRepo, waiter)
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 	return
 }
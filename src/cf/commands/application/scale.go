@@ -4,10 +4,10 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/formatters"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -42,6 +42,12 @@ func (cmd *Scale) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 		return
 	}
 
+	if !validRollingFlags(c) {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "scale")
+		return
+	}
+
 	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
 
 	reqs = []requirements.Requirement{
@@ -52,7 +58,7 @@ func (cmd *Scale) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 	return
 }
 
-func (cmd *Scale) Run(c *cli.Context) {
+func (cmd *Scale) Run(c *cli.Context) (err errors.Error) {
 	currentApp := cmd.appReq.GetApplication()
 	cmd.ui.Say("Scaling app %s in org %s / space %s as %s...",
 		terminal.EntityNameColor(currentApp.Name),
@@ -65,10 +71,11 @@ func (cmd *Scale) Run(c *cli.Context) {
 	shouldRestart := false
 
 	if c.String("m") != "" {
-		memory, err := formatters.ToMegabytes(c.String("m"))
-		if err != nil {
+		memory, formatErr := formatters.ToMegabytes(c.String("m"))
+		if formatErr != nil {
 			cmd.ui.Say("Invalid value for memory")
 			cmd.ui.FailWithUsage(c, "scale")
+			err = errors.NewUsageError("Incorrect Usage.")
 			return
 		}
 		params.Set("memory", memory)
@@ -81,14 +88,22 @@ func (cmd *Scale) Run(c *cli.Context) {
 
 	updatedApp, apiResponse := cmd.appRepo.Update(currentApp.Guid, params)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
-	if shouldRestart {
-		cmd.restarter.ApplicationRestart(updatedApp)
+	if !shouldRestart {
+		return
 	}
+
+	if c.Bool("rolling") {
+		err = cmd.restarter.ApplicationRollingRestart(updatedApp, rollingBatchSize(c), rollingPause(c))
+		return
+	}
+
+	err = cmd.restarter.ApplicationRestart(updatedApp)
+	return
 }
@@ -4,6 +4,7 @@ import (
 	"cf"
 	. "cf/commands/application"
 	"cf/configuration"
+	"cf/errors"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
@@ -13,6 +14,7 @@ import (
 	testreq "testhelpers/requirements"
 	testterm "testhelpers/terminal"
 	"testing"
+	"time"
 )
 
 func TestScaleRequirements(t *testing.T) {
@@ -104,6 +106,53 @@ func TestScaleOnlyMemory(t *testing.T) {
 	assert.False(t, deps.appRepo.UpdateParams.Has("instances"))
 }
 
+func TestScaleMemoryWithRollingRestart(t *testing.T) {
+	app := maker.NewApp(maker.Overrides{"name": "my-app", "guid": "my-app-guid"})
+	deps := getScaleDependencies()
+	deps.reqFactory.Application = app
+	deps.appRepo.UpdateAppResult = app
+
+	callScale(t, []string{"-m", "512M", "--rolling", "--batch-size", "3", "--pause", "30", "my-app"}, deps)
+
+	assert.Equal(t, deps.restarter.AppToRestart.Guid, "")
+	assert.Equal(t, deps.restarter.AppToRollingRestart.Guid, "my-app-guid")
+	assert.Equal(t, deps.restarter.RollingBatchSize, 3)
+	assert.Equal(t, deps.restarter.RollingPause, 30*time.Second)
+}
+
+func TestScaleWhenTheRestartFails(t *testing.T) {
+	app := maker.NewApp(maker.Overrides{"name": "my-app", "guid": "my-app-guid"})
+	deps := getScaleDependencies()
+	deps.reqFactory.Application = app
+	deps.appRepo.UpdateAppResult = app
+	deps.restarter.RestartErr = errors.NewTimeoutError("Start app timeout")
+
+	callScale(t, []string{"-m", "512M", "my-app"}, deps)
+
+	assert.Equal(t, testcmd.CommandRunError, deps.restarter.RestartErr)
+}
+
+func TestScaleWhenTheRollingRestartFails(t *testing.T) {
+	app := maker.NewApp(maker.Overrides{"name": "my-app", "guid": "my-app-guid"})
+	deps := getScaleDependencies()
+	deps.reqFactory.Application = app
+	deps.appRepo.UpdateAppResult = app
+	deps.restarter.RollingRestartErr = errors.New("Instance #1 is crashing")
+
+	callScale(t, []string{"-m", "512M", "--rolling", "my-app"}, deps)
+
+	assert.Equal(t, testcmd.CommandRunError, deps.restarter.RollingRestartErr)
+}
+
+func TestScaleFailsWithUsageForRollingFlagsWithoutRolling(t *testing.T) {
+	deps := getScaleDependencies()
+
+	ui := callScale(t, []string{"-m", "512M", "--batch-size", "3", "my-app"}, deps)
+
+	assert.True(t, ui.FailedWithUsage)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
 type scaleDependencies struct {
 	reqFactory *testreq.FakeReqFactory
 	restarter  *testcmd.FakeAppRestarter
@@ -4,11 +4,14 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/dotenv"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"generic"
 	"github.com/codegangsta/cli"
+	"os"
+	"sort"
 )
 
 type SetEnv struct {
@@ -27,7 +30,12 @@ func NewSetEnv(ui terminal.UI, config *configuration.Configuration, appRepo api.
 }
 
 func (cmd *SetEnv) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
-	if len(c.Args()) < 3 {
+	argCount := 3
+	if c.String("from-file") != "" {
+		argCount = 1
+	}
+
+	if len(c.Args()) < argCount || (c.Bool("replace") && c.String("from-file") == "") {
 		err = errors.New("Incorrect Usage")
 		cmd.ui.FailWithUsage(c, "set-env")
 		return
@@ -42,7 +50,12 @@ func (cmd *SetEnv) GetRequirements(reqFactory requirements.Factory, c *cli.Conte
 	return
 }
 
-func (cmd *SetEnv) Run(c *cli.Context) {
+func (cmd *SetEnv) Run(c *cli.Context) (err errors.Error) {
+	if c.String("from-file") != "" {
+		err = cmd.setEnvFromFile(c.String("from-file"), c.Bool("replace"), c.Bool("reveal"), c.Bool("f"))
+		return
+	}
+
 	varName := c.Args()[1]
 	varValue := c.Args()[2]
 	app := cmd.appReq.GetApplication()
@@ -56,8 +69,7 @@ func (cmd *SetEnv) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
-	appParams := app.ToParams()
-	envParams := appParams.Get("env").(generic.Map)
+	envParams := generic.NewMap(app.EnvironmentVars)
 	envParams.Set(varName, varValue)
 
 	updateParams := cf.NewEmptyAppParams()
@@ -66,10 +78,128 @@ func (cmd *SetEnv) Run(c *cli.Context) {
 	_, apiResponse := cmd.appRepo.Update(app.Guid, updateParams)
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("TIP: Use '%s' to ensure your env variable changes take effect", terminal.CommandColor(cf.Name()+" push"))
+	return
+}
+
+// setEnvFromFile applies every variable in a dotenv file in a single update.
+// Variables missing from the file are kept, unless replace is set. The
+// changes are shown with their values hidden, unless reveal is set, and only
+// applied once confirmed, unless force is set.
+func (cmd *SetEnv) setEnvFromFile(path string, replace, reveal, force bool) (err errors.Error) {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say("Setting env variables from %s for app %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(path),
+		terminal.EntityNameColor(app.Name),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	file, readErr := os.Open(path)
+	if readErr != nil {
+		err = cmd.ui.Failed("Could not read env file %s\n%s", path, readErr.Error())
+		return
+	}
+	defer file.Close()
+
+	fileVars, parseErr := dotenv.Parse(file)
+	if parseErr != nil {
+		err = cmd.ui.Failed("Invalid env file %s\n%s", path, parseErr.Error())
+		return
+	}
+
+	newVars := map[string]string{}
+	if !replace {
+		for name, value := range app.EnvironmentVars {
+			newVars[name] = value
+		}
+	}
+	for name, value := range fileVars {
+		newVars[name] = value
+	}
+
+	if !cmd.displayEnvDiff(app.EnvironmentVars, newVars, reveal) {
+		cmd.ui.Ok()
+		cmd.ui.Say("No env variables changed")
+		return
+	}
+
+	if !reveal {
+		cmd.ui.Say("Values are hidden, use %s to show them", terminal.CommandColor("--reveal"))
+	}
+
+	if !force {
+		var confirmed bool
+		confirmed, err = terminal.Confirm(cmd.ui, "Apply these changes to app %s?", terminal.EntityNameColor(app.Name))
+		if !confirmed {
+			return
+		}
+	}
+
+	envParams := generic.NewMap()
+	for name, value := range newVars {
+		envParams.Set(name, value)
+	}
+
+	updateParams := cf.NewEmptyAppParams()
+	updateParams.Set("env", envParams)
+
+	_, apiResponse := cmd.appRepo.Update(app.Guid, updateParams)
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 	cmd.ui.Say("TIP: Use '%s' to ensure your env variable changes take effect", terminal.CommandColor(cf.Name()+" push"))
+	return
+}
+
+// displayEnvDiff prints the variables that are added, changed and removed
+// when going from oldVars to newVars, and reports whether there were any.
+// Values are replaced by a placeholder unless reveal is set.
+func (cmd *SetEnv) displayEnvDiff(oldVars, newVars map[string]string, reveal bool) (changed bool) {
+	names := []string{}
+	for name := range oldVars {
+		names = append(names, name)
+	}
+	for name := range newVars {
+		if _, found := oldVars[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	cmd.ui.Say("")
+	for _, name := range names {
+		oldValue, inOld := oldVars[name]
+		newValue, inNew := newVars[name]
+		if inOld && inNew && oldValue == newValue {
+			continue
+		}
+		changed = true
+
+		shownOld, shownNew := oldValue, newValue
+		if !reveal {
+			shownOld, shownNew = HiddenCredentialValue, HiddenCredentialValue
+		}
+
+		switch {
+		case !inOld:
+			cmd.ui.Say(terminal.SuccessColor("+ %s: %s"), name, shownNew)
+		case !inNew:
+			cmd.ui.Say(terminal.FailureColor("- %s: %s"), name, shownOld)
+		default:
+			cmd.ui.Say(terminal.AdvisoryColor("~ %s: %s -> %s"), name, shownOld, shownNew)
+		}
+	}
+	cmd.ui.Say("")
+	return
 }
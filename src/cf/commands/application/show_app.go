@@ -4,13 +4,14 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/formatters"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
 	"strings"
+	"time"
 )
 
 type ShowApp struct {
@@ -22,7 +23,7 @@ type ShowApp struct {
 }
 
 type ApplicationDisplayer interface {
-	ShowApp(app cf.Application)
+	ShowApp(app cf.Application) (err errors.Error)
 }
 
 func NewShowApp(ui terminal.UI, config *configuration.Configuration, appSummaryRepo api.AppSummaryRepository, appInstancesRepo api.AppInstancesRepository) (cmd *ShowApp) {
@@ -41,6 +42,12 @@ func (cmd *ShowApp) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 		return
 	}
 
+	if !validWatchFlags(c) {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "app")
+		return
+	}
+
 	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
 
 	reqs = []requirements.Requirement{
@@ -51,12 +58,19 @@ func (cmd *ShowApp) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 	return
 }
 
-func (cmd *ShowApp) Run(c *cli.Context) {
+func (cmd *ShowApp) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
-	cmd.ShowApp(app)
+
+	if c.Bool("watch") {
+		err = cmd.WatchApp(app, watchInterval(c), c.Int("count"), c.String("space-apps"))
+		return
+	}
+
+	err = cmd.ShowApp(app)
+	return
 }
 
-func (cmd *ShowApp) ShowApp(app cf.Application) {
+func (cmd *ShowApp) ShowApp(app cf.Application) (err errors.Error) {
 
 	cmd.ui.Say("Showing health and status for app %s in org %s / space %s as %s...",
 		terminal.EntityNameColor(app.Name),
@@ -71,28 +85,19 @@ func (cmd *ShowApp) ShowApp(app cf.Application) {
 		appSummary.State == "stopped"
 
 	if apiResponse.IsNotSuccessful() && !appIsStopped {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	var instances []cf.AppInstanceFields
 	instances, apiResponse = cmd.appInstancesRepo.GetInstances(app.Guid)
 	if apiResponse.IsNotSuccessful() && !appIsStopped {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
-	cmd.ui.Say("\n%s %s", terminal.HeaderColor("requested state:"), coloredAppState(appSummary.ApplicationFields))
-	cmd.ui.Say("%s %s", terminal.HeaderColor("instances:"), coloredAppInstances(appSummary.ApplicationFields))
-	cmd.ui.Say("%s %s x %d instances", terminal.HeaderColor("usage:"), formatters.ByteSize(appSummary.Memory*formatters.MEGABYTE), appSummary.InstanceCount)
-
-	var urls []string
-	for _, route := range appSummary.RouteSummaries {
-		urls = append(urls, route.URL())
-	}
-
-	cmd.ui.Say("%s %s\n", terminal.HeaderColor("urls:"), strings.Join(urls, ", "))
+	cmd.displaySummary(appSummary)
 
 	if appIsStopped {
 		cmd.ui.Say("There are no running instances of this app.")
@@ -103,16 +108,40 @@ func (cmd *ShowApp) ShowApp(app cf.Application) {
 		[]string{"", "state", "since", "cpu", "memory", "disk"},
 	}
 
+	now := time.Now()
 	for index, instance := range instances {
-		table = append(table, []string{
-			fmt.Sprintf("#%d", index),
-			coloredInstanceState(instance),
-			instance.Since.Format("2006-01-02 03:04:05 PM"),
-			fmt.Sprintf("%.1f%%", instance.CpuUsage*100),
-			fmt.Sprintf("%s of %s", formatters.ByteSize(instance.MemUsage), formatters.ByteSize(instance.MemQuota)),
-			fmt.Sprintf("%s of %s", formatters.ByteSize(instance.DiskUsage), formatters.ByteSize(instance.DiskQuota)),
-		})
+		table = append(table, instanceRow(index, instance, instances, now))
 	}
 
 	cmd.ui.DisplayTable(table)
+	return
+}
+
+func (cmd *ShowApp) displaySummary(appSummary cf.AppSummary) {
+	cmd.ui.Say("\n%s %s", terminal.HeaderColor("requested state:"), coloredAppState(appSummary.ApplicationFields))
+	cmd.ui.Say("%s %s", terminal.HeaderColor("instances:"), coloredAppInstances(appSummary.ApplicationFields))
+	cmd.ui.Say("%s %s x %d instances", terminal.HeaderColor("usage:"), formatters.ByteSize(appSummary.Memory*formatters.MEGABYTE), appSummary.InstanceCount)
+
+	var urls []string
+	for _, route := range appSummary.RouteSummaries {
+		urls = append(urls, route.URL())
+	}
+
+	cmd.ui.Say("%s %s\n", terminal.HeaderColor("urls:"), strings.Join(urls, ", "))
+}
+
+func instanceRow(index int, instance cf.AppInstanceFields, instances []cf.AppInstanceFields, now time.Time) []string {
+	state := coloredInstanceState(instance)
+	if recentlyStarted(instance, instances, now) {
+		state = state + terminal.AdvisoryColor(" (recently started)")
+	}
+
+	return []string{
+		fmt.Sprintf("#%d", index),
+		state,
+		instance.Since.Format("2006-01-02 03:04:05 PM"),
+		fmt.Sprintf("%.1f%%", instance.CpuUsage*100),
+		fmt.Sprintf("%s of %s", formatters.ByteSize(instance.MemUsage), formatters.ByteSize(instance.MemQuota)),
+		fmt.Sprintf("%s of %s", formatters.ByteSize(instance.DiskUsage), formatters.ByteSize(instance.DiskQuota)),
+	}
 }
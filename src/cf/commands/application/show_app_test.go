@@ -109,6 +109,38 @@ func TestDisplayingAppSummary(t *testing.T) {
 	})
 }
 
+func TestDisplayingAppSummaryMarksRecentlyStartedInstances(t *testing.T) {
+	reqApp := cf.Application{}
+	reqApp.Name = "my-app"
+	reqApp.Guid = "my-app-guid"
+
+	appSummary := cf.AppSummary{}
+	appSummary.State = "started"
+	appSummary.InstanceCount = 2
+
+	appInstance := cf.AppInstanceFields{}
+	appInstance.State = cf.InstanceRunning
+	appInstance.Since = time.Now().Add(-2 * time.Hour)
+
+	appInstance2 := cf.AppInstanceFields{}
+	appInstance2.State = cf.InstanceRunning
+	appInstance2.Since = time.Now().Add(-1 * time.Minute)
+
+	instances := []cf.AppInstanceFields{appInstance, appInstance2}
+
+	appSummaryRepo := &testapi.FakeAppSummaryRepo{GetSummarySummary: appSummary}
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{GetInstancesResponses: [][]cf.AppInstanceFields{instances}}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: reqApp}
+	ui := callApp(t, []string{"my-app"}, reqFactory, appSummaryRepo, appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"#1", "running (recently started)"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"#0", "recently started"},
+	})
+}
+
 func TestDisplayingStoppedAppSummary(t *testing.T) {
 	testDisplayingAppSummaryWithErrorCode(t, cf.APP_STOPPED)
 }
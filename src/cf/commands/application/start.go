@@ -4,13 +4,15 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"fmt"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/codegangsta/cli"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -32,18 +34,25 @@ type Start struct {
 	appRepo          api.ApplicationRepository
 	appInstancesRepo api.AppInstancesRepository
 	logRepo          api.LogsRepository
+	appEventsRepo    api.AppEventsRepository
 
 	StartupTimeout time.Duration
 	StagingTimeout time.Duration
 	PingerThrottle time.Duration
+
+	timeoutErr errors.Error
+}
+
+type ApplicationInstanceWaiter interface {
+	WaitForInstancesRestart(appGuid string, previousSinces map[int]time.Time) (err errors.Error)
 }
 
 type ApplicationStarter interface {
 	SetStartTimeoutSeconds(timeout int)
-	ApplicationStart(app cf.Application) (updatedApp cf.Application, err error)
+	ApplicationStart(app cf.Application) (updatedApp cf.Application, err errors.Error)
 }
 
-func NewStart(ui terminal.UI, config *configuration.Configuration, appDisplayer ApplicationDisplayer, appRepo api.ApplicationRepository, appInstancesRepo api.AppInstancesRepository, logRepo api.LogsRepository) (cmd *Start) {
+func NewStart(ui terminal.UI, config *configuration.Configuration, appDisplayer ApplicationDisplayer, appRepo api.ApplicationRepository, appInstancesRepo api.AppInstancesRepository, logRepo api.LogsRepository, appEventsRepo api.AppEventsRepository) (cmd *Start) {
 	cmd = new(Start)
 	cmd.ui = ui
 	cmd.config = config
@@ -51,13 +60,14 @@ func NewStart(ui terminal.UI, config *configuration.Configuration, appDisplayer
 	cmd.appRepo = appRepo
 	cmd.appInstancesRepo = appInstancesRepo
 	cmd.logRepo = logRepo
+	cmd.appEventsRepo = appEventsRepo
 
 	cmd.PingerThrottle = DefaultPingerThrottle
 
 	if os.Getenv("CF_STAGING_TIMEOUT") != "" {
 		duration, err := strconv.ParseInt(os.Getenv("CF_STAGING_TIMEOUT"), 10, 64)
 		if err != nil {
-			cmd.ui.Failed("invalid value for env var CF_STAGING_TIMEOUT\n%s", err)
+			cmd.timeoutErr = errors.New(fmt.Sprintf("invalid value for env var CF_STAGING_TIMEOUT\n%s", err))
 		}
 		cmd.StagingTimeout = time.Duration(duration) * time.Minute
 	} else {
@@ -67,7 +77,7 @@ func NewStart(ui terminal.UI, config *configuration.Configuration, appDisplayer
 	if os.Getenv("CF_STARTUP_TIMEOUT") != "" {
 		duration, err := strconv.ParseInt(os.Getenv("CF_STARTUP_TIMEOUT"), 10, 64)
 		if err != nil {
-			cmd.ui.Failed("invalid value for env var CF_STARTUP_TIMEOUT\n%s", err)
+			cmd.timeoutErr = errors.New(fmt.Sprintf("invalid value for env var CF_STARTUP_TIMEOUT\n%s", err))
 		}
 		cmd.StartupTimeout = time.Duration(duration) * time.Minute
 	} else {
@@ -90,11 +100,17 @@ func (cmd *Start) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 	return
 }
 
-func (cmd *Start) Run(c *cli.Context) {
-	cmd.ApplicationStart(cmd.appReq.GetApplication())
+func (cmd *Start) Run(c *cli.Context) (err errors.Error) {
+	_, err = cmd.ApplicationStart(cmd.appReq.GetApplication())
+	return
 }
 
-func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Application, err error) {
+func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Application, err errors.Error) {
+	if cmd.timeoutErr != nil {
+		err = cmd.ui.FailWithError(cmd.timeoutErr)
+		return
+	}
+
 	if app.State == "started" {
 		cmd.ui.Say(terminal.WarningColor("App " + app.Name + " is already started"))
 		return
@@ -120,21 +136,31 @@ func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Applicatio
 	params.Set("state", "STARTED")
 	updatedApp, apiResponse := cmd.appRepo.Update(app.Guid, params)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 
-	cmd.waitForInstancesToStage(updatedApp)
+	if net.IsDryRun() {
+		return
+	}
+
+	err = cmd.waitForInstancesToStage(updatedApp)
+	if err != nil {
+		return
+	}
 	stopLoggingChan <- true
 
 	cmd.ui.Say("")
 
-	cmd.waitForOneRunningInstance(app.Guid)
+	err = cmd.waitForOneRunningInstance(app)
+	if err != nil {
+		return
+	}
 	cmd.ui.Say(terminal.HeaderColor("\nApp started\n"))
 
-	cmd.appDisplayer.ShowApp(app)
+	err = cmd.appDisplayer.ShowApp(app)
 	return
 }
 
@@ -171,14 +197,14 @@ func (cmd Start) displayLogMessages(logChan chan *logmessage.Message) {
 	}
 }
 
-func (cmd Start) waitForInstancesToStage(app cf.Application) {
+func (cmd Start) waitForInstancesToStage(app cf.Application) (err errors.Error) {
 	stagingStartTime := time.Now()
 	_, apiResponse := cmd.appInstancesRepo.GetInstances(app.Guid)
 
 	for apiResponse.IsNotSuccessful() && time.Since(stagingStartTime) < cmd.StagingTimeout {
 		if apiResponse.ErrorCode != cf.APP_NOT_STAGED {
-			cmd.ui.Say("")
-			cmd.ui.Failed(apiResponse.Message)
+			cmd.printFailureReport(app, nil)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
 			return
 		}
 		cmd.ui.Wait(cmd.PingerThrottle)
@@ -187,24 +213,13 @@ func (cmd Start) waitForInstancesToStage(app cf.Application) {
 	return
 }
 
-func (cmd Start) waitForOneRunningInstance(appGuid string) {
-	var runningCount, startingCount, flappingCount, downCount int
-	startupStartTime := time.Now()
-
-	for runningCount == 0 {
-		if time.Since(startupStartTime) > cmd.StartupTimeout {
-			cmd.ui.Failed("Start app timeout")
-			return
-		}
-
-		instances, apiResponse := cmd.appInstancesRepo.GetInstances(appGuid)
-		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Wait(cmd.PingerThrottle)
-			continue
-		}
+func (cmd Start) waitForOneRunningInstance(app cf.Application) (err errors.Error) {
+	var lastInstances []cf.AppInstanceFields
 
+	pollErr := cmd.pollInstances(app.Guid, "Start app timeout", func(instances []cf.AppInstanceFields) bool {
+		lastInstances = instances
+		var runningCount, startingCount, flappingCount, downCount int
 		totalCount := len(instances)
-		runningCount, startingCount, flappingCount, downCount = 0, 0, 0, 0
 
 		for _, inst := range instances {
 			switch inst.State {
@@ -222,7 +237,93 @@ func (cmd Start) waitForOneRunningInstance(appGuid string) {
 		cmd.ui.Say(instancesDetails(startingCount, downCount, runningCount, flappingCount, totalCount))
 
 		if flappingCount > 0 {
-			cmd.ui.Failed("Start unsuccessful")
+			cmd.printFailureReport(app, instances)
+			err = cmd.ui.Failed("Start unsuccessful")
+			return true
+		}
+		return runningCount > 0
+	})
+
+	if pollErr != nil {
+		cmd.printFailureReport(app, lastInstances)
+		err = cmd.ui.FailWithError(pollErr)
+	}
+	return
+}
+
+// WaitForInstancesRestart polls until every instance in previousSinces is
+// running again, which is when it reports a start time later than the one
+// recorded for its index. It gives up as soon as any instance of the app is
+// flapping, whether or not it was part of the batch being restarted.
+func (cmd Start) WaitForInstancesRestart(appGuid string, previousSinces map[int]time.Time) (err errors.Error) {
+	indexes := []int{}
+	for index := range previousSinces {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	pollErr := cmd.pollInstances(appGuid, "Restart instance timeout", func(instances []cf.AppInstanceFields) bool {
+		runningCount := 0
+
+		for _, index := range indexes {
+			if index >= len(instances) || !instances[index].Since.After(previousSinces[index]) {
+				cmd.ui.Say("instance #%d is stopping", index)
+				continue
+			}
+
+			instance := instances[index]
+			cmd.ui.Say("instance #%d is %s", index, coloredInstanceState(instance))
+
+			switch instance.State {
+			case cf.InstanceRunning:
+				runningCount++
+			case cf.InstanceFlapping:
+				err = errors.New(fmt.Sprintf("Instance #%d is crashing", index))
+				return true
+			}
+		}
+
+		for index, instance := range instances {
+			if _, restarting := previousSinces[index]; restarting || instance.State != cf.InstanceFlapping {
+				continue
+			}
+			cmd.ui.Say("instance #%d is %s", index, coloredInstanceState(instance))
+			err = errors.New(fmt.Sprintf("Instance #%d is crashing", index))
+			return true
+		}
+
+		if runningCount == len(indexes) {
+			return true
+		}
+
+		cmd.ui.Wait(cmd.PingerThrottle)
+		return false
+	})
+
+	if pollErr != nil {
+		err = pollErr
+	}
+	return
+}
+
+// pollInstances fetches the app's instances until done reports true. It
+// returns a timeout error once the startup timeout has passed.
+func (cmd Start) pollInstances(appGuid, timeoutMessage string, done func(instances []cf.AppInstanceFields) bool) (err errors.Error) {
+	startupStartTime := time.Now()
+
+	for {
+		if time.Since(startupStartTime) > cmd.StartupTimeout {
+			err = errors.NewTimeoutError(timeoutMessage)
+			return
+		}
+
+		instances, apiResponse := cmd.appInstancesRepo.GetInstances(appGuid)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Wait(cmd.PingerThrottle)
+			continue
+		}
+
+		if done(instances) {
 			return
 		}
 	}
@@ -5,7 +5,7 @@ import (
 	"cf/api"
 	. "cf/commands/application"
 	"cf/configuration"
-	"errors"
+	"cf/errors"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/stretchr/testify/assert"
 	"os"
@@ -63,7 +63,7 @@ func callStart(args []string, config *configuration.Configuration, reqFactory *t
 	ui = new(testterm.FakeUI)
 	ctxt := testcmd.NewContext("start", args)
 
-	cmd := NewStart(ui, config, displayApp, appRepo, appInstancesRepo, logRepo)
+	cmd := NewStart(ui, config, displayApp, appRepo, appInstancesRepo, logRepo, &testapi.FakeAppEventsRepo{})
 	cmd.StagingTimeout = 5 * time.Millisecond
 	cmd.StartupTimeout = config.ApplicationStartTimeout
 	cmd.PingerThrottle = 5 * time.Millisecond
@@ -112,7 +112,7 @@ func startAppWithInstancesAndErrors(t *testing.T, displayApp ApplicationDisplaye
 }
 
 func TestStartCommandDefaultTimeouts(t *testing.T) {
-	cmd := NewStart(new(testterm.FakeUI), &configuration.Configuration{}, &testcmd.FakeAppDisplayer{}, &testapi.FakeApplicationRepository{}, &testapi.FakeAppInstancesRepo{}, &testapi.FakeLogsRepository{})
+	cmd := NewStart(new(testterm.FakeUI), &configuration.Configuration{}, &testcmd.FakeAppDisplayer{}, &testapi.FakeApplicationRepository{}, &testapi.FakeAppInstancesRepo{}, &testapi.FakeLogsRepository{}, &testapi.FakeAppEventsRepo{})
 	assert.Equal(t, cmd.StagingTimeout, 15*time.Minute)
 	assert.Equal(t, cmd.StartupTimeout, 5*time.Minute)
 }
@@ -127,11 +127,96 @@ func TestStartCommandSetsTimeoutsFromEnv(t *testing.T) {
 
 	os.Setenv("CF_STAGING_TIMEOUT", "6")
 	os.Setenv("CF_STARTUP_TIMEOUT", "3")
-	cmd := NewStart(new(testterm.FakeUI), &configuration.Configuration{}, &testcmd.FakeAppDisplayer{}, &testapi.FakeApplicationRepository{}, &testapi.FakeAppInstancesRepo{}, &testapi.FakeLogsRepository{})
+	cmd := NewStart(new(testterm.FakeUI), &configuration.Configuration{}, &testcmd.FakeAppDisplayer{}, &testapi.FakeApplicationRepository{}, &testapi.FakeAppInstancesRepo{}, &testapi.FakeLogsRepository{}, &testapi.FakeAppEventsRepo{})
 	assert.Equal(t, cmd.StagingTimeout, 6*time.Minute)
 	assert.Equal(t, cmd.StartupTimeout, 3*time.Minute)
 }
 
+func TestWaitForInstancesRestart(t *testing.T) {
+	previousSince := time.Now().Add(-1 * time.Hour)
+
+	oldInstance := cf.AppInstanceFields{}
+	oldInstance.State = cf.InstanceRunning
+	oldInstance.Since = previousSince
+	otherInstance := cf.AppInstanceFields{}
+	otherInstance.State = cf.InstanceRunning
+	otherInstance.Since = previousSince
+	startingInstance := cf.AppInstanceFields{}
+	startingInstance.State = cf.InstanceStarting
+	startingInstance.Since = time.Now()
+	runningInstance := cf.AppInstanceFields{}
+	runningInstance.State = cf.InstanceRunning
+	runningInstance.Since = time.Now()
+
+	ui := new(testterm.FakeUI)
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{otherInstance, oldInstance},
+			[]cf.AppInstanceFields{otherInstance, startingInstance},
+			[]cf.AppInstanceFields{otherInstance, runningInstance},
+		},
+	}
+
+	cmd := NewStart(ui, &configuration.Configuration{}, &testcmd.FakeAppDisplayer{}, &testapi.FakeApplicationRepository{}, appInstancesRepo, &testapi.FakeLogsRepository{}, &testapi.FakeAppEventsRepo{})
+	cmd.PingerThrottle = 5 * time.Millisecond
+	err := cmd.WaitForInstancesRestart("my-app-guid", map[int]time.Time{1: previousSince})
+	assert.Nil(t, err)
+
+	assert.Equal(t, appInstancesRepo.GetInstancesAppGuid, "my-app-guid")
+	assert.Equal(t, len(appInstancesRepo.GetInstancesResponses), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"instance #1 is stopping"},
+		{"instance #1 is starting"},
+		{"instance #1 is running"},
+	})
+}
+
+func TestWaitForInstancesRestartWhenAnInstanceIsFlapping(t *testing.T) {
+	flappingInstance := cf.AppInstanceFields{}
+	flappingInstance.State = cf.InstanceFlapping
+	flappingInstance.Since = time.Now()
+
+	ui := new(testterm.FakeUI)
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{flappingInstance},
+		},
+	}
+
+	cmd := NewStart(ui, &configuration.Configuration{}, &testcmd.FakeAppDisplayer{}, &testapi.FakeApplicationRepository{}, appInstancesRepo, &testapi.FakeLogsRepository{}, &testapi.FakeAppEventsRepo{})
+	err := cmd.WaitForInstancesRestart("my-app-guid", map[int]time.Time{0: time.Now().Add(-1 * time.Hour)})
+
+	assert.Equal(t, err.Error(), "Instance #0 is crashing")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"instance #0 is crashing"},
+	})
+}
+
+func TestWaitForInstancesRestartWhenAnotherInstanceIsFlapping(t *testing.T) {
+	runningInstance := cf.AppInstanceFields{}
+	runningInstance.State = cf.InstanceRunning
+	runningInstance.Since = time.Now()
+	flappingInstance := cf.AppInstanceFields{}
+	flappingInstance.State = cf.InstanceFlapping
+	flappingInstance.Since = time.Now().Add(-2 * time.Hour)
+
+	ui := new(testterm.FakeUI)
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{runningInstance, flappingInstance},
+		},
+	}
+
+	cmd := NewStart(ui, &configuration.Configuration{}, &testcmd.FakeAppDisplayer{}, &testapi.FakeApplicationRepository{}, appInstancesRepo, &testapi.FakeLogsRepository{}, &testapi.FakeAppEventsRepo{})
+	err := cmd.WaitForInstancesRestart("my-app-guid", map[int]time.Time{0: time.Now().Add(-1 * time.Hour)})
+
+	assert.Equal(t, err.Error(), "Instance #1 is crashing")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"instance #0 is running"},
+		{"instance #1 is crashing"},
+	})
+}
+
 func TestStartCommandFailsWithUsage(t *testing.T) {
 	t.Parallel()
 
@@ -222,9 +307,10 @@ func TestStartApplicationWhenAppHasNoURL(t *testing.T) {
 	appInstance.State = cf.InstanceRunning
 	instances := [][]cf.AppInstanceFields{
 		[]cf.AppInstanceFields{appInstance},
+		[]cf.AppInstanceFields{appInstance},
 	}
 
-	errorCodes := []string{""}
+	errorCodes := []string{"", ""}
 	ui, appRepo, _, reqFactory := startAppWithInstancesAndErrors(t, displayApp, app, instances, errorCodes, defaultStartTimeout)
 
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
@@ -343,7 +429,7 @@ func TestStartApplicationWhenStartTimesOut(t *testing.T) {
 		[]cf.AppInstanceFields{appInstance5, appInstance6},
 	}
 
-	errorCodes := []string{"500", "500", "500"}
+	errorCodes := []string{"", "", ""}
 
 	ui, _, _, _ := startAppWithInstancesAndErrors(t, displayApp, defaultAppForStart, instances, errorCodes, 0)
 
@@ -356,6 +442,7 @@ func TestStartApplicationWhenStartTimesOut(t *testing.T) {
 	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
 		{"instances running"},
 	})
+	assert.Equal(t, ui.FailedWithError.ExitCode(), errors.EXIT_TIMEOUT)
 }
 
 func TestStartApplicationWhenStartFails(t *testing.T) {
@@ -443,3 +530,72 @@ func TestStartApplicationWithLoggingFailure(t *testing.T) {
 		testassert.Line{"Ooops"},
 	})
 }
+
+func TestStartApplicationPrintsAFailureReportWhenAnInstanceFlaps(t *testing.T) {
+	starting := cf.AppInstanceFields{State: cf.InstanceStarting}
+	flapping := cf.AppInstanceFields{State: cf.InstanceFlapping}
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses:  [][]cf.AppInstanceFields{{starting, starting}, {starting, flapping}},
+		GetInstancesErrorCodes: []string{"", ""},
+	}
+
+	crashTime := time.Now().Add(-1 * time.Minute)
+	appEventsRepo := &testapi.FakeAppEventsRepo{
+		Events: []cf.EventFields{
+			{InstanceIndex: 1, Timestamp: crashTime.Add(-1 * time.Hour), ExitDescription: "old crash", ExitStatus: 2},
+			{InstanceIndex: 1, Timestamp: crashTime, ExitDescription: "app instance exited", ExitStatus: 137},
+		},
+	}
+
+	logRepo := &testapi.FakeLogsRepository{
+		RecentLogs: []*logmessage.Message{
+			NewLogMessage("Installing dependencies", "my-app-guid", LogMessageTypeStaging, crashTime),
+			NewLogMessage("panic: out of memory", "my-app-guid", "App", crashTime),
+		},
+	}
+
+	ui := new(testterm.FakeUI)
+	config := &configuration.Configuration{ApplicationStartTimeout: defaultStartTimeout}
+	appRepo := &testapi.FakeApplicationRepository{ReadApp: defaultAppForStart, UpdateAppResult: defaultAppForStart}
+
+	cmd := NewStart(ui, config, &testcmd.FakeAppDisplayer{}, appRepo, appInstancesRepo, logRepo, appEventsRepo)
+	cmd.StartupTimeout = defaultStartTimeout
+	cmd.PingerThrottle = 5 * time.Millisecond
+	cmd.ApplicationStart(defaultAppForStart)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"1 failing"},
+		{"Gathering diagnostics", "my-app"},
+		{"Recent crashes"},
+		{"time", "instance", "description", "exit status"},
+		{"app instance exited", "137"},
+		{"old crash", "2"},
+		{"Last 1 log lines"},
+		{"panic: out of memory"},
+		{"Instances"},
+		{"#0", "starting"},
+		{"#1", "crashing"},
+		{"FAILED"},
+		{"Start unsuccessful"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"Installing dependencies"},
+	})
+}
+
+func TestStartApplicationPrintsAFailureReportWhenStagingFails(t *testing.T) {
+	displayApp := &testcmd.FakeAppDisplayer{}
+	instances := [][]cf.AppInstanceFields{[]cf.AppInstanceFields{}}
+	errorCodes := []string{"170001"}
+
+	ui, _, _, _ := startAppWithInstancesAndErrors(t, displayApp, defaultAppForStart, instances, errorCodes, defaultStartTimeout)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Gathering diagnostics", "my-app"},
+		{"Recent crashes"},
+		{"none"},
+		{"Last 0 log lines"},
+		{"FAILED"},
+		{"Error staging app"},
+	})
+}
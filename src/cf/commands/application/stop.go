@@ -4,14 +4,14 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
 type ApplicationStopper interface {
-	ApplicationStop(app cf.Application) (updatedApp cf.Application, err error)
+	ApplicationStop(app cf.Application) (updatedApp cf.Application, err errors.Error)
 }
 
 type Stop struct {
@@ -43,7 +43,7 @@ func (cmd *Stop) GetRequirements(reqFactory requirements.Factory, c *cli.Context
 	return
 }
 
-func (cmd *Stop) ApplicationStop(app cf.Application) (updatedApp cf.Application, err error) {
+func (cmd *Stop) ApplicationStop(app cf.Application) (updatedApp cf.Application, err errors.Error) {
 	if app.State == "stopped" {
 		updatedApp = app
 		cmd.ui.Say(terminal.WarningColor("App " + app.Name + " is already stopped"))
@@ -62,8 +62,7 @@ func (cmd *Stop) ApplicationStop(app cf.Application) (updatedApp cf.Application,
 
 	updatedApp, apiResponse := cmd.appRepo.Update(app.Guid, params)
 	if apiResponse.IsNotSuccessful() {
-		err = errors.New(apiResponse.Message)
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -71,7 +70,8 @@ func (cmd *Stop) ApplicationStop(app cf.Application) (updatedApp cf.Application,
 	return
 }
 
-func (cmd *Stop) Run(c *cli.Context) {
+func (cmd *Stop) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
-	cmd.ApplicationStop(app)
+	_, err = cmd.ApplicationStop(app)
+	return
 }
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"generic"
 	"github.com/codegangsta/cli"
 )
@@ -42,7 +42,7 @@ func (cmd *UnsetEnv) GetRequirements(reqFactory requirements.Factory, c *cli.Con
 	return
 }
 
-func (cmd *UnsetEnv) Run(c *cli.Context) {
+func (cmd *UnsetEnv) Run(c *cli.Context) (err errors.Error) {
 	varName := c.Args()[1]
 	app := cmd.appReq.GetApplication()
 
@@ -54,8 +54,7 @@ func (cmd *UnsetEnv) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
-	appParams := app.ToParams()
-	envParams := appParams.Get("env").(generic.Map)
+	envParams := generic.NewMap(app.EnvironmentVars)
 
 	if !envParams.Has(varName) {
 		cmd.ui.Ok()
@@ -71,10 +70,11 @@ func (cmd *UnsetEnv) Run(c *cli.Context) {
 	_, apiResponse := cmd.appRepo.Update(app.Guid, updateParams)
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 	cmd.ui.Say("TIP: Use '%s' to ensure your env variable changes take effect", terminal.CommandColor(cf.Name()+" push"))
+	return
 }
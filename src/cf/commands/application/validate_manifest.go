@@ -0,0 +1,61 @@
+package application
+
+import (
+	"cf/errors"
+	"cf/manifest"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+	"path/filepath"
+)
+
+type ValidateManifest struct {
+	ui           terminal.UI
+	manifestRepo manifest.ManifestRepository
+}
+
+func NewValidateManifest(ui terminal.UI, manifestRepo manifest.ManifestRepository) (cmd *ValidateManifest) {
+	cmd = new(ValidateManifest)
+	cmd.ui = ui
+	cmd.manifestRepo = manifestRepo
+	return
+}
+
+func (cmd *ValidateManifest) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "validate-manifest")
+		return
+	}
+	return
+}
+
+func (cmd *ValidateManifest) Run(c *cli.Context) (err errors.Error) {
+	manifestDir, manifestFilename, pathErr := cmd.manifestRepo.ManifestPath(c.String("f"))
+	if pathErr != nil {
+		err = cmd.ui.Failed("%s", pathErr)
+		return
+	}
+	manifestPath := filepath.Join(manifestDir, manifestFilename)
+
+	cmd.ui.Say("Validating manifest file %s...", terminal.EntityNameColor(manifestPath))
+
+	problems, readErr := cmd.manifestRepo.ValidateManifest(manifestPath)
+	if readErr != nil {
+		err = cmd.ui.Failed("Error reading manifest file:\n%s", readErr)
+		return
+	}
+
+	if len(problems) == 0 {
+		cmd.ui.Ok()
+		return
+	}
+
+	cmd.ui.Say("")
+	for _, problem := range problems {
+		cmd.ui.Say("%s", problem)
+	}
+	cmd.ui.Say("")
+	err = cmd.ui.Failed("Found %d problem(s) in the manifest", len(problems))
+	return
+}
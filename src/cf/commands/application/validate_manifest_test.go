@@ -0,0 +1,81 @@
+package application_test
+
+import (
+	. "cf/commands/application"
+	"cf/manifest"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testmanifest "testhelpers/manifest"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestValidateManifestFailsWithUsage(t *testing.T) {
+	manifestRepo := &testmanifest.FakeManifestRepository{}
+
+	ui := callValidateManifest([]string{"extra-arg"}, manifestRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callValidateManifest([]string{}, manifestRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestValidateManifestWithAValidManifest(t *testing.T) {
+	manifestRepo := &testmanifest.FakeManifestRepository{ManifestDir: "/some/dir"}
+
+	ui := callValidateManifest([]string{"-f", "/some/dir"}, manifestRepo)
+
+	assert.Equal(t, manifestRepo.UserSpecifiedPath, "/some/dir")
+	assert.Equal(t, manifestRepo.ValidateManifestPath, filepath.Join("/some/dir", "manifest.yml"))
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Validating manifest file", "manifest.yml"},
+		{"OK"},
+	})
+}
+
+func TestValidateManifestListsEveryProblemAndFails(t *testing.T) {
+	manifestRepo := &testmanifest.FakeManifestRepository{
+		ManifestDir: "/some/dir",
+		ValidateManifestProblems: []manifest.ManifestProblem{
+			{File: "manifest.yml", Line: 6, App: "web", Message: "Unknown key 'instance', did you mean 'instances'?"},
+			{File: "base.yml", Line: 2, Message: "memory should not be null"},
+		},
+	}
+
+	ui := callValidateManifest([]string{}, manifestRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"manifest.yml:6: app web: Unknown key 'instance', did you mean 'instances'?"},
+		{"base.yml:2: memory should not be null"},
+		{"FAILED"},
+		{"Found 2 problem(s)"},
+	})
+}
+
+func TestValidateManifestWhenTheManifestCannotBeRead(t *testing.T) {
+	manifestRepo := &testmanifest.FakeManifestRepository{
+		ValidateManifestErr: errors.New("open manifest.yml: no such file or directory"),
+	}
+
+	ui := callValidateManifest([]string{}, manifestRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error reading manifest file"},
+		{"no such file or directory"},
+	})
+}
+
+func callValidateManifest(args []string, manifestRepo *testmanifest.FakeManifestRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("validate-manifest", args)
+	reqFactory := &testreq.FakeReqFactory{}
+
+	cmd := NewValidateManifest(ui, manifestRepo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
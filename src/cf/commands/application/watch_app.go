@@ -0,0 +1,240 @@
+package application
+
+import (
+	"cf"
+	"cf/errors"
+	"cf/formatters"
+	"cf/terminal"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"sort"
+	"time"
+)
+
+const DefaultWatchInterval = 2 * time.Second
+
+const (
+	SORT_BY_MEMORY = "memory"
+	SORT_BY_CPU    = "cpu"
+)
+
+// WatchApp redraws the app's status every interval, highlighting what changed
+// since the previous refresh and counting the crashes seen along the way.
+// It refreshes count times, or until interrupted when count is 0.
+func (cmd *ShowApp) WatchApp(app cf.Application, interval time.Duration, count int, sortSpaceAppsBy string) (err errors.Error) {
+	var previous []cf.AppInstanceFields
+	crashes := map[int]int{}
+
+	for refresh := 0; count == 0 || refresh < count; refresh++ {
+		if refresh > 0 {
+			cmd.ui.Wait(interval)
+		}
+
+		appSummary, apiResponse := cmd.appSummaryRepo.GetSummary(app.Guid)
+		if apiResponse.IsNotFound() {
+			err = cmd.ui.FailWithError(errors.NewNotFoundError(fmt.Sprintf("App %s no longer exists", app.Name)))
+			return
+		}
+
+		appIsStopped := apiResponse.ErrorCode == cf.APP_STOPPED ||
+			apiResponse.ErrorCode == cf.APP_NOT_STAGED ||
+			appSummary.State == "stopped"
+
+		var instances []cf.AppInstanceFields
+		if apiResponse.IsSuccessful() && !appIsStopped {
+			instances, apiResponse = cmd.appInstancesRepo.GetInstances(app.Guid)
+		}
+
+		cmd.ui.ClearScreen()
+		cmd.ui.Say("Every %s: health and status for app %s in org %s / space %s as %s   %s",
+			interval,
+			terminal.EntityNameColor(app.Name),
+			terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+			terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+			terminal.EntityNameColor(cmd.config.Username()),
+			time.Now().Format("15:04:05"),
+		)
+
+		if apiResponse.IsNotSuccessful() && !appIsStopped {
+			cmd.ui.Warn("Could not refresh: %s", apiResponse.Message)
+			continue
+		}
+
+		cmd.displaySummary(appSummary)
+
+		if appIsStopped {
+			cmd.ui.Say("There are no running instances of this app.")
+			previous = nil
+		} else {
+			countCrashes(instances, previous, crashes)
+			cmd.ui.DisplayTable(watchInstancesTable(instances, previous, crashes))
+			previous = instances
+		}
+
+		if sortSpaceAppsBy != "" {
+			cmd.ui.Say("")
+			cmd.displaySpaceApps(sortSpaceAppsBy)
+		}
+	}
+	return
+}
+
+func watchInstancesTable(instances, previous []cf.AppInstanceFields, crashes map[int]int) [][]string {
+	table := [][]string{
+		[]string{"", "state", "since", "cpu", "memory", "disk", "crashes"},
+	}
+
+	now := time.Now()
+	for index, instance := range instances {
+		row := instanceRow(index, instance, instances, now)
+
+		if index < len(previous) {
+			previousRow := instanceRow(index, previous[index], previous, now)
+			if previous[index].State != instance.State {
+				row[1] = row[1] + terminal.AdvisoryColor(fmt.Sprintf(" (was %s)", previous[index].State))
+			}
+			for col := 2; col < len(row); col++ {
+				if row[col] != previousRow[col] {
+					row[col] = terminal.AdvisoryColor(row[col])
+				}
+			}
+		}
+
+		crashCount := fmt.Sprintf("%d", crashes[index])
+		if crashes[index] > 0 {
+			crashCount = terminal.CrashedColor(crashCount)
+		}
+		row = append(row, crashCount)
+
+		table = append(table, row)
+	}
+	return table
+}
+
+// countCrashes adds one to an instance's count each time it is seen going
+// into a crashed state.
+func countCrashes(instances, previous []cf.AppInstanceFields, crashes map[int]int) {
+	for index, instance := range instances {
+		if !isCrashed(instance) {
+			continue
+		}
+		if index < len(previous) && isCrashed(previous[index]) {
+			continue
+		}
+		crashes[index]++
+	}
+}
+
+func isCrashed(instance cf.AppInstanceFields) bool {
+	return instance.State == cf.InstanceFlapping || instance.State == cf.InstanceDown
+}
+
+type appUsage struct {
+	app      cf.AppSummary
+	cpu      float64
+	memory   uint64
+	fetched  bool
+	crashing int
+}
+
+type appUsages []appUsage
+
+func (usages appUsages) Len() int      { return len(usages) }
+func (usages appUsages) Swap(i, j int) { usages[i], usages[j] = usages[j], usages[i] }
+
+type appUsagesByMemory struct{ appUsages }
+
+func (usages appUsagesByMemory) Less(i, j int) bool {
+	return usages.appUsages[i].memory > usages.appUsages[j].memory
+}
+
+type appUsagesByCpu struct{ appUsages }
+
+func (usages appUsagesByCpu) Less(i, j int) bool {
+	return usages.appUsages[i].cpu > usages.appUsages[j].cpu
+}
+
+// displaySpaceApps lists every app in the space with its instances' summed
+// usage, which takes one instances request per started app.
+func (cmd *ShowApp) displaySpaceApps(sortBy string) {
+	apps, apiResponse := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Warn("Could not list apps in space: %s", apiResponse.Message)
+		return
+	}
+
+	usages := appUsages{}
+	for _, app := range apps {
+		usage := appUsage{app: app}
+
+		if app.State == "started" {
+			instances, apiResponse := cmd.appInstancesRepo.GetInstances(app.Guid)
+			if apiResponse.IsSuccessful() {
+				usage.fetched = true
+				for _, instance := range instances {
+					usage.cpu += instance.CpuUsage
+					usage.memory += instance.MemUsage
+					if isCrashed(instance) {
+						usage.crashing++
+					}
+				}
+			}
+		}
+
+		usages = append(usages, usage)
+	}
+
+	if sortBy == SORT_BY_CPU {
+		sort.Stable(appUsagesByCpu{usages})
+	} else {
+		sort.Stable(appUsagesByMemory{usages})
+	}
+
+	table := [][]string{
+		[]string{"app", "state", "instances", "cpu", "memory"},
+	}
+
+	for _, usage := range usages {
+		cpu, memory := "-", "-"
+		if usage.fetched {
+			cpu = fmt.Sprintf("%.1f%%", usage.cpu*100)
+			memory = formatters.ByteSize(usage.memory)
+		}
+
+		instances := coloredAppInstances(usage.app.ApplicationFields)
+		if usage.crashing > 0 {
+			instances = instances + terminal.CrashedColor(fmt.Sprintf(" (%d crashing)", usage.crashing))
+		}
+
+		table = append(table, []string{
+			usage.app.Name,
+			coloredAppState(usage.app.ApplicationFields),
+			instances,
+			cpu,
+			memory,
+		})
+	}
+
+	cmd.ui.DisplayTable(table)
+}
+
+func validWatchFlags(c *cli.Context) bool {
+	if !c.Bool("watch") {
+		return c.Int("interval") == 0 && c.Int("count") == 0 && c.String("space-apps") == ""
+	}
+
+	switch c.String("space-apps") {
+	case "", SORT_BY_MEMORY, SORT_BY_CPU:
+	default:
+		return false
+	}
+
+	return c.Int("interval") >= 0 && c.Int("count") >= 0
+}
+
+func watchInterval(c *cli.Context) time.Duration {
+	if c.Int("interval") == 0 {
+		return DefaultWatchInterval
+	}
+	return time.Duration(c.Int("interval")) * time.Second
+}
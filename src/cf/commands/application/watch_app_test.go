@@ -0,0 +1,137 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"cf/formatters"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+	"time"
+)
+
+func TestAppWatchFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui := callApp(t, []string{"--interval", "5", "my-app"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callApp(t, []string{"--watch", "--space-apps", "disk", "my-app"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callApp(t, []string{"--watch", "--count", "1", "--space-apps", "cpu", "my-app"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestWatchingAnAppRedrawsAndHighlightsChanges(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+
+	appSummary := cf.AppSummary{}
+	appSummary.State = "started"
+	appSummary.InstanceCount = 2
+	appSummary.RunningInstances = 1
+	appSummary.Memory = 256
+
+	since := time.Now().Add(-2 * time.Hour)
+	running := cf.AppInstanceFields{State: cf.InstanceRunning, Since: since, CpuUsage: 0.1, MemUsage: 64 * formatters.MEGABYTE, MemQuota: 256 * formatters.MEGABYTE}
+	busier := cf.AppInstanceFields{State: cf.InstanceRunning, Since: since, CpuUsage: 0.5, MemUsage: 64 * formatters.MEGABYTE, MemQuota: 256 * formatters.MEGABYTE}
+	crashing := cf.AppInstanceFields{State: cf.InstanceFlapping, Since: since}
+
+	appSummaryRepo := &testapi.FakeAppSummaryRepo{GetSummarySummary: appSummary}
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{running, running},
+			[]cf.AppInstanceFields{busier, crashing},
+		},
+	}
+
+	ui, cmd := createShowAppForWatch(t, appSummaryRepo, appInstancesRepo)
+	cmd.WatchApp(app, time.Millisecond, 2, "")
+
+	assert.Equal(t, ui.ClearScreenCount, 2)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Every", "my-app", "my-org", "my-space", "my-user"},
+		{"#0", "running", "10.0%", "0"},
+		{"#1", "running", "10.0%", "0"},
+		{"Every", "my-app"},
+		{"#0", "running", "50.0%", "0"},
+		{"#1", "crashing (was running)", "0.0%", "1"},
+	})
+}
+
+func TestWatchingAnAppWithTheSpaceAppsSortedByMemory(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+
+	appSummary := cf.AppSummary{}
+	appSummary.Name = "my-app"
+	appSummary.Guid = "my-app-guid"
+	appSummary.State = "started"
+	appSummary.InstanceCount = 1
+	appSummary.RunningInstances = 1
+
+	bigApp := cf.AppSummary{}
+	bigApp.Name = "big-app"
+	bigApp.Guid = "big-app-guid"
+	bigApp.State = "started"
+	bigApp.InstanceCount = 2
+	bigApp.RunningInstances = 2
+
+	stoppedApp := cf.AppSummary{}
+	stoppedApp.Name = "stopped-app"
+	stoppedApp.State = "stopped"
+
+	small := cf.AppInstanceFields{State: cf.InstanceRunning, CpuUsage: 0.3, MemUsage: 32 * formatters.MEGABYTE}
+	big := cf.AppInstanceFields{State: cf.InstanceRunning, CpuUsage: 0.1, MemUsage: 512 * formatters.MEGABYTE}
+
+	appSummaryRepo := &testapi.FakeAppSummaryRepo{
+		GetSummarySummary:              appSummary,
+		GetSummariesInCurrentSpaceApps: []cf.AppSummary{appSummary, stoppedApp, bigApp},
+	}
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{small},
+			[]cf.AppInstanceFields{small},
+			[]cf.AppInstanceFields{big, big},
+		},
+	}
+
+	ui, cmd := createShowAppForWatch(t, appSummaryRepo, appInstancesRepo)
+	cmd.WatchApp(app, time.Millisecond, 1, SORT_BY_MEMORY)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"app", "state", "instances", "cpu", "memory"},
+		{"big-app", "started", "2/2", "20.0%", "1G"},
+		{"my-app", "started", "1/1", "30.0%", "32M"},
+		{"stopped-app", "stopped", "-", "-"},
+	})
+}
+
+func createShowAppForWatch(t *testing.T, appSummaryRepo *testapi.FakeAppSummaryRepo, appInstancesRepo *testapi.FakeAppInstancesRepo) (ui *testterm.FakeUI, cmd *ShowApp) {
+	ui = &testterm.FakeUI{}
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd = NewShowApp(ui, config, appSummaryRepo, appInstancesRepo)
+	return
+}
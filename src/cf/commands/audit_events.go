@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"cf"
+	"cf/api"
+	"cf/commands/application"
+	"cf/configuration"
+	"cf/errors"
+	"cf/requirements"
+	"cf/terminal"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultAuditEventsFollowInterval = 5 * time.Second
+
+type AuditEvents struct {
+	ui         terminal.UI
+	config     *configuration.Configuration
+	eventsRepo api.AuditEventsRepository
+	filter     api.AuditEventFilter
+
+	FollowInterval time.Duration
+}
+
+func NewAuditEvents(ui terminal.UI, config *configuration.Configuration, eventsRepo api.AuditEventsRepository) (cmd *AuditEvents) {
+	cmd = new(AuditEvents)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.eventsRepo = eventsRepo
+	cmd.FollowInterval = DefaultAuditEventsFollowInterval
+	return
+}
+
+func (cmd *AuditEvents) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 0 || (c.Bool("follow") && c.String("until") != "") {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "audit-events")
+		return
+	}
+
+	now := time.Now()
+	cmd.filter = api.AuditEventFilter{}
+
+	for _, flag := range []string{"since", "until"} {
+		if c.String(flag) == "" {
+			continue
+		}
+
+		var eventTime time.Time
+		eventTime, err = parseEventTime(c.String(flag), now)
+		if err != nil {
+			cmd.ui.Say("Invalid time for --%s: %s", flag, c.String(flag))
+			cmd.ui.FailWithUsage(c, "audit-events")
+			return
+		}
+
+		if flag == "since" {
+			cmd.filter.Since = eventTime
+		} else {
+			cmd.filter.Until = eventTime
+		}
+	}
+
+	for _, eventType := range strings.Split(c.String("type"), ",") {
+		eventType = strings.TrimSpace(eventType)
+		if eventType != "" {
+			cmd.filter.Types = append(cmd.filter.Types, eventType)
+		}
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+
+	if c.Bool("org") {
+		reqs = append(reqs, reqFactory.NewTargetedOrgRequirement())
+	} else {
+		reqs = append(reqs, reqFactory.NewTargetedSpaceRequirement())
+	}
+	return
+}
+
+func (cmd *AuditEvents) Run(c *cli.Context) (err errors.Error) {
+	filter := cmd.filter
+	scope := fmt.Sprintf("org %s / space %s",
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+	)
+
+	if c.Bool("org") {
+		filter.OrganizationGuid = cmd.config.OrganizationFields.Guid
+		scope = fmt.Sprintf("org %s", terminal.EntityNameColor(cmd.config.OrganizationFields.Name))
+	} else {
+		filter.SpaceGuid = cmd.config.SpaceFields.Guid
+	}
+
+	cmd.ui.Say("Getting audit events in %s as %s...\n",
+		scope,
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	table := cmd.ui.Table([]string{"time", "event", "actor", "target"})
+	matcher := auditEventMatcher{actor: c.String("actor"), target: c.String("target")}
+	seen := map[string]time.Time{}
+
+	eventCount, err := cmd.printEvents(filter, matcher, table, seen)
+	if err != nil {
+		return
+	}
+
+	if !c.Bool("follow") {
+		if eventCount == 0 {
+			cmd.ui.Say("No events found")
+		}
+		return
+	}
+
+	for {
+		cmd.ui.Wait(cmd.FollowInterval)
+
+		filter.Since = latestEventTime(seen, filter.Since)
+		forgetEventsBefore(seen, filter.Since)
+		_, err = cmd.printEvents(filter, matcher, table, seen)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// printEvents prints every event matching the filter that has not been seen
+// before. It records every event it fetches in seen, printed or not, so that
+// following moves past the events the matcher drops.
+func (cmd *AuditEvents) printEvents(filter api.AuditEventFilter, matcher auditEventMatcher, table terminal.Table, seen map[string]time.Time) (count int, err errors.Error) {
+	eventChan, statusChan := cmd.eventsRepo.ListAuditEvents(filter)
+
+	for events := range eventChan {
+		rows := [][]string{}
+		for _, event := range events {
+			if _, found := seen[event.Guid]; found {
+				continue
+			}
+			seen[event.Guid] = event.Timestamp
+
+			if !matcher.matches(event) {
+				continue
+			}
+
+			rows = append(rows, []string{
+				event.Timestamp.Local().Format(application.TIMESTAMP_FORMAT),
+				event.Type,
+				describeEventParty(event.ActorName, event.ActorType, event.ActorGuid),
+				describeEventParty(event.ActeeName, event.ActeeType, event.ActeeGuid),
+			})
+		}
+
+		if len(rows) > 0 {
+			table.Print(rows)
+			count += len(rows)
+		}
+	}
+
+	apiStatus := <-statusChan
+	if apiStatus.IsNotSuccessful() {
+		err = cmd.ui.Failed("Failed fetching events.\n%s", apiStatus.Message)
+	}
+	return
+}
+
+type auditEventMatcher struct {
+	actor  string
+	target string
+}
+
+// matches filters on the actor and target client side, so that they can be
+// given by name as well as by guid.
+func (matcher auditEventMatcher) matches(event cf.AuditEventFields) bool {
+	if matcher.actor != "" && !matchesNameOrGuid(matcher.actor, event.ActorName, event.ActorGuid) {
+		return false
+	}
+	if matcher.target != "" && !matchesNameOrGuid(matcher.target, event.ActeeName, event.ActeeGuid) {
+		return false
+	}
+	return true
+}
+
+func matchesNameOrGuid(value, name, guid string) bool {
+	return strings.EqualFold(value, name) || value == guid
+}
+
+func describeEventParty(name, partyType, guid string) string {
+	if name == "" {
+		name = guid
+	}
+	if partyType == "" {
+		return name
+	}
+	return fmt.Sprintf("%s %s", partyType, name)
+}
+
+// latestEventTime returns the time to poll from next. The Cloud Controller
+// compares timestamps strictly, so it steps back a second from the newest
+// event and relies on seen to drop the events already printed.
+func latestEventTime(seen map[string]time.Time, since time.Time) (latest time.Time) {
+	latest = since
+	for _, timestamp := range seen {
+		if timestamp.Add(-1 * time.Second).After(latest) {
+			latest = timestamp.Add(-1 * time.Second)
+		}
+	}
+	return
+}
+
+// forgetEventsBefore drops the events older than since from seen. Polls only
+// return events after since, so they can't come up again.
+func forgetEventsBefore(seen map[string]time.Time, since time.Time) {
+	for guid, timestamp := range seen {
+		if timestamp.Before(since) {
+			delete(seen, guid)
+		}
+	}
+}
+
+// parseEventTime accepts a timestamp such as 2014-01-21T15:04:05Z or
+// 2014-01-21, or a duration before now such as 90m, 12h or 7d.
+func parseEventTime(value string, now time.Time) (eventTime time.Time, err error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		eventTime, err = time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return
+		}
+	}
+
+	if strings.HasSuffix(value, "d") {
+		var days int
+		days, err = strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err == nil && days >= 0 {
+			eventTime = now.Add(-time.Duration(days) * 24 * time.Hour)
+			return
+		}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		err = errors.New(fmt.Sprintf("Invalid time %s", value))
+		return
+	}
+
+	eventTime = now.Add(-duration)
+	return
+}
@@ -0,0 +1,178 @@
+package commands_test
+
+import (
+	"cf"
+	"cf/api"
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+	"time"
+)
+
+func TestAuditEventsFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	eventsRepo := &testapi.FakeAuditEventsRepo{EventPages: [][]cf.AuditEventFields{{}}}
+
+	ui := callAuditEvents(t, []string{"extra-arg"}, reqFactory, eventsRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callAuditEvents(t, []string{"--follow", "--until", "2h"}, reqFactory, eventsRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callAuditEvents(t, []string{"--since", "yesterday"}, reqFactory, eventsRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callAuditEvents(t, []string{"--since", "2014-01-21"}, reqFactory, eventsRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestAuditEventsRequirements(t *testing.T) {
+	eventsRepo := &testapi.FakeAuditEventsRepo{EventPages: [][]cf.AuditEventFields{{}, {}}}
+
+	callAuditEvents(t, []string{}, &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}, eventsRepo)
+	assert.Equal(t, len(eventsRepo.Filters), 0)
+
+	callAuditEvents(t, []string{"--org"}, &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true}, eventsRepo)
+	assert.Equal(t, len(eventsRepo.Filters), 1)
+	assert.Equal(t, eventsRepo.Filters[0].OrganizationGuid, "my-org-guid")
+	assert.Equal(t, eventsRepo.Filters[0].SpaceGuid, "")
+}
+
+func TestAuditEventsFiltersAndListsEvents(t *testing.T) {
+	timestamp := time.Date(2014, 1, 21, 13, 0, 0, 0, time.UTC)
+	eventsRepo := &testapi.FakeAuditEventsRepo{
+		EventPages: [][]cf.AuditEventFields{{
+			{Guid: "event-1", Type: "app.create", Timestamp: timestamp, ActorName: "admin", ActorType: "user", ActeeName: "my-app", ActeeType: "app"},
+			{Guid: "event-2", Type: "app.update", Timestamp: timestamp, ActorName: "someone-else", ActorType: "user", ActeeName: "my-app", ActeeType: "app"},
+			{Guid: "event-3", Type: "app.update", Timestamp: timestamp, ActorGuid: "admin", ActeeGuid: "other-app-guid", ActeeType: "app"},
+		}},
+	}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	ui := callAuditEvents(t, []string{"--type", "app.create, app.update", "--actor", "admin", "--since", "2014-01-21", "--until", "2h"}, reqFactory, eventsRepo)
+
+	filter := eventsRepo.Filters[0]
+	assert.Equal(t, filter.Types, []string{"app.create", "app.update"})
+	assert.Equal(t, filter.SpaceGuid, "my-space-guid")
+	assert.True(t, filter.Since.Equal(time.Date(2014, 1, 21, 0, 0, 0, 0, time.Local)))
+	assert.True(t, filter.Until.Before(time.Now().Add(-119*time.Minute)))
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Getting audit events in org", "my-org", "my-space", "my-user"},
+		{"time", "event", "actor", "target"},
+		{"app.create", "user admin", "app my-app"},
+		{"app.update", "admin", "app other-app-guid"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"someone-else"},
+	})
+}
+
+func TestAuditEventsWhenThereAreNone(t *testing.T) {
+	eventsRepo := &testapi.FakeAuditEventsRepo{EventPages: [][]cf.AuditEventFields{{}}}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui := callAuditEvents(t, []string{"--target", "my-app"}, reqFactory, eventsRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"No events found"},
+	})
+}
+
+func TestAuditEventsFollowPollsForNewEvents(t *testing.T) {
+	first := time.Date(2014, 1, 21, 13, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+	eventsRepo := &testapi.FakeAuditEventsRepo{
+		EventPages: [][]cf.AuditEventFields{
+			{{Guid: "event-1", Type: "app.create", Timestamp: first}},
+			{{Guid: "event-1", Type: "app.create", Timestamp: first}, {Guid: "event-2", Type: "app.start", Timestamp: second}},
+		},
+	}
+
+	ui := &testterm.FakeUI{}
+	cmd := NewAuditEvents(ui, auditEventsConfig(t), eventsRepo)
+	cmd.FollowInterval = time.Millisecond
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	testcmd.RunCommand(cmd, testcmd.NewContext("audit-events", []string{"--follow"}), reqFactory)
+
+	assert.Equal(t, len(eventsRepo.Filters), 3)
+	assert.True(t, eventsRepo.Filters[0].Since.IsZero())
+	assert.True(t, eventsRepo.Filters[1].Since.Equal(first.Add(-time.Second)))
+	assert.True(t, eventsRepo.Filters[2].Since.Equal(second.Add(-time.Second)))
+
+	eventOneLines := 0
+	for _, output := range ui.Outputs {
+		if strings.Contains(output, "app.create") {
+			eventOneLines++
+		}
+	}
+	assert.Equal(t, eventOneLines, 1)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"app.create"},
+		{"app.start"},
+		{"FAILED"},
+		{"No more events"},
+	})
+}
+
+func TestAuditEventsFollowMovesPastEventsThatDoNotMatch(t *testing.T) {
+	first := time.Date(2014, 1, 21, 13, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+	eventsRepo := &testapi.FakeAuditEventsRepo{
+		EventPages: [][]cf.AuditEventFields{
+			{{Guid: "event-1", Type: "app.create", Timestamp: first, ActorName: "my-user"}},
+			{{Guid: "event-2", Type: "app.start", Timestamp: second, ActorName: "other-user"}},
+			{},
+		},
+	}
+
+	ui := &testterm.FakeUI{}
+	cmd := NewAuditEvents(ui, auditEventsConfig(t), eventsRepo)
+	cmd.FollowInterval = time.Millisecond
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	testcmd.RunCommand(cmd, testcmd.NewContext("audit-events", []string{"--follow", "--actor", "my-user"}), reqFactory)
+
+	assert.Equal(t, len(eventsRepo.Filters), 4)
+	assert.True(t, eventsRepo.Filters[2].Since.Equal(second.Add(-time.Second)))
+	assert.True(t, eventsRepo.Filters[3].Since.Equal(second.Add(-time.Second)))
+
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"app.start"},
+	})
+}
+
+func auditEventsConfig(t *testing.T) *configuration.Configuration {
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	org.Guid = "my-org-guid"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	space.Guid = "my-space-guid"
+
+	return &configuration.Configuration{
+		OrganizationFields: org,
+		SpaceFields:        space,
+		AccessToken:        token,
+	}
+}
+
+func callAuditEvents(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, eventsRepo api.AuditEventsRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+	cmd := NewAuditEvents(ui, auditEventsConfig(t), eventsRepo)
+	testcmd.RunCommand(cmd, testcmd.NewContext("audit-events", args), reqFactory)
+	return
+}
@@ -4,10 +4,10 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -35,7 +35,7 @@ func (cmd Authenticate) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd Authenticate) Run(c *cli.Context) {
+func (cmd Authenticate) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("API endpoint: %s", terminal.EntityNameColor(cmd.config.Target))
 
 	username := c.Args()[0]
@@ -45,7 +45,7 @@ func (cmd Authenticate) Run(c *cli.Context) {
 
 	apiResponse := cmd.doLogin(username, password)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -3,6 +3,7 @@ package buildpack
 import (
 	"cf"
 	"cf/api"
+	"cf/errors"
 	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
@@ -24,18 +25,19 @@ func NewCreateBuildpack(ui terminal.UI, buildpackRepo api.BuildpackRepository, b
 }
 
 func (cmd CreateBuildpack) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 3 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "create-buildpack")
+		return
+	}
+
 	reqs = []requirements.Requirement{
 		reqFactory.NewLoginRequirement(),
 	}
 	return
 }
 
-func (cmd CreateBuildpack) Run(c *cli.Context) {
-	if len(c.Args()) != 3 {
-		cmd.ui.FailWithUsage(c, "create-buildpack")
-		return
-	}
-
+func (cmd CreateBuildpack) Run(c *cli.Context) (err errors.Error) {
 	buildpackName := c.Args()[0]
 
 	cmd.ui.Say("Creating buildpack %s...", terminal.EntityNameColor(buildpackName))
@@ -47,7 +49,7 @@ func (cmd CreateBuildpack) Run(c *cli.Context) {
 			cmd.ui.Warn("Buildpack %s already exists", buildpackName)
 			cmd.ui.Say("TIP: use '%s' to update this buildpack", terminal.CommandColor(cf.Name()+" update-buildpack"))
 		} else {
-			cmd.ui.Failed(apiResponse.Message)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
 		}
 		return
 	}
@@ -58,13 +60,14 @@ func (cmd CreateBuildpack) Run(c *cli.Context) {
 
 	dir := c.Args()[1]
 
-	apiResponse = cmd.buildpackBitsRepo.UploadBuildpack(buildpack, dir)
+	apiResponse = cmd.buildpackBitsRepo.UploadBuildpack(buildpack, dir, terminal.NewProgressBar())
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
 
 func (cmd CreateBuildpack) createBuildpack(buildpackName string, c *cli.Context) (buildpack cf.Buildpack, apiResponse net.ApiResponse) {
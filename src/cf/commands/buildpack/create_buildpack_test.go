@@ -2,7 +2,9 @@ package buildpack_test
 
 import (
 	"cf"
+	"cf/commands"
 	. "cf/commands/buildpack"
+	"cf/errors"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
@@ -17,11 +19,11 @@ func TestCreateBuildpackRequirements(t *testing.T) {
 	repo, bitsRepo := getRepositories()
 
 	repo.FindByNameBuildpack = cf.Buildpack{}
-	callCreateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
+	callCreateBuildpack([]string{"my-buildpack", "my.war", "5"}, reqFactory, repo, bitsRepo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 
 	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false}
-	callCreateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
+	callCreateBuildpack([]string{"my-buildpack", "my.war", "5"}, reqFactory, repo, bitsRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
 }
 
@@ -132,11 +134,38 @@ func TestCreateBuildpackFailsWithUsage(t *testing.T) {
 
 	ui := callCreateBuildpack([]string{}, reqFactory, repo, bitsRepo)
 	assert.True(t, ui.FailedWithUsage)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	ui = callCreateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
+	assert.True(t, ui.FailedWithUsage)
+	assert.False(t, testcmd.CommandDidPassRequirements)
 
 	ui = callCreateBuildpack([]string{"my-buildpack", "my.war", "5"}, reqFactory, repo, bitsRepo)
 	assert.False(t, ui.FailedWithUsage)
 }
 
+func TestCreateBuildpackWithMissingArgsExitsWithUsage(t *testing.T) {
+	repo, bitsRepo := getRepositories()
+	ui := new(testterm.FakeUI)
+	cmd := NewCreateBuildpack(ui, repo, bitsRepo)
+	runner := commands.NewRunner(ui, createBuildpackFactory{cmd}, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	err := runner.RunCmdByName("create-buildpack", testcmd.NewContext("create-buildpack", []string{"my-buildpack"}))
+
+	assert.Error(t, err)
+	assert.Equal(t, runner.ExitCode(), errors.EXIT_USAGE)
+	assert.Equal(t, repo.CreateBuildpack.Name, "")
+}
+
+type createBuildpackFactory struct {
+	cmd CreateBuildpack
+}
+
+func (factory createBuildpackFactory) GetByCmdName(cmdName string) (cmd commands.Command, err error) {
+	cmd = factory.cmd
+	return
+}
+
 func getRepositories() (*testapi.FakeBuildpackRepository, *testapi.FakeBuildpackBitsRepository) {
 	return &testapi.FakeBuildpackRepository{}, &testapi.FakeBuildpackBitsRepository{}
 }
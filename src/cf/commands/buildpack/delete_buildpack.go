@@ -2,9 +2,9 @@ package buildpack
 
 import (
 	"cf/api"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -36,14 +36,15 @@ func (cmd *DeleteBuildpack) GetRequirements(reqFactory requirements.Factory, c *
 	return
 }
 
-func (cmd *DeleteBuildpack) Run(c *cli.Context) {
+func (cmd *DeleteBuildpack) Run(c *cli.Context) (err errors.Error) {
 	buildpackName := c.Args()[0]
 
 	force := c.Bool("f")
 
 	if !force {
-		answer := cmd.ui.Confirm("Are you sure you want to delete the buildpack %s ?", terminal.EntityNameColor(buildpackName))
-		if !answer {
+		var confirmed bool
+		confirmed, err = terminal.Confirm(cmd.ui, "Are you sure you want to delete the buildpack %s ?", terminal.EntityNameColor(buildpackName))
+		if !confirmed {
 			return
 		}
 	}
@@ -59,15 +60,16 @@ func (cmd *DeleteBuildpack) Run(c *cli.Context) {
 	}
 
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	apiResponse = cmd.buildpackRepo.Delete(buildpack.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Error deleting buildpack %s\n%s", terminal.EntityNameColor(buildpack.Name), apiResponse.Message)
+		err = cmd.ui.Failed("Error deleting buildpack %s\n%s", terminal.EntityNameColor(buildpack.Name), apiResponse.Message)
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -3,7 +3,9 @@ package buildpack_test
 import (
 	"cf"
 	. "cf/commands/buildpack"
+	"cf/errors"
 	"cf/net"
+	"cf/terminal"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
@@ -79,6 +81,28 @@ func TestDeleteBuildpackNoConfirmation(t *testing.T) {
 	})
 }
 
+func TestDeleteBuildpackNonInteractivelyWithoutForce(t *testing.T) {
+	terminal.EnableNonInteractive()
+	defer terminal.DisableNonInteractive()
+
+	ui := &testterm.FakeUI{}
+	buildpack := cf.Buildpack{}
+	buildpack.Name = "my-buildpack"
+	buildpack.Guid = "my-buildpack-guid"
+	buildpackRepo := &testapi.FakeBuildpackRepository{
+		FindByNameBuildpack: buildpack,
+	}
+	cmd := NewDeleteBuildpack(ui, buildpackRepo)
+
+	ctxt := testcmd.NewContext("delete-buildpack", []string{"my-buildpack"})
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.Equal(t, len(ui.Prompts), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"non-interactive", "-f"}})
+	assert.Equal(t, testcmd.CommandRunError.ExitCode(), errors.EXIT_USAGE)
+	assert.Equal(t, buildpackRepo.DeleteBuildpackGuid, "")
+}
+
 func TestDeleteBuildpackThatDoesNotExist(t *testing.T) {
 	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
 	buildpack := cf.Buildpack{}
@@ -2,6 +2,7 @@ package buildpack
 
 import (
 	"cf/api"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -26,7 +27,7 @@ func (cmd ListBuildpacks) GetRequirements(reqFactory requirements.Factory, c *cl
 	return
 }
 
-func (cmd ListBuildpacks) Run(c *cli.Context) {
+func (cmd ListBuildpacks) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting buildpacks...\n")
 
 	stopChan := make(chan bool)
@@ -62,11 +63,12 @@ func (cmd ListBuildpacks) Run(c *cli.Context) {
 
 	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
-		cmd.ui.Failed("Failed fetching buildpacks.\n%s", apiStatus.Message)
+		err = cmd.ui.Failed("Failed fetching buildpacks.\n%s", apiStatus.Message)
 		return
 	}
 
 	if noBuildpacks {
 		cmd.ui.Say("No buildpacks found")
 	}
+	return
 }
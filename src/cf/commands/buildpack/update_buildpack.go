@@ -2,9 +2,9 @@ package buildpack
 
 import (
 	"cf/api"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -41,7 +41,7 @@ func (cmd *UpdateBuildpack) GetRequirements(reqFactory requirements.Factory, c *
 	return
 }
 
-func (cmd *UpdateBuildpack) Run(c *cli.Context) {
+func (cmd *UpdateBuildpack) Run(c *cli.Context) (err errors.Error) {
 	buildpack := cmd.buildpackReq.GetBuildpack()
 
 	cmd.ui.Say("Updating buildpack %s...", terminal.EntityNameColor(buildpack.Name))
@@ -58,7 +58,7 @@ func (cmd *UpdateBuildpack) Run(c *cli.Context) {
 	enabled := c.Bool("enable")
 	disabled := c.Bool("disable")
 	if enabled && disabled {
-		cmd.ui.Failed("Cannot specify both enabled and disabled options.")
+		err = cmd.ui.Failed("Cannot specify both enabled and disabled options.")
 		return
 	}
 
@@ -75,18 +75,19 @@ func (cmd *UpdateBuildpack) Run(c *cli.Context) {
 	if updateBuildpack {
 		buildpack, apiResponse := cmd.buildpackRepo.Update(buildpack)
 		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Failed("Error updating buildpack %s\n%s", terminal.EntityNameColor(buildpack.Name), apiResponse.Message)
+			err = cmd.ui.Failed("Error updating buildpack %s\n%s", terminal.EntityNameColor(buildpack.Name), apiResponse.Message)
 			return
 		}
 	}
 
 	dir := c.String("p")
 	if dir != "" {
-		apiResponse := cmd.buildpackBitsRepo.UploadBuildpack(buildpack, dir)
+		apiResponse := cmd.buildpackBitsRepo.UploadBuildpack(buildpack, dir, terminal.NewProgressBar())
 		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Failed("Error uploading buildpack %s\n%s", terminal.EntityNameColor(buildpack.Name), apiResponse.Message)
+			err = cmd.ui.Failed("Error uploading buildpack %s\n%s", terminal.EntityNameColor(buildpack.Name), apiResponse.Message)
 			return
 		}
 	}
 	cmd.ui.Ok()
+	return
 }
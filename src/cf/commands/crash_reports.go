@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"cf/crash"
+	"cf/errors"
+	"cf/formatters"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+	"time"
+)
+
+type CrashReports struct {
+	ui        terminal.UI
+	crashRepo crash.CrashReportRepository
+}
+
+func NewCrashReports(ui terminal.UI, crashRepo crash.CrashReportRepository) (cmd CrashReports) {
+	cmd.ui = ui
+	cmd.crashRepo = crashRepo
+	return
+}
+
+func (cmd CrashReports) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) > 1 || (len(c.Args()) == 0 && len(c.StringSlice("redact")) > 0) {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "crash-reports")
+		return
+	}
+	return
+}
+
+func (cmd CrashReports) Run(c *cli.Context) (err errors.Error) {
+	if len(c.Args()) == 0 {
+		err = cmd.listReports()
+		return
+	}
+
+	reportName := c.Args()[0]
+	secrets := c.StringSlice("redact")
+
+	if len(secrets) == 0 {
+		err = cmd.showReport(reportName)
+		return
+	}
+
+	err = cmd.redactReport(reportName, secrets)
+	return
+}
+
+func (cmd CrashReports) listReports() (err errors.Error) {
+	cmd.ui.Say("Getting crash reports...")
+
+	reports, repoErr := cmd.crashRepo.List()
+	if repoErr != nil {
+		err = cmd.ui.Failed(repoErr.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(reports) == 0 {
+		cmd.ui.Say("No crash reports found")
+		return
+	}
+
+	table := [][]string{
+		[]string{"name", "time", "size", "path"},
+	}
+
+	for _, report := range reports {
+		table = append(table, []string{
+			report.Name,
+			report.Time.Format(time.RFC1123),
+			formatters.ByteSize(uint64(report.Size)),
+			report.Path,
+		})
+	}
+
+	cmd.ui.DisplayTable(table)
+	return
+}
+
+func (cmd CrashReports) showReport(reportName string) (err errors.Error) {
+	contents, repoErr := cmd.crashRepo.Read(reportName)
+	if repoErr != nil {
+		err = cmd.ui.Failed(repoErr.Error())
+		return
+	}
+
+	cmd.ui.Say("%s", contents)
+	return
+}
+
+func (cmd CrashReports) redactReport(reportName string, secrets []string) (err errors.Error) {
+	cmd.ui.Say("Redacting crash report %s...", terminal.EntityNameColor(reportName))
+
+	repoErr := cmd.crashRepo.Redact(reportName, secrets)
+	if repoErr != nil {
+		err = cmd.ui.Failed(repoErr.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+	return
+}
@@ -0,0 +1,99 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/crash"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testcrash "testhelpers/crash"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+	"time"
+)
+
+func TestCrashReportsFailsWithUsage(t *testing.T) {
+	crashRepo := &testcrash.FakeCrashReportRepository{}
+
+	ui := callCrashReports([]string{"report-1", "report-2"}, crashRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCrashReports([]string{"--redact", "secret"}, crashRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCrashReports([]string{}, crashRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestCrashReportsListsReports(t *testing.T) {
+	crashRepo := &testcrash.FakeCrashReportRepository{
+		Reports: []crash.ReportInfo{
+			{Name: "crash-20140202-030405.log", Path: "/home/me/.cf/crash/crash-20140202-030405.log", Time: time.Now(), Size: 2048},
+			{Name: "crash-20140102-030405.log", Path: "/home/me/.cf/crash/crash-20140102-030405.log", Time: time.Now(), Size: 1024},
+		},
+	}
+
+	ui := callCrashReports([]string{}, crashRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Getting crash reports"},
+		{"OK"},
+		{"name", "time", "size", "path"},
+		{"crash-20140202-030405.log", "2K", "/home/me/.cf/crash/crash-20140202-030405.log"},
+		{"crash-20140102-030405.log", "1K", "/home/me/.cf/crash/crash-20140102-030405.log"},
+	})
+}
+
+func TestCrashReportsWhenThereAreNone(t *testing.T) {
+	ui := callCrashReports([]string{}, &testcrash.FakeCrashReportRepository{})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+		{"No crash reports found"},
+	})
+}
+
+func TestCrashReportsShowsAReport(t *testing.T) {
+	crashRepo := &testcrash.FakeCrashReportRepository{ReadContents: "the stack trace"}
+
+	ui := callCrashReports([]string{"crash-20140102-030405.log"}, crashRepo)
+
+	assert.Equal(t, crashRepo.ReadName, "crash-20140102-030405.log")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"the stack trace"},
+	})
+}
+
+func TestCrashReportsRedactsAReport(t *testing.T) {
+	crashRepo := &testcrash.FakeCrashReportRepository{}
+
+	ui := callCrashReports([]string{"--redact", "my-company", "--redact", "my-app", "crash-20140102-030405.log"}, crashRepo)
+
+	assert.Equal(t, crashRepo.RedactedName, "crash-20140102-030405.log")
+	assert.Equal(t, crashRepo.RedactedSecrets, []string{"my-company", "my-app"})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Redacting crash report", "crash-20140102-030405.log"},
+		{"OK"},
+	})
+}
+
+func TestCrashReportsWhenRedactingFails(t *testing.T) {
+	crashRepo := &testcrash.FakeCrashReportRepository{RedactErr: errors.New("no such report")}
+
+	ui := callCrashReports([]string{"--redact", "my-app", "crash-20140102-030405.log"}, crashRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"no such report"},
+	})
+}
+
+func callCrashReports(args []string, crashRepo *testcrash.FakeCrashReportRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+	ctxt := testcmd.NewContext("crash-reports", args)
+	cmd := NewCrashReports(ui, crashRepo)
+	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	return
+}
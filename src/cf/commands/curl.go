@@ -3,10 +3,10 @@ package commands
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"cf/trace"
-	"errors"
 	"github.com/codegangsta/cli"
 	"strings"
 )
@@ -38,7 +38,7 @@ func (cmd *Curl) GetRequirements(reqFactory requirements.Factory, c *cli.Context
 	return
 }
 
-func (cmd *Curl) Run(c *cli.Context) {
+func (cmd *Curl) Run(c *cli.Context) (err errors.Error) {
 	path := c.Args()[0]
 	method := c.String("X")
 	headers := c.StringSlice("H")
@@ -53,7 +53,7 @@ func (cmd *Curl) Run(c *cli.Context) {
 
 	respHeader, respBody, apiResponse := cmd.curlRepo.Request(method, path, reqHeader, body)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Error creating request:\n%s", apiResponse.Message)
+		err = cmd.ui.Failed("Error creating request:\n%s", apiResponse.Message)
 		return
 	}
 
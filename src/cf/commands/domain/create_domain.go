@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -40,7 +40,7 @@ func (cmd *CreateDomain) GetRequirements(reqFactory requirements.Factory, c *cli
 	return
 }
 
-func (cmd *CreateDomain) Run(c *cli.Context) {
+func (cmd *CreateDomain) Run(c *cli.Context) (err errors.Error) {
 	domainName := c.Args()[1]
 	owningOrg := cmd.orgReq.GetOrganization()
 
@@ -52,11 +52,12 @@ func (cmd *CreateDomain) Run(c *cli.Context) {
 
 	_, apiResponse := cmd.domainRepo.Create(domainName, owningOrg.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 
 	cmd.ui.Say("TIP: Use '%s' to assign it to a space", terminal.CommandColor(cf.Name()+" map-domain"))
+	return
 }
@@ -3,9 +3,9 @@ package domain
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -42,7 +42,7 @@ func (cmd *DeleteDomain) GetRequirements(reqFactory requirements.Factory, c *cli
 	return
 }
 
-func (cmd *DeleteDomain) Run(c *cli.Context) {
+func (cmd *DeleteDomain) Run(c *cli.Context) (err errors.Error) {
 	domainName := c.Args()[0]
 	force := c.Bool("f")
 
@@ -53,7 +53,7 @@ func (cmd *DeleteDomain) Run(c *cli.Context) {
 
 	domain, apiResponse := cmd.domainRepo.FindByNameInOrg(domainName, cmd.orgReq.GetOrganizationFields().Guid)
 	if apiResponse.IsError() {
-		cmd.ui.Failed("Error finding domain %s\n%s", domainName, apiResponse.Message)
+		err = cmd.ui.Failed("Error finding domain %s\n%s", domainName, apiResponse.Message)
 		return
 	}
 	if apiResponse.IsNotFound() {
@@ -62,24 +62,31 @@ func (cmd *DeleteDomain) Run(c *cli.Context) {
 		return
 	}
 
-	if !force {
-		var answer bool
+	var confirmed bool
+	if cmd.config.IsOrganizationProtected(cmd.orgReq.GetOrganizationFields().Name) {
+		cascades := []string{"all routes on this domain, leaving apps mapped to them unreachable"}
+		confirmed, err = terminal.ConfirmProtectedDeletion(cmd.ui, "domain", domainName, cascades, force)
+		if !confirmed {
+			return
+		}
+	} else if !force {
 		if domain.Shared {
-			answer = cmd.ui.Confirm("This domain is shared across all orgs.\nDeleting it will remove all associated routes, and will make any app with this domain unreachable.\nAre you sure you want to delete the domain %s? ", domainName)
+			confirmed, err = terminal.Confirm(cmd.ui, "This domain is shared across all orgs.\nDeleting it will remove all associated routes, and will make any app with this domain unreachable.\nAre you sure you want to delete the domain %s? ", domainName)
 		} else {
-			answer = cmd.ui.Confirm("Are you sure you want to delete the domain %s and all of its associations?", domainName)
+			confirmed, err = terminal.Confirm(cmd.ui, "Are you sure you want to delete the domain %s and all of its associations?", domainName)
 		}
 
-		if !answer {
+		if !confirmed {
 			return
 		}
 	}
 
 	apiResponse = cmd.domainRepo.Delete(domain.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Error deleting domain %s\n%s", domainName, apiResponse.Message)
+		err = cmd.ui.Failed("Error deleting domain %s\n%s", domainName, apiResponse.Message)
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
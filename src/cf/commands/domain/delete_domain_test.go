@@ -179,6 +179,47 @@ func TestDeleteDomainForceFlagSkipsConfirmation(t *testing.T) {
 	})
 }
 
+func TestDeleteDomainInProtectedOrg(t *testing.T) {
+	domain := cf.Domain{}
+	domain.Name = "foo.com"
+	domain.Guid = "foo-guid"
+	orgFields := cf.OrganizationFields{}
+	orgFields.Name = "prod-org"
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true, OrganizationFields: orgFields}
+
+	domainRepo := &testapi.FakeDomainRepository{FindByNameInOrgDomain: domain}
+	ui := callDeleteDomainInProtectedOrg([]string{"foo.com"}, "y", reqFactory, domainRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"foo.com", "is protected"},
+		{"routes"},
+		{"did not match"},
+	})
+	assert.Equal(t, domainRepo.DeleteDomainGuid, "")
+
+	ui = callDeleteDomainInProtectedOrg([]string{"foo.com"}, "foo.com", reqFactory, domainRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+	})
+	assert.Equal(t, domainRepo.DeleteDomainGuid, "foo-guid")
+
+	domainRepo = &testapi.FakeDomainRepository{FindByNameInOrgDomain: domain}
+	ui = callDeleteDomainInProtectedOrg([]string{"-f", "foo.com"}, "", reqFactory, domainRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"foo.com", "protected", "-f"},
+	})
+	assert.Equal(t, domainRepo.DeleteDomainGuid, "")
+}
+
+func callDeleteDomainInProtectedOrg(args []string, confirmation string, reqFactory *testreq.FakeReqFactory, domainRepo *testapi.FakeDomainRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{Inputs: []string{confirmation}}
+	config := &configuration.Configuration{ProtectedOrganizations: []string{"prod-org"}}
+
+	cmd := domain.NewDeleteDomain(ui, config, domainRepo)
+	testcmd.RunCommand(cmd, testcmd.NewContext("delete-domain", args), reqFactory)
+	return
+}
+
 func callDeleteDomain(t *testing.T, args []string, inputs []string, reqFactory *testreq.FakeReqFactory, domainRepo *testapi.FakeDomainRepository) (ui *testterm.FakeUI) {
 	ctxt := testcmd.NewContext("delete-domain", args)
 	ui = &testterm.FakeUI{
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -40,7 +40,7 @@ func (cmd *ListDomains) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd *ListDomains) Run(c *cli.Context) {
+func (cmd *ListDomains) Run(c *cli.Context) (err errors.Error) {
 	org := cmd.orgReq.GetOrganizationFields()
 
 	cmd.ui.Say("Getting domains in org %s as %s...",
@@ -54,13 +54,14 @@ func (cmd *ListDomains) Run(c *cli.Context) {
 	apiResponse = cmd.domainRepo.ListDomainsForOrg(org.Guid, domainsCallback("owned", table, &noDomains))
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Failed fetching domains.\n%s", apiResponse.Message)
+		err = cmd.ui.Failed("Failed fetching domains.\n%s", apiResponse.Message)
 		return
 	}
 
 	if noDomains {
 		cmd.ui.Say("No domains found")
 	}
+	return
 }
 
 func domainsCallback(status string, table terminal.Table, noDomains *bool) api.ListDomainsCallback {
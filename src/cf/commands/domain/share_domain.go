@@ -3,9 +3,9 @@ package domain
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -37,7 +37,7 @@ func (cmd *ShareDomain) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd *ShareDomain) Run(c *cli.Context) {
+func (cmd *ShareDomain) Run(c *cli.Context) (err errors.Error) {
 	domainName := c.Args()[0]
 
 	cmd.ui.Say("Sharing domain %s as %s...",
@@ -47,9 +47,10 @@ func (cmd *ShareDomain) Run(c *cli.Context) {
 
 	apiResponse := cmd.domainRepo.CreateSharedDomain(domainName)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -4,7 +4,7 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
-	"cf/net"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -47,32 +47,28 @@ func (cmd Login) GetRequirements(reqFactory requirements.Factory, c *cli.Context
 	return
 }
 
-func (cmd Login) Run(c *cli.Context) {
+func (cmd Login) Run(c *cli.Context) (err errors.Error) {
 	oldUserName := cmd.config.Username()
 
-	apiResponse := cmd.setApi(c)
-	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Invalid API endpoint.\n%s", apiResponse.Message)
+	err = cmd.setApi(c)
+	if err != nil {
 		return
 	}
 
-	apiResponse = cmd.authenticate(c)
-	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Unable to authenticate.")
+	err = cmd.authenticate(c)
+	if err != nil {
 		return
 	}
 
 	userChanged := (cmd.config.Username() != oldUserName && oldUserName != "")
 
-	apiResponse = cmd.setOrganization(c, userChanged)
-	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+	err = cmd.setOrganization(c, userChanged)
+	if err != nil {
 		return
 	}
 
-	apiResponse = cmd.setSpace(c, userChanged)
-	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+	err = cmd.setSpace(c, userChanged)
+	if err != nil {
 		return
 	}
 
@@ -80,14 +76,17 @@ func (cmd Login) Run(c *cli.Context) {
 	return
 }
 
-func (cmd Login) setApi(c *cli.Context) (apiResponse net.ApiResponse) {
+func (cmd Login) setApi(c *cli.Context) (err errors.Error) {
 	api := c.String("a")
 	if api == "" {
 		api = cmd.config.Target
 	}
 
 	if api == "" {
-		api = cmd.ui.Ask("API endpoint%s", terminal.PromptColor(">"))
+		api, err = terminal.PromptFor(cmd.ui, "API endpoint", "-a")
+		if err != nil {
+			return
+		}
 	} else {
 		cmd.ui.Say("API endpoint: %s", terminal.EntityNameColor(api))
 	}
@@ -98,45 +97,56 @@ func (cmd Login) setApi(c *cli.Context) (apiResponse net.ApiResponse) {
 		cmd.ui.Say(terminal.WarningColor("Warning: Insecure http API endpoint detected: secure https API endpoints are recommended\n"))
 	}
 
+	if apiResponse.IsNotSuccessful() {
+		err = cmd.ui.Failed("Invalid API endpoint.\n%s", apiResponse.Message)
+	}
 	return
 }
 
-func (cmd Login) authenticate(c *cli.Context) (apiResponse net.ApiResponse) {
+func (cmd Login) authenticate(c *cli.Context) (err errors.Error) {
 	username := c.String("u")
 	if username == "" {
-		username = cmd.ui.Ask("Username%s", terminal.PromptColor(">"))
+		username, err = terminal.PromptFor(cmd.ui, "Username", "-u")
+		if err != nil {
+			return
+		}
 	}
 
 	password := c.String("p")
 
 	for i := 0; i < maxLoginTries; i++ {
 		if password == "" || i > 0 {
-			password = cmd.ui.AskForPassword("Password%s", terminal.PromptColor(">"))
+			password, err = terminal.PromptForPassword(cmd.ui, "Password", "-p")
+			if err != nil {
+				return
+			}
 		}
 
 		cmd.ui.Say("Authenticating...")
 
-		apiResponse = cmd.authenticator.Authenticate(username, password)
+		apiResponse := cmd.authenticator.Authenticate(username, password)
 		if apiResponse.IsSuccessful() {
 			cmd.ui.Ok()
 			cmd.ui.Say("")
-			break
+			return
 		}
 
 		cmd.ui.Say(apiResponse.Message)
 	}
+
+	err = cmd.ui.Failed("Unable to authenticate.")
 	return
 }
 
-func (cmd Login) setOrganization(c *cli.Context, userChanged bool) (apiResponse net.ApiResponse) {
+func (cmd Login) setOrganization(c *cli.Context, userChanged bool) (err errors.Error) {
 	orgName := c.String("o")
 
 	if orgName == "" {
 		// If the user is changing, clear out the org
 		if userChanged {
-			err := cmd.configRepo.SetOrganization(cf.OrganizationFields{})
-			if err != nil {
-				apiResponse = net.NewApiResponseWithError("%s", err)
+			configErr := cmd.configRepo.SetOrganization(cf.OrganizationFields{})
+			if configErr != nil {
+				err = cmd.ui.FailWithError(configErr)
 				return
 			}
 		}
@@ -161,9 +171,9 @@ func (cmd Login) setOrganization(c *cli.Context, userChanged bool) (apiResponse
 			}
 		}
 
-		apiResponse = <-statusChan
+		apiResponse := <-statusChan
 		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Failed("Error finding avilable orgs\n%s", apiResponse.Message)
+			err = cmd.ui.Failed("Error finding avilable orgs\n%s", apiResponse.Message)
 			return
 		}
 
@@ -172,34 +182,37 @@ func (cmd Login) setOrganization(c *cli.Context, userChanged bool) (apiResponse
 			return cmd.targetOrganization(availableOrgs[0])
 		}
 
-		orgName = cmd.promptForOrgName(availableOrgs)
+		orgName, err = cmd.promptForOrgName(availableOrgs)
+		if err != nil {
+			return
+		}
 	}
 
 	// Find org
 	org, apiResponse := cmd.orgRepo.FindByName(orgName)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Error finding org %s\n%s", terminal.EntityNameColor(orgName), apiResponse.Message)
+		err = cmd.ui.Failed("Error finding org %s\n%s", terminal.EntityNameColor(orgName), apiResponse.Message)
 		return
 	}
 
 	return cmd.targetOrganization(org)
 }
 
-func (cmd Login) promptForOrgName(orgs []cf.Organization) string {
+func (cmd Login) promptForOrgName(orgs []cf.Organization) (string, errors.Error) {
 	orgNames := []string{}
 	for _, org := range orgs {
 		orgNames = append(orgNames, org.Name)
 	}
 
-	return cmd.promptForName(orgNames, "Select an org:", "Org")
+	return cmd.promptForName(orgNames, "Select an org:", "Org", "-o")
 }
 
-func (cmd Login) targetOrganization(org cf.Organization) (apiResponse net.ApiResponse) {
-	err := cmd.configRepo.SetOrganization(org.OrganizationFields)
-	if err != nil {
-		apiResponse = net.NewApiResponseWithMessage("Error setting org %s in config file\n%s",
+func (cmd Login) targetOrganization(org cf.Organization) (err errors.Error) {
+	configErr := cmd.configRepo.SetOrganization(org.OrganizationFields)
+	if configErr != nil {
+		err = cmd.ui.Failed("Error setting org %s in config file\n%s",
 			terminal.EntityNameColor(org.Name),
-			err.Error(),
+			configErr.Error(),
 		)
 		return
 	}
@@ -208,15 +221,15 @@ func (cmd Login) targetOrganization(org cf.Organization) (apiResponse net.ApiRes
 	return
 }
 
-func (cmd Login) setSpace(c *cli.Context, userChanged bool) (apiResponse net.ApiResponse) {
+func (cmd Login) setSpace(c *cli.Context, userChanged bool) (err errors.Error) {
 	spaceName := c.String("s")
 
 	if spaceName == "" {
 		// If user is changing, clear the space
 		if userChanged {
-			err := cmd.configRepo.SetSpace(cf.SpaceFields{})
-			if err != nil {
-				apiResponse = net.NewApiResponseWithError("%s", err)
+			configErr := cmd.configRepo.SetSpace(cf.SpaceFields{})
+			if configErr != nil {
+				err = cmd.ui.FailWithError(configErr)
 				return
 			}
 		}
@@ -240,9 +253,9 @@ func (cmd Login) setSpace(c *cli.Context, userChanged bool) (apiResponse net.Api
 			}
 		}
 
-		apiResponse = <-statusChan
+		apiResponse := <-statusChan
 		if apiResponse.IsNotSuccessful() {
-			cmd.ui.Failed("Error finding avilable spaces\n%s", apiResponse.Message)
+			err = cmd.ui.Failed("Error finding avilable spaces\n%s", apiResponse.Message)
 			return
 		}
 
@@ -251,34 +264,37 @@ func (cmd Login) setSpace(c *cli.Context, userChanged bool) (apiResponse net.Api
 			return cmd.targetSpace(availableSpaces[0])
 		}
 
-		spaceName = cmd.promptForSpaceName(availableSpaces)
+		spaceName, err = cmd.promptForSpaceName(availableSpaces)
+		if err != nil {
+			return
+		}
 	}
 
 	// Find space
 	space, apiResponse := cmd.spaceRepo.FindByName(spaceName)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Error finding space %s\n%s", terminal.EntityNameColor(spaceName), apiResponse.Message)
+		err = cmd.ui.Failed("Error finding space %s\n%s", terminal.EntityNameColor(spaceName), apiResponse.Message)
 		return
 	}
 
 	return cmd.targetSpace(space)
 }
 
-func (cmd Login) promptForSpaceName(spaces []cf.Space) string {
+func (cmd Login) promptForSpaceName(spaces []cf.Space) (string, errors.Error) {
 	spaceNames := []string{}
 	for _, space := range spaces {
 		spaceNames = append(spaceNames, space.Name)
 	}
 
-	return cmd.promptForName(spaceNames, "Select a space:", "Space")
+	return cmd.promptForName(spaceNames, "Select a space:", "Space", "-s")
 }
 
-func (cmd Login) targetSpace(space cf.Space) (apiResponse net.ApiResponse) {
-	err := cmd.configRepo.SetSpace(space.SpaceFields)
-	if err != nil {
-		apiResponse = net.NewApiResponseWithMessage("Error setting space %s in config file\n%s",
+func (cmd Login) targetSpace(space cf.Space) (err errors.Error) {
+	configErr := cmd.configRepo.SetSpace(space.SpaceFields)
+	if configErr != nil {
+		err = cmd.ui.Failed("Error setting space %s in config file\n%s",
 			terminal.EntityNameColor(space.Name),
-			err.Error(),
+			configErr.Error(),
 		)
 		return
 	}
@@ -287,11 +303,12 @@ func (cmd Login) targetSpace(space cf.Space) (apiResponse net.ApiResponse) {
 	return
 }
 
-func (cmd Login) promptForName(names []string, listPrompt, itemPrompt string) string {
+func (cmd Login) promptForName(names []string, listPrompt, itemPrompt, flag string) (string, errors.Error) {
 	nameIndex := 0
 	var nameString string
 	for nameIndex < 1 || nameIndex > len(names) {
 		var err error
+		var promptErr errors.Error
 
 		// list header
 		cmd.ui.Say(listPrompt)
@@ -305,14 +322,17 @@ func (cmd Login) promptForName(names []string, listPrompt, itemPrompt string) st
 			cmd.ui.Say("There are too many options to display, please type in the name.")
 		}
 
-		nameString = cmd.ui.Ask("%s%s", itemPrompt, terminal.PromptColor(">"))
+		nameString, promptErr = terminal.PromptFor(cmd.ui, itemPrompt, flag)
+		if promptErr != nil {
+			return "", promptErr
+		}
 		nameIndex, err = strconv.Atoi(nameString)
 
 		if err != nil {
 			nameIndex = 1
-			return nameString
+			return nameString, nil
 		}
 	}
 
-	return names[nameIndex-1]
+	return names[nameIndex-1], nil
 }
@@ -4,7 +4,9 @@ import (
 	"cf"
 	. "cf/commands"
 	"cf/configuration"
+	"cf/errors"
 	"cf/net"
+	"cf/terminal"
 	"github.com/stretchr/testify/assert"
 	"strconv"
 	testapi "testhelpers/api"
@@ -398,6 +400,44 @@ func TestSuccessfullyLoggingInWithOnlyOneSpace(t *testing.T) {
 	assert.True(t, c.ui.ShowConfigurationCalled)
 }
 
+func TestLoggingInNonInteractivelyFailsNamingTheMissingFlag(t *testing.T) {
+	terminal.EnableNonInteractive()
+	defer terminal.DisableNonInteractive()
+
+	c := LoginTestContext{
+		Flags: []string{"-a", "api.example.com", "-u", "user@example.com"},
+	}
+	callLogin(t, &c, defaultBeforeBlock)
+
+	assert.Equal(t, len(c.ui.Prompts), 0)
+	assert.Equal(t, len(c.ui.PasswordPrompts), 0)
+	testassert.SliceContains(t, c.ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Cannot prompt for Password", "non-interactive", "-p"},
+	})
+	assert.Equal(t, c.ui.FailedWithError.ExitCode(), errors.EXIT_USAGE)
+}
+
+func TestLoggingInNonInteractivelyFailsWhenAnOrgMustBeSelected(t *testing.T) {
+	terminal.EnableNonInteractive()
+	defer terminal.DisableNonInteractive()
+
+	c := LoginTestContext{
+		Flags: []string{"-a", "api.example.com", "-u", "user@example.com", "-p", "password"},
+	}
+	callLogin(t, &c, func(c *LoginTestContext) {
+		org1 := cf.Organization{}
+		org1.Name = "some-org"
+		org2 := cf.Organization{}
+		org2.Name = "my-org"
+		c.orgRepo.Organizations = []cf.Organization{org1, org2}
+	})
+
+	testassert.SliceContains(t, c.ui.Outputs, testassert.Lines{
+		{"Cannot prompt for Org", "non-interactive", "-o"},
+	})
+}
+
 func TestUnsuccessfullyLoggingInWithAuthError(t *testing.T) {
 	c := LoginTestContext{
 		Flags:  []string{"-u", "user@example.com"},
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -22,14 +23,15 @@ func (cmd Logout) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 	return
 }
 
-func (cmd Logout) Run(c *cli.Context) {
+func (cmd Logout) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Logging out...")
-	err := cmd.configRepo.ClearSession()
+	configErr := cmd.configRepo.ClearSession()
 
-	if err != nil {
-		cmd.ui.Failed(err.Error())
+	if configErr != nil {
+		err = cmd.ui.Failed(configErr.Error())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -36,7 +36,7 @@ func (cmd CreateOrg) GetRequirements(reqFactory requirements.Factory, c *cli.Con
 	return
 }
 
-func (cmd CreateOrg) Run(c *cli.Context) {
+func (cmd CreateOrg) Run(c *cli.Context) (err errors.Error) {
 	name := c.Args()[0]
 
 	cmd.ui.Say("Creating org %s as %s...",
@@ -51,10 +51,11 @@ func (cmd CreateOrg) Run(c *cli.Context) {
 			return
 		}
 
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 	cmd.ui.Say("\nTIP: Use '%s' to target new org", terminal.CommandColor(cf.Name()+" target -o "+name))
+	return
 }
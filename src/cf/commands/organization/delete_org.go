@@ -4,9 +4,10 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -37,19 +38,25 @@ func (cmd *DeleteOrg) GetRequirements(reqFactory requirements.Factory, c *cli.Co
 	return
 }
 
-func (cmd *DeleteOrg) Run(c *cli.Context) {
+func (cmd *DeleteOrg) Run(c *cli.Context) (err errors.Error) {
 	orgName := c.Args()[0]
 
 	force := c.Bool("f")
 
-	if !force {
-		response := cmd.ui.Confirm(
+	var confirmed bool
+	if cmd.config.IsOrganizationProtected(orgName) {
+		confirmed, err = cmd.confirmProtected(orgName, force)
+		if !confirmed {
+			return
+		}
+	} else if !force {
+		confirmed, err = terminal.Confirm(cmd.ui,
 			"Really delete org %s and everything associated with it?%s",
 			terminal.EntityNameColor(orgName),
 			terminal.PromptColor(">"),
 		)
 
-		if !response {
+		if !confirmed {
 			return
 		}
 	}
@@ -62,7 +69,7 @@ func (cmd *DeleteOrg) Run(c *cli.Context) {
 	org, apiResponse := cmd.orgRepo.FindByName(orgName)
 
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -74,16 +81,16 @@ func (cmd *DeleteOrg) Run(c *cli.Context) {
 
 	apiResponse = cmd.orgRepo.Delete(org.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
-	config, err := cmd.configRepo.Get()
-	if err != nil {
-		cmd.ui.Failed("Couldn't reset your target. You should logout and log in again.")
+	config, configErr := cmd.configRepo.Get()
+	if configErr != nil {
+		err = cmd.ui.Failed("Couldn't reset your target. You should logout and log in again.")
 		return
 	}
 
-	if org.Guid == config.OrganizationFields.Guid {
+	if org.Guid == config.OrganizationFields.Guid && !net.IsDryRun() {
 		config.OrganizationFields = cf.OrganizationFields{}
 		config.SpaceFields = cf.SpaceFields{}
 		cmd.configRepo.Save()
@@ -92,3 +99,35 @@ func (cmd *DeleteOrg) Run(c *cli.Context) {
 	cmd.ui.Ok()
 	return
 }
+
+func (cmd *DeleteOrg) confirmProtected(orgName string, force bool) (confirmed bool, err errors.Error) {
+	cascades := []string{}
+	org, apiResponse := cmd.orgRepo.FindByName(orgName)
+	if apiResponse.IsSuccessful() {
+		spaceNames := []string{}
+		for _, space := range org.Spaces {
+			spaceNames = append(spaceNames, space.Name)
+		}
+		domainNames := []string{}
+		for _, domain := range org.Domains {
+			domainNames = append(domainNames, domain.Name)
+		}
+		cascades = append(cascades,
+			terminal.CascadeLine("spaces", spaceNames),
+			terminal.CascadeLine("domains", domainNames),
+		)
+
+		counts, countResponse := cmd.orgRepo.CountResources(org.Guid)
+		if countResponse.IsSuccessful() {
+			cascades = append(cascades,
+				terminal.CascadeCount("apps", counts.Apps),
+				terminal.CascadeCount("service instances", counts.ServiceInstances),
+				terminal.CascadeCount("routes", counts.Routes),
+			)
+		} else {
+			cascades = append(cascades, "apps, service instances and routes: could not be counted")
+		}
+	}
+
+	return terminal.ConfirmProtectedDeletion(cmd.ui, "org", orgName, cascades, force)
+}
@@ -147,6 +147,80 @@ func TestDeleteOrgWhenOrgDoesNotExist(t *testing.T) {
 	assert.Equal(t, orgRepo.FindByNameName, "org-to-delete")
 }
 
+func TestDeleteProtectedOrgRequiresTypingTheName(t *testing.T) {
+	org := cf.Organization{}
+	org.Name = "org-to-delete"
+	org.Guid = "org-to-delete-guid"
+	space := cf.SpaceFields{}
+	space.Name = "production"
+	org.Spaces = []cf.SpaceFields{space}
+	orgRepo := &testapi.FakeOrgRepository{
+		FindByNameOrganization: org,
+		CountResourcesCounts:   cf.OrganizationResourceCounts{Apps: 4, ServiceInstances: 2, Routes: 5},
+	}
+
+	ui := deleteProtectedOrg(t, "y", []string{"org-to-delete"}, orgRepo)
+	assert.Equal(t, orgRepo.CountResourcesOrgGuid, "org-to-delete-guid")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"org-to-delete", "is protected"},
+		{"spaces", "production"},
+		{"apps: 4"},
+		{"service instances: 2"},
+		{"routes: 5"},
+		{"did not match"},
+	})
+	testassert.SliceContains(t, ui.Prompts, testassert.Lines{
+		{"Type the name of the org"},
+	})
+	assert.Equal(t, orgRepo.DeletedOrganizationGuid, "")
+
+	ui = deleteProtectedOrg(t, "org-to-delete", []string{"org-to-delete"}, orgRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Deleting", "org-to-delete"},
+		{"OK"},
+	})
+	assert.Equal(t, orgRepo.DeletedOrganizationGuid, "org-to-delete-guid")
+}
+
+func TestDeleteProtectedOrgWhenTheResourcesCannotBeCounted(t *testing.T) {
+	org := cf.Organization{}
+	org.Name = "org-to-delete"
+	org.Guid = "org-to-delete-guid"
+	orgRepo := &testapi.FakeOrgRepository{FindByNameOrganization: org, CountResourcesErr: true}
+
+	ui := deleteProtectedOrg(t, "y", []string{"org-to-delete"}, orgRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"org-to-delete", "is protected"},
+		{"apps, service instances and routes", "could not be counted"},
+		{"did not match"},
+	})
+	assert.Equal(t, orgRepo.DeletedOrganizationGuid, "")
+}
+
+func TestDeleteProtectedOrgRefusesForceOption(t *testing.T) {
+	org := cf.Organization{}
+	org.Name = "org-to-delete"
+	org.Guid = "org-to-delete-guid"
+	orgRepo := &testapi.FakeOrgRepository{FindByNameOrganization: org}
+
+	ui := deleteProtectedOrg(t, "", []string{"-f", "org-to-delete"}, orgRepo)
+	assert.Equal(t, len(ui.Prompts), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"org-to-delete", "protected", "-f"},
+	})
+	assert.Equal(t, orgRepo.DeletedOrganizationGuid, "")
+}
+
+func deleteProtectedOrg(t *testing.T, confirmation string, args []string, orgRepo *testapi.FakeOrgRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{Inputs: []string{confirmation}}
+	config := &configuration.Configuration{ProtectedOrganizations: []string{"org-to-delete"}}
+
+	cmd := NewDeleteOrg(ui, config, orgRepo, &testconfig.FakeConfigRepository{})
+	testcmd.RunCommand(cmd, testcmd.NewContext("delete-org", args), &testreq.FakeReqFactory{})
+	return
+}
+
 func deleteOrg(t *testing.T, confirmation string, args []string, orgRepo *testapi.FakeOrgRepository) (ui *testterm.FakeUI) {
 	reqFactory := &testreq.FakeReqFactory{}
 	configRepo := &testconfig.FakeConfigRepository{}
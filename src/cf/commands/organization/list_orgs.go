@@ -3,6 +3,7 @@ package organization
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -28,7 +29,7 @@ func (cmd ListOrgs) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 	return
 }
 
-func (cmd ListOrgs) Run(c *cli.Context) {
+func (cmd ListOrgs) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting orgs as %s...\n", terminal.EntityNameColor(cmd.config.Username()))
 
 	stopChan := make(chan bool)
@@ -50,11 +51,12 @@ func (cmd ListOrgs) Run(c *cli.Context) {
 
 	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
-		cmd.ui.Failed("Failed fetching orgs.\n%s", apiStatus.Message)
+		err = cmd.ui.Failed("Failed fetching orgs.\n%s", apiStatus.Message)
 		return
 	}
 
 	if noOrgs {
 		cmd.ui.Say("No orgs found")
 	}
+	return
 }
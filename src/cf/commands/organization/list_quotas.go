@@ -3,6 +3,7 @@ package organization
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/formatters"
 	"cf/requirements"
 	"cf/terminal"
@@ -30,13 +31,13 @@ func (cmd *ListQuotas) GetRequirements(reqFactory requirements.Factory, c *cli.C
 	return
 }
 
-func (cmd *ListQuotas) Run(c *cli.Context) {
+func (cmd *ListQuotas) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting quotas as %s...", terminal.EntityNameColor(cmd.config.Username()))
 
 	quotas, apiResponse := cmd.quotaRepo.FindAll()
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 	cmd.ui.Ok()
@@ -54,4 +55,5 @@ func (cmd *ListQuotas) Run(c *cli.Context) {
 	}
 
 	cmd.ui.DisplayTable(table)
+	return
 }
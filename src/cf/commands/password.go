@@ -3,6 +3,8 @@ package commands
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -28,13 +30,22 @@ func (cmd Password) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 	return
 }
 
-func (cmd Password) Run(c *cli.Context) {
-	oldPassword := cmd.ui.AskForPassword("Current Password%s", terminal.PromptColor(">"))
-	newPassword := cmd.ui.AskForPassword("New Password%s", terminal.PromptColor(">"))
-	verifiedPassword := cmd.ui.AskForPassword("Verify Password%s", terminal.PromptColor(">"))
+func (cmd Password) Run(c *cli.Context) (err errors.Error) {
+	oldPassword, err := terminal.PromptForPassword(cmd.ui, "Current Password", "")
+	if err != nil {
+		return
+	}
+	newPassword, err := terminal.PromptForPassword(cmd.ui, "New Password", "")
+	if err != nil {
+		return
+	}
+	verifiedPassword, err := terminal.PromptForPassword(cmd.ui, "Verify Password", "")
+	if err != nil {
+		return
+	}
 
 	if verifiedPassword != newPassword {
-		cmd.ui.Failed("Password verification does not match")
+		err = cmd.ui.Failed("Password verification does not match")
 		return
 	}
 
@@ -43,15 +54,20 @@ func (cmd Password) Run(c *cli.Context) {
 
 	if apiResponse.IsNotSuccessful() {
 		if apiResponse.StatusCode == 401 {
-			cmd.ui.Failed("Current password did not match")
+			err = cmd.ui.Failed("Current password did not match")
 		} else {
-			cmd.ui.Failed(apiResponse.Message)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
 		}
 		return
 	}
 
 	cmd.ui.Ok()
 
+	if net.IsDryRun() {
+		return
+	}
+
 	cmd.configRepo.ClearSession()
 	cmd.ui.Say("Please log in again")
+	return
 }
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"cf"
+	"cf/configuration"
+	"cf/errors"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+)
+
+type Protect struct {
+	ui         terminal.UI
+	configRepo configuration.ConfigurationRepository
+}
+
+func NewProtect(ui terminal.UI, configRepo configuration.ConfigurationRepository) (cmd Protect) {
+	cmd.ui = ui
+	cmd.configRepo = configRepo
+	return
+}
+
+func (cmd Protect) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "protect")
+		return
+	}
+	return
+}
+
+func (cmd Protect) Run(c *cli.Context) (err errors.Error) {
+	config, configErr := cmd.configRepo.Get()
+	if configErr != nil {
+		err = cmd.ui.Failed(configErr.Error())
+		return
+	}
+
+	orgName := c.String("o")
+	spaceName := c.String("s")
+	protected := !c.Bool("remove")
+
+	if !c.Bool("api") && orgName == "" && spaceName == "" {
+		cmd.showProtected(config)
+		return
+	}
+
+	if c.Bool("api") {
+		if config.Target == "" {
+			err = cmd.ui.Failed("No api endpoint targeted, use '%s' to target one", terminal.CommandColor(cf.Name()+" api"))
+			return
+		}
+		config.SetTargetProtected(protected)
+		cmd.sayProtected("api endpoint", config.Target, protected)
+	}
+
+	if spaceName != "" {
+		if orgName == "" {
+			if !config.HasOrganization() {
+				err = cmd.ui.Failed("No org targeted, use '-o ORG' to name the org of space %s", spaceName)
+				return
+			}
+			orgName = config.OrganizationFields.Name
+		}
+		config.SetSpaceProtected(orgName, spaceName, protected)
+		cmd.sayProtected("space", orgName+"/"+spaceName, protected)
+	} else if orgName != "" {
+		config.SetOrganizationProtected(orgName, protected)
+		cmd.sayProtected("org", orgName, protected)
+	}
+
+	saveErr := cmd.configRepo.Save()
+	if saveErr != nil {
+		err = cmd.ui.Failed(saveErr.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+	return
+}
+
+func (cmd Protect) sayProtected(resourceType, name string, protected bool) {
+	if protected {
+		cmd.ui.Say("Protecting %s %s...", resourceType, terminal.EntityNameColor(name))
+	} else {
+		cmd.ui.Say("Removing protection from %s %s...", resourceType, terminal.EntityNameColor(name))
+	}
+}
+
+func (cmd Protect) showProtected(config *configuration.Configuration) {
+	cmd.ui.Say(terminal.CascadeLine("Protected API endpoints", config.ProtectedTargets))
+	cmd.ui.Say(terminal.CascadeLine("Protected orgs", config.ProtectedOrganizations))
+	cmd.ui.Say(terminal.CascadeLine("Protected spaces", config.ProtectedSpaces))
+}
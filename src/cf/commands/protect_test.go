@@ -0,0 +1,108 @@
+package commands_test
+
+import (
+	"cf"
+	. "cf/commands"
+	"github.com/stretchr/testify/assert"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestProtectFailsWithUsage(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+
+	ui := callProtect([]string{"prod-org"}, configRepo)
+	assert.True(t, ui.FailedWithUsage)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestProtectListsWhatIsProtected(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	config, _ := configRepo.Get()
+	config.ProtectedOrganizations = []string{"prod-org"}
+	config.ProtectedSpaces = []string{"dev-org/production"}
+
+	ui := callProtect([]string{}, configRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Protected API endpoints", "none"},
+		{"Protected orgs", "prod-org"},
+		{"Protected spaces", "dev-org/production"},
+	})
+}
+
+func TestProtectAnOrg(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+
+	ui := callProtect([]string{"-o", "prod-org"}, configRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Protecting org", "prod-org"},
+		{"OK"},
+	})
+	assert.Equal(t, testconfig.SavedConfiguration.ProtectedOrganizations, []string{"prod-org"})
+	assert.Empty(t, testconfig.SavedConfiguration.ProtectedSpaces)
+}
+
+func TestProtectASpaceInTheTargetedOrg(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	config, _ := configRepo.Get()
+	config.OrganizationFields = cf.OrganizationFields{}
+	config.OrganizationFields.Name = "dev-org"
+	config.OrganizationFields.Guid = "dev-org-guid"
+
+	ui := callProtect([]string{"-s", "production"}, configRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Protecting space", "dev-org/production"},
+		{"OK"},
+	})
+	assert.Equal(t, testconfig.SavedConfiguration.ProtectedSpaces, []string{"dev-org/production"})
+	assert.Empty(t, testconfig.SavedConfiguration.ProtectedOrganizations)
+}
+
+func TestProtectASpaceWithoutAnOrg(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+
+	ui := callProtect([]string{"-s", "production"}, configRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"No org targeted", "-o ORG"},
+	})
+	assert.Empty(t, testconfig.SavedConfiguration.ProtectedSpaces)
+}
+
+func TestProtectTheTargetedApi(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+
+	callProtect([]string{"--api"}, configRepo)
+	assert.Equal(t, testconfig.SavedConfiguration.ProtectedTargets, []string{"https://api.run.pivotal.io"})
+}
+
+func TestProtectWithRemove(t *testing.T) {
+	configRepo := &testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	config, _ := configRepo.Get()
+	config.ProtectedOrganizations = []string{"prod-org", "other-org"}
+
+	ui := callProtect([]string{"--remove", "-o", "prod-org"}, configRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Removing protection from org", "prod-org"},
+		{"OK"},
+	})
+	assert.Equal(t, testconfig.SavedConfiguration.ProtectedOrganizations, []string{"other-org"})
+}
+
+func callProtect(args []string, configRepo *testconfig.FakeConfigRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewProtect(ui, configRepo)
+	testcmd.RunCommand(cmd, testcmd.NewContext("protect", args), &testreq.FakeReqFactory{})
+	return
+}
@@ -4,10 +4,10 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -54,16 +54,17 @@ func (cmd *CreateRoute) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd *CreateRoute) Run(c *cli.Context) {
+func (cmd *CreateRoute) Run(c *cli.Context) (err errors.Error) {
 	hostName := c.String("n")
 	space := cmd.spaceReq.GetSpace()
 	domain := cmd.domainReq.GetDomain()
 
 	_, apiResponse := cmd.CreateRoute(hostName, domain.DomainFields, space.SpaceFields)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
+	return
 }
 
 func (cmd *CreateRoute) CreateRoute(hostName string, domain cf.DomainFields, space cf.SpaceFields) (route cf.Route, apiResponse net.ApiResponse) {
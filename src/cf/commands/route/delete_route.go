@@ -3,9 +3,9 @@ package route
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -37,7 +37,7 @@ func (cmd *DeleteRoute) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd *DeleteRoute) Run(c *cli.Context) {
+func (cmd *DeleteRoute) Run(c *cli.Context) (err errors.Error) {
 	host := c.String("n")
 	domainName := c.Args()[0]
 
@@ -47,13 +47,14 @@ func (cmd *DeleteRoute) Run(c *cli.Context) {
 	}
 	force := c.Bool("f")
 	if !force {
-		response := cmd.ui.Confirm(
+		var confirmed bool
+		confirmed, err = terminal.Confirm(cmd.ui,
 			"Really delete route %s?%s",
 			terminal.EntityNameColor(url),
 			terminal.PromptColor(">"),
 		)
 
-		if !response {
+		if !confirmed {
 			return
 		}
 	}
@@ -62,7 +63,7 @@ func (cmd *DeleteRoute) Run(c *cli.Context) {
 
 	route, apiResponse := cmd.routeRepo.FindByHostAndDomain(host, domainName)
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 	if apiResponse.IsNotFound() {
@@ -73,9 +74,10 @@ func (cmd *DeleteRoute) Run(c *cli.Context) {
 
 	apiResponse = cmd.routeRepo.Delete(route.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -4,6 +4,8 @@ import (
 	"cf"
 	. "cf/commands/route"
 	"cf/configuration"
+	"cf/errors"
+	"cf/terminal"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
@@ -65,6 +67,22 @@ func TestDeleteRouteWithConfirmation(t *testing.T) {
 	assert.Equal(t, routeRepo.DeleteRouteGuid, "route-guid")
 }
 
+func TestDeleteRouteNonInteractivelyWithoutForce(t *testing.T) {
+	terminal.EnableNonInteractive()
+	defer terminal.DisableNonInteractive()
+
+	route := cf.Route{}
+	route.Guid = "route-guid"
+	routeRepo := &testapi.FakeRouteRepository{FindByHostAndDomainRoute: route}
+
+	ui := callDeleteRoute(t, "", []string{"-n", "my-host", "example.com"}, &testreq.FakeReqFactory{LoginSuccess: true}, routeRepo)
+
+	assert.Equal(t, len(ui.Prompts), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"non-interactive", "-f"}})
+	assert.Equal(t, testcmd.CommandRunError.ExitCode(), errors.EXIT_USAGE)
+	assert.Equal(t, routeRepo.DeleteRouteGuid, "")
+}
+
 func TestDeleteRouteWithForce(t *testing.T) {
 	domain := cf.DomainFields{}
 	domain.Guid = "domain-guid"
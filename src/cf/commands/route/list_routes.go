@@ -3,6 +3,7 @@ package route
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -27,7 +28,7 @@ func (cmd ListRoutes) GetRequirements(reqFactory requirements.Factory, c *cli.Co
 	return
 }
 
-func (cmd ListRoutes) Run(c *cli.Context) {
+func (cmd ListRoutes) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting routes as %s ...\n",
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
@@ -60,11 +61,12 @@ func (cmd ListRoutes) Run(c *cli.Context) {
 
 	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
-		cmd.ui.Failed("Failed fetching routes.\n%s", apiStatus.Message)
+		err = cmd.ui.Failed("Failed fetching routes.\n%s", apiStatus.Message)
 		return
 	}
 
 	if noRoutes {
 		cmd.ui.Say("No routes found")
 	}
+	return
 }
@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"cf/errors"
+	"cf/net"
 	"cf/requirements"
-	"errors"
+	"cf/terminal"
 	"fmt"
 	"github.com/codegangsta/cli"
 	"os"
@@ -10,7 +12,7 @@ import (
 
 type Command interface {
 	GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error)
-	Run(c *cli.Context)
+	Run(c *cli.Context) (err errors.Error)
 }
 
 type Runner interface {
@@ -18,37 +20,82 @@ type Runner interface {
 }
 
 type ConcreteRunner struct {
+	ui         terminal.UI
 	cmdFactory Factory
 	reqFactory requirements.Factory
+	lastError  error
 }
 
-func NewRunner(cmdFactory Factory, reqFactory requirements.Factory) (runner ConcreteRunner) {
+func NewRunner(ui terminal.UI, cmdFactory Factory, reqFactory requirements.Factory) (runner *ConcreteRunner) {
+	runner = new(ConcreteRunner)
+	runner.ui = ui
 	runner.cmdFactory = cmdFactory
 	runner.reqFactory = reqFactory
 	return
 }
 
-func (runner ConcreteRunner) RunCmdByName(cmdName string, c *cli.Context) (err error) {
+// RunCmdByName runs the command and returns the errors.Error it failed with,
+// if any. Commands print their failure through the UI before returning it.
+// GetRequirements only fails on incorrect usage.
+func (runner *ConcreteRunner) RunCmdByName(cmdName string, c *cli.Context) (err error) {
+	defer func() {
+		runner.lastError = err
+	}()
+
 	cmd, err := runner.cmdFactory.GetByCmdName(cmdName)
 	if err != nil {
-		fmt.Printf("Error finding command %s\n", cmdName)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "Error finding command %s\n", cmdName)
+		err = errors.NewUsageError(err.Error())
 		return
 	}
 
+	if c.GlobalBool("non-interactive") {
+		terminal.EnableNonInteractive()
+	}
+
+	if c.GlobalBool("dry-run") {
+		net.EnableDryRun()
+		defer runner.showDryRunSummary()
+	}
+
 	requirements, err := cmd.GetRequirements(runner.reqFactory, c)
 	if err != nil {
+		err = errors.NewUsageError(err.Error())
 		return
 	}
 
 	for _, requirement := range requirements {
-		success := requirement.Execute()
-		if !success {
-			err = errors.New("Error in requirement")
+		reqErr := requirement.Execute()
+		if reqErr != nil {
+			err = reqErr
 			return
 		}
 	}
 
-	cmd.Run(c)
+	cmdErr := cmd.Run(c)
+	if cmdErr != nil {
+		err = cmdErr
+	}
 	return
 }
+
+// ExitCode maps the error of the last command run to the exit code cf
+// terminates with.
+func (runner *ConcreteRunner) ExitCode() int {
+	return errors.ExitCode(runner.lastError)
+}
+
+func (runner *ConcreteRunner) showDryRunSummary() {
+	requests := net.DryRunRequests()
+
+	runner.ui.Say("")
+	if len(requests) == 0 {
+		runner.ui.Say(terminal.WarningColor("Dry run: no changes would have been made."))
+		return
+	}
+
+	runner.ui.Say(terminal.WarningColor(fmt.Sprintf("Dry run: the following %d change(s) were not made:", len(requests))))
+	for _, request := range requests {
+		runner.ui.Say("  %s %s", request.Method, request.Url)
+	}
+}
@@ -2,10 +2,17 @@ package commands_test
 
 import (
 	. "cf/commands"
+	"cf/errors"
+	"cf/net"
 	"cf/requirements"
+	"cf/terminal"
+	goerrors "errors"
+	"flag"
 	"github.com/codegangsta/cli"
 	"github.com/stretchr/testify/assert"
+	testassert "testhelpers/assert"
 	testcmd "testhelpers/commands"
+	testterm "testhelpers/terminal"
 	"testing"
 )
 
@@ -30,8 +37,9 @@ func (cmd *TestCommand) GetRequirements(factory requirements.Factory, c *cli.Con
 	return
 }
 
-func (cmd *TestCommand) Run(c *cli.Context) {
+func (cmd *TestCommand) Run(c *cli.Context) (err errors.Error) {
 	cmd.WasRunWith = c
+	return
 }
 
 type TestRequirement struct {
@@ -39,14 +47,14 @@ type TestRequirement struct {
 	WasExecuted bool
 }
 
-func (r *TestRequirement) Execute() (success bool) {
+func (r *TestRequirement) Execute() (err errors.Error) {
 	r.WasExecuted = true
 
 	if !r.Passes {
-		return false
+		err = errors.NewNotLoggedInError("Not logged in.")
 	}
 
-	return true
+	return
 }
 
 func TestRun(t *testing.T) {
@@ -59,7 +67,7 @@ func TestRun(t *testing.T) {
 	}
 
 	cmdFactory := &TestCommandFactory{Cmd: &cmd}
-	runner := NewRunner(cmdFactory, nil)
+	runner := NewRunner(&testterm.FakeUI{}, cmdFactory, nil)
 
 	ctxt := testcmd.NewContext("login", []string{})
 
@@ -74,4 +82,96 @@ func TestRun(t *testing.T) {
 	assert.Nil(t, cmd.WasRunWith)
 
 	assert.Error(t, err)
+	assert.Equal(t, runner.ExitCode(), errors.EXIT_NOT_LOGGED_IN)
+}
+
+func TestRunWithDryRunPrintsASummary(t *testing.T) {
+	cmd := TestCommand{}
+	cmdFactory := &TestCommandFactory{Cmd: &cmd}
+	ui := &testterm.FakeUI{}
+	runner := NewRunner(ui, cmdFactory, nil)
+
+	globalSet := flag.NewFlagSet("global", flag.ContinueOnError)
+	globalSet.Bool("dry-run", true, "")
+	ctxt := cli.NewContext(cli.NewApp(), flag.NewFlagSet("some-cmd", flag.ContinueOnError), globalSet)
+
+	defer net.DisableDryRun()
+	err := runner.RunCmdByName("some-cmd", ctxt)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd.WasRunWith)
+	assert.True(t, net.IsDryRun())
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Dry run", "no changes"},
+	})
+}
+
+func TestRunWithNonInteractive(t *testing.T) {
+	cmd := TestCommand{}
+	runner := NewRunner(&testterm.FakeUI{}, &TestCommandFactory{Cmd: &cmd}, nil)
+
+	globalSet := flag.NewFlagSet("global", flag.ContinueOnError)
+	globalSet.Bool("non-interactive", true, "")
+	ctxt := cli.NewContext(cli.NewApp(), flag.NewFlagSet("some-cmd", flag.ContinueOnError), globalSet)
+
+	defer terminal.DisableNonInteractive()
+	err := runner.RunCmdByName("some-cmd", ctxt)
+
+	assert.NoError(t, err)
+	assert.True(t, terminal.IsNonInteractive())
+}
+
+func TestRunWithoutDryRun(t *testing.T) {
+	cmd := TestCommand{}
+	cmdFactory := &TestCommandFactory{Cmd: &cmd}
+	ui := &testterm.FakeUI{}
+	runner := NewRunner(ui, cmdFactory, nil)
+
+	err := runner.RunCmdByName("some-cmd", testcmd.NewContext("login", []string{}))
+
+	assert.NoError(t, err)
+	assert.False(t, net.IsDryRun())
+	assert.Equal(t, len(ui.Outputs), 0)
+}
+
+type FailingCommand struct {
+	TestCommand
+	Err errors.Error
+}
+
+func (cmd *FailingCommand) Run(c *cli.Context) (err errors.Error) {
+	return cmd.Err
+}
+
+func TestRunReturnsTheErrorTheCommandFailedWith(t *testing.T) {
+	cmd := &FailingCommand{Err: errors.NewNotFoundError("App my-app not found")}
+	runner := NewRunner(&testterm.FakeUI{}, &TestCommandFactory{Cmd: cmd}, nil)
+
+	err := runner.RunCmdByName("some-cmd", testcmd.NewContext("login", []string{}))
+
+	assert.Equal(t, err, cmd.Err)
+	assert.Equal(t, runner.ExitCode(), errors.EXIT_NOT_FOUND)
+
+	runner = NewRunner(&testterm.FakeUI{}, &TestCommandFactory{Cmd: &TestCommand{}}, nil)
+	runner.RunCmdByName("some-cmd", testcmd.NewContext("login", []string{}))
+	assert.Equal(t, runner.ExitCode(), 0)
+}
+
+type IncorrectUsageCommand struct {
+	TestCommand
+}
+
+func (cmd *IncorrectUsageCommand) GetRequirements(factory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	err = goerrors.New("Incorrect Usage")
+	return
+}
+
+func TestRunMapsIncorrectUsageToTheUsageExitCode(t *testing.T) {
+	cmd := &IncorrectUsageCommand{}
+	runner := NewRunner(&testterm.FakeUI{}, &TestCommandFactory{Cmd: cmd}, nil)
+
+	runner.RunCmdByName("some-cmd", testcmd.NewContext("login", []string{}))
+
+	assert.Nil(t, cmd.WasRunWith)
+	assert.Equal(t, runner.ExitCode(), errors.EXIT_USAGE)
 }
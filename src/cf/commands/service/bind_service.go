@@ -4,10 +4,10 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -50,7 +50,7 @@ func (cmd *BindService) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd *BindService) Run(c *cli.Context) {
+func (cmd *BindService) Run(c *cli.Context) (err errors.Error) {
 	app := cmd.appReq.GetApplication()
 	serviceInstance := cmd.serviceInstanceReq.GetServiceInstance()
 
@@ -64,7 +64,8 @@ func (cmd *BindService) Run(c *cli.Context) {
 
 	apiResponse := cmd.BindApplication(app, serviceInstance)
 	if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode != AppAlreadyBoundErrorCode {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
 	cmd.ui.Ok()
@@ -75,6 +76,7 @@ func (cmd *BindService) Run(c *cli.Context) {
 	}
 
 	cmd.ui.Say("TIP: Use '%s push' to ensure your env variable changes take effect", cf.Name())
+	return
 }
 
 func (cmd *BindService) BindApplication(app cf.Application, serviceInstance cf.ServiceInstance) (apiResponse net.ApiResponse) {
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
 )
@@ -34,7 +34,7 @@ func (cmd CreateService) GetRequirements(reqFactory requirements.Factory, c *cli
 	return
 }
 
-func (cmd CreateService) Run(c *cli.Context) {
+func (cmd CreateService) Run(c *cli.Context) (err errors.Error) {
 	offeringName := c.Args()[0]
 	planName := c.Args()[1]
 	name := c.Args()[2]
@@ -48,26 +48,20 @@ func (cmd CreateService) Run(c *cli.Context) {
 
 	offerings, apiResponse := cmd.serviceRepo.GetServiceOfferings()
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
-	offering, err := findOffering(offerings, offeringName)
-	if err != nil {
-		cmd.ui.Failed(err.Error())
-		return
-	}
-
-	plan, err := findPlan(offering.Plans, planName)
-	if err != nil {
-		cmd.ui.Failed(err.Error())
+	plan, planErr := FindServicePlan(offerings, offeringName, planName)
+	if planErr != nil {
+		err = cmd.ui.Failed(planErr.Error())
 		return
 	}
 
 	var identicalAlreadyExists bool
 	identicalAlreadyExists, apiResponse = cmd.serviceRepo.CreateServiceInstance(name, plan.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -76,6 +70,18 @@ func (cmd CreateService) Run(c *cli.Context) {
 	if identicalAlreadyExists {
 		cmd.ui.Warn("Service %s already exists", name)
 	}
+	return
+}
+
+// FindServicePlan finds the plan called planName of the offering labelled
+// offeringName.
+func FindServicePlan(offerings []cf.ServiceOffering, offeringName, planName string) (plan cf.ServicePlanFields, err error) {
+	offering, err := findOffering(offerings, offeringName)
+	if err != nil {
+		return
+	}
+
+	return findPlan(offering.Plans, planName)
 }
 
 func findOffering(offerings []cf.ServiceOffering, name string) (offering cf.ServiceOffering, err error) {
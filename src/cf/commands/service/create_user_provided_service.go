@@ -3,10 +3,10 @@ package service
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"encoding/json"
-	"errors"
 	"github.com/codegangsta/cli"
 	"strings"
 )
@@ -34,7 +34,7 @@ func (cmd CreateUserProvidedService) GetRequirements(reqFactory requirements.Fac
 	return
 }
 
-func (cmd CreateUserProvidedService) Run(c *cli.Context) {
+func (cmd CreateUserProvidedService) Run(c *cli.Context) (err errors.Error) {
 	name := c.Args()[0]
 	drainUrl := c.String("l")
 
@@ -42,9 +42,12 @@ func (cmd CreateUserProvidedService) Run(c *cli.Context) {
 	params = strings.Trim(params, `"`)
 	paramsMap := make(map[string]string)
 
-	err := json.Unmarshal([]byte(params), &paramsMap)
-	if err != nil && params != "" {
-		paramsMap = cmd.mapValuesFromPrompt(params, paramsMap)
+	jsonErr := json.Unmarshal([]byte(params), &paramsMap)
+	if jsonErr != nil && params != "" {
+		paramsMap, err = cmd.mapValuesFromPrompt(params, paramsMap)
+		if err != nil {
+			return
+		}
 	}
 
 	cmd.ui.Say("Creating user provided service %s in org %s / space %s as %s...",
@@ -56,17 +59,22 @@ func (cmd CreateUserProvidedService) Run(c *cli.Context) {
 
 	apiResponse := cmd.userProvidedServiceInstanceRepo.Create(name, drainUrl, paramsMap)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
 
-func (cmd CreateUserProvidedService) mapValuesFromPrompt(params string, paramsMap map[string]string) map[string]string {
+func (cmd CreateUserProvidedService) mapValuesFromPrompt(params string, paramsMap map[string]string) (map[string]string, errors.Error) {
 	for _, param := range strings.Split(params, ",") {
 		param = strings.Trim(param, " ")
-		paramsMap[param] = cmd.ui.Ask("%s%s", param, terminal.PromptColor(">"))
+		value, err := terminal.PromptFor(cmd.ui, param, `-p '{"`+param+`":"VALUE"}'`)
+		if err != nil {
+			return nil, err
+		}
+		paramsMap[param] = value
 	}
-	return paramsMap
+	return paramsMap, nil
 }
@@ -5,6 +5,7 @@ import (
 	"cf/api"
 	. "cf/commands/service"
 	"cf/configuration"
+	"cf/terminal"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
@@ -42,6 +43,24 @@ func TestCreateUserProvidedServiceWithParameterList(t *testing.T) {
 	})
 }
 
+func TestCreateUserProvidedServiceWithParameterListInNonInteractiveMode(t *testing.T) {
+	terminal.EnableNonInteractive()
+	defer terminal.DisableNonInteractive()
+
+	repo := &testapi.FakeUserProvidedServiceInstanceRepo{}
+	ui := callCreateUserProvidedService(t,
+		[]string{"-p", `"foo"`, "my-custom-service"},
+		[]string{},
+		repo,
+	)
+
+	assert.Equal(t, len(ui.Prompts), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Cannot prompt for foo", "non-interactive", `-p '{"foo":"VALUE"}'`},
+	})
+}
+
 func TestCreateUserProvidedServiceWithJson(t *testing.T) {
 	repo := &testapi.FakeUserProvidedServiceInstanceRepo{}
 	ui := callCreateUserProvidedService(t,
@@ -3,9 +3,9 @@ package service
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -40,13 +40,19 @@ func (cmd *DeleteService) GetRequirements(reqFactory requirements.Factory, c *cl
 	return
 }
 
-func (cmd *DeleteService) Run(c *cli.Context) {
+func (cmd *DeleteService) Run(c *cli.Context) (err errors.Error) {
 	serviceName := c.Args()[0]
 	force := c.Bool("f")
 
-	if !force {
-		answer := cmd.ui.Confirm("Are you sure you want to delete the service %s ?", terminal.EntityNameColor(serviceName))
-		if !answer {
+	var confirmed bool
+	if cmd.config.IsSpaceProtected(cmd.config.OrganizationFields.Name, cmd.config.SpaceFields.Name) {
+		confirmed, err = cmd.confirmProtected(serviceName, force)
+		if !confirmed {
+			return
+		}
+	} else if !force {
+		confirmed, err = terminal.Confirm(cmd.ui, "Are you sure you want to delete the service %s ?", terminal.EntityNameColor(serviceName))
+		if !confirmed {
 			return
 		}
 	}
@@ -61,7 +67,7 @@ func (cmd *DeleteService) Run(c *cli.Context) {
 	instance, apiResponse := cmd.serviceRepo.FindInstanceByName(serviceName)
 
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -73,9 +79,20 @@ func (cmd *DeleteService) Run(c *cli.Context) {
 
 	apiResponse = cmd.serviceRepo.DeleteService(instance)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
+}
+
+func (cmd *DeleteService) confirmProtected(serviceName string, force bool) (confirmed bool, err errors.Error) {
+	cascades := []string{}
+	instance, apiResponse := cmd.serviceRepo.FindInstanceByName(serviceName)
+	if apiResponse.IsSuccessful() {
+		cascades = append(cascades, terminal.CascadeLine("bindings to apps", instance.ApplicationNames))
+	}
+
+	return terminal.ConfirmProtectedDeletion(cmd.ui, "service", serviceName, cascades, force)
 }
@@ -89,6 +89,56 @@ func TestDeleteServiceForceFlagSkipsConfirmation(t *testing.T) {
 	})
 }
 
+func TestDeleteServiceInProtectedSpace(t *testing.T) {
+	serviceInstance := cf.ServiceInstance{}
+	serviceInstance.Name = "my-service"
+	serviceInstance.Guid = "my-service-guid"
+	serviceInstance.ApplicationNames = []string{"app1", "app2"}
+
+	serviceRepo := &testapi.FakeServiceRepo{FindInstanceByNameServiceInstance: serviceInstance}
+	ui := callDeleteServiceInProtectedSpace("y", []string{"my-service"}, serviceRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"my-service", "is protected"},
+		{"bindings", "app1, app2"},
+		{"did not match"},
+	})
+	assert.Equal(t, serviceRepo.DeleteServiceServiceInstance, cf.ServiceInstance{})
+
+	ui = callDeleteServiceInProtectedSpace("my-service", []string{"my-service"}, serviceRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Deleting service", "my-service"},
+		{"OK"},
+	})
+	assert.Equal(t, serviceRepo.DeleteServiceServiceInstance, serviceInstance)
+
+	serviceRepo = &testapi.FakeServiceRepo{FindInstanceByNameServiceInstance: serviceInstance}
+	ui = callDeleteServiceInProtectedSpace("", []string{"-f", "my-service"}, serviceRepo)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"my-service", "protected", "-f"},
+	})
+	assert.Equal(t, serviceRepo.DeleteServiceServiceInstance, cf.ServiceInstance{})
+}
+
+func callDeleteServiceInProtectedSpace(confirmation string, args []string, serviceRepo api.ServiceRepository) (fakeUI *testterm.FakeUI) {
+	fakeUI = &testterm.FakeUI{Inputs: []string{confirmation}}
+
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		ProtectedTargets:   []string{"https://api.example.com"},
+		Target:             "https://api.example.com",
+	}
+
+	cmd := NewDeleteService(fakeUI, config, serviceRepo)
+	testcmd.RunCommand(cmd, testcmd.NewContext("delete-service", args), &testreq.FakeReqFactory{})
+	return
+}
+
 func callDeleteService(t *testing.T, confirmation string, args []string, reqFactory *testreq.FakeReqFactory, serviceRepo api.ServiceRepository) (fakeUI *testterm.FakeUI) {
 	fakeUI = &testterm.FakeUI{
 		Inputs: []string{confirmation},
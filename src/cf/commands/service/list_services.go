@@ -3,6 +3,7 @@ package service
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -26,7 +27,7 @@ func (cmd ListServices) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd ListServices) Run(c *cli.Context) {
+func (cmd ListServices) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting services in org %s / space %s as %s...",
 		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
 		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
@@ -36,7 +37,7 @@ func (cmd ListServices) Run(c *cli.Context) {
 	serviceInstances, apiResponse := cmd.serviceSummaryRepo.GetSummariesInCurrentSpace()
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -70,4 +71,5 @@ func (cmd ListServices) Run(c *cli.Context) {
 	}
 
 	cmd.ui.DisplayTable(table)
+	return
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -27,7 +28,7 @@ func (cmd MarketplaceServices) GetRequirements(reqFactory requirements.Factory,
 	return
 }
 
-func (cmd MarketplaceServices) Run(c *cli.Context) {
+func (cmd MarketplaceServices) Run(c *cli.Context) (err errors.Error) {
 	if cmd.config.HasSpace() {
 		cmd.ui.Say("Getting services from marketplace in org %s / space %s as %s...",
 			terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
@@ -41,7 +42,7 @@ func (cmd MarketplaceServices) Run(c *cli.Context) {
 	serviceOfferings, apiResponse := cmd.serviceRepo.GetServiceOfferings()
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -43,7 +43,7 @@ func (cmd *RenameService) GetRequirements(reqFactory requirements.Factory, c *cl
 	return
 }
 
-func (cmd *RenameService) Run(c *cli.Context) {
+func (cmd *RenameService) Run(c *cli.Context) (err errors.Error) {
 	newName := c.Args()[1]
 	serviceInstance := cmd.serviceInstanceReq.GetServiceInstance()
 
@@ -58,12 +58,13 @@ func (cmd *RenameService) Run(c *cli.Context) {
 
 	if apiResponse.IsNotSuccessful() {
 		if apiResponse.ErrorCode == cf.SERVICE_INSTANCE_NAME_TAKEN {
-			cmd.ui.Failed("%s\nTIP: Use '%s services' to view all services in this org and space.", apiResponse.Message, cf.Name())
+			err = cmd.ui.Failed("%s\nTIP: Use '%s services' to view all services in this org and space.", apiResponse.Message, cf.Name())
 		} else {
-			cmd.ui.Failed(apiResponse.Message)
+			err = cmd.ui.FailWithError(apiResponse.ToError())
 		}
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -1,9 +1,9 @@
 package service
 
 import (
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -35,7 +35,7 @@ func (cmd *ShowService) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd *ShowService) Run(c *cli.Context) {
+func (cmd *ShowService) Run(c *cli.Context) (err errors.Error) {
 	serviceInstance := cmd.serviceInstanceReq.GetServiceInstance()
 
 	cmd.ui.Say("")
@@ -49,4 +49,5 @@ func (cmd *ShowService) Run(c *cli.Context) {
 		cmd.ui.Say("Description: %s", terminal.EntityNameColor(serviceInstance.ServiceOffering.Description))
 		cmd.ui.Say("Documentation url: %s", terminal.EntityNameColor(serviceInstance.ServiceOffering.DocumentationUrl))
 	}
+	return
 }
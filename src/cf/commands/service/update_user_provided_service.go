@@ -3,10 +3,10 @@ package service
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"encoding/json"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -42,11 +42,11 @@ func (cmd *UpdateUserProvidedService) GetRequirements(reqFactory requirements.Fa
 	return
 }
 
-func (cmd *UpdateUserProvidedService) Run(c *cli.Context) {
+func (cmd *UpdateUserProvidedService) Run(c *cli.Context) (err errors.Error) {
 
 	serviceInstance := cmd.serviceInstanceReq.GetServiceInstance()
 	if !serviceInstance.IsUserProvided() {
-		cmd.ui.Failed("Service Instance is not user provided")
+		err = cmd.ui.Failed("Service Instance is not user provided")
 		return
 	}
 
@@ -56,9 +56,9 @@ func (cmd *UpdateUserProvidedService) Run(c *cli.Context) {
 	paramsMap := make(map[string]string)
 	if params != "" {
 
-		err := json.Unmarshal([]byte(params), &paramsMap)
-		if err != nil {
-			cmd.ui.Failed("JSON is invalid: %s", err.Error())
+		jsonErr := json.Unmarshal([]byte(params), &paramsMap)
+		if jsonErr != nil {
+			err = cmd.ui.Failed("JSON is invalid: %s", jsonErr.Error())
 			return
 		}
 	}
@@ -75,7 +75,7 @@ func (cmd *UpdateUserProvidedService) Run(c *cli.Context) {
 
 	apiResponse := cmd.userProvidedServiceInstanceRepo.Update(serviceInstance.ServiceInstanceFields)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -85,4 +85,5 @@ func (cmd *UpdateUserProvidedService) Run(c *cli.Context) {
 	if params == "" && drainUrl == "" {
 		cmd.ui.Warn("No flags specified. No changes were made.")
 	}
+	return
 }
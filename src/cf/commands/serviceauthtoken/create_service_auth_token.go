@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -36,7 +36,7 @@ func (cmd CreateServiceAuthTokenFields) GetRequirements(reqFactory requirements.
 	return
 }
 
-func (cmd CreateServiceAuthTokenFields) Run(c *cli.Context) {
+func (cmd CreateServiceAuthTokenFields) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Creating service auth token as %s...", terminal.EntityNameColor(cmd.config.Username()))
 
 	serviceAuthTokenRepo := cf.ServiceAuthTokenFields{
@@ -47,9 +47,10 @@ func (cmd CreateServiceAuthTokenFields) Run(c *cli.Context) {
 
 	apiResponse := cmd.authTokenRepo.Create(serviceAuthTokenRepo)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
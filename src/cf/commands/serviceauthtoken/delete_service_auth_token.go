@@ -3,9 +3,9 @@ package serviceauthtoken
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
 )
@@ -34,17 +34,18 @@ func (cmd DeleteServiceAuthTokenFields) GetRequirements(reqFactory requirements.
 	return
 }
 
-func (cmd DeleteServiceAuthTokenFields) Run(c *cli.Context) {
+func (cmd DeleteServiceAuthTokenFields) Run(c *cli.Context) (err errors.Error) {
 	tokenLabel := c.Args()[0]
 	tokenProvider := c.Args()[1]
 
 	if c.Bool("f") == false {
-		response := cmd.ui.Confirm(
+		var confirmed bool
+		confirmed, err = terminal.Confirm(cmd.ui,
 			"Are you sure you want to delete %s?%s",
 			terminal.EntityNameColor(fmt.Sprintf("%s %s", tokenLabel, tokenProvider)),
 			terminal.PromptColor(">"),
 		)
-		if response == false {
+		if !confirmed {
 			return
 		}
 	}
@@ -52,7 +53,7 @@ func (cmd DeleteServiceAuthTokenFields) Run(c *cli.Context) {
 	cmd.ui.Say("Deleting service auth token as %s", terminal.EntityNameColor(cmd.config.Username()))
 	token, apiResponse := cmd.authTokenRepo.FindByLabelAndProvider(tokenLabel, tokenProvider)
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 	if apiResponse.IsNotFound() {
@@ -63,9 +64,10 @@ func (cmd DeleteServiceAuthTokenFields) Run(c *cli.Context) {
 
 	apiResponse = cmd.authTokenRepo.Delete(token)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -4,7 +4,9 @@ import (
 	"cf"
 	. "cf/commands/serviceauthtoken"
 	"cf/configuration"
+	"cf/errors"
 	"cf/net"
+	"cf/terminal"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
@@ -79,6 +81,19 @@ func TestDeleteServiceAuthTokenWithN(t *testing.T) {
 	assert.Equal(t, authTokenRepo.DeletedServiceAuthTokenFields, cf.ServiceAuthTokenFields{})
 }
 
+func TestDeleteServiceAuthTokenNonInteractivelyWithoutForce(t *testing.T) {
+	terminal.EnableNonInteractive()
+	defer terminal.DisableNonInteractive()
+
+	authTokenRepo := &testapi.FakeAuthTokenRepo{}
+	ui := callDeleteServiceAuthToken(t, []string{"a label", "a provider"}, []string{}, &testreq.FakeReqFactory{LoginSuccess: true}, authTokenRepo)
+
+	assert.Equal(t, len(ui.Prompts), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"non-interactive", "-f"}})
+	assert.Equal(t, testcmd.CommandRunError.ExitCode(), errors.EXIT_USAGE)
+	assert.Equal(t, authTokenRepo.DeletedServiceAuthTokenFields, cf.ServiceAuthTokenFields{})
+}
+
 func TestDeleteServiceAuthTokenWithY(t *testing.T) {
 	expectedToken := cf.ServiceAuthTokenFields{}
 	expectedToken.Label = "a label"
@@ -3,6 +3,7 @@ package serviceauthtoken
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -28,11 +29,11 @@ func (cmd ListServiceAuthTokens) GetRequirements(reqFactory requirements.Factory
 	return
 }
 
-func (cmd ListServiceAuthTokens) Run(c *cli.Context) {
+func (cmd ListServiceAuthTokens) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting service auth tokens as %s...", terminal.EntityNameColor(cmd.config.Username()))
 	authTokens, apiResponse := cmd.authTokenRepo.FindAll()
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 	cmd.ui.Ok()
@@ -47,4 +48,5 @@ func (cmd ListServiceAuthTokens) Run(c *cli.Context) {
 	}
 
 	cmd.ui.DisplayTable(table)
+	return
 }
@@ -3,9 +3,9 @@ package servicebroker
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -35,7 +35,7 @@ func (cmd CreateServiceBroker) GetRequirements(reqFactory requirements.Factory,
 	return
 }
 
-func (cmd CreateServiceBroker) Run(c *cli.Context) {
+func (cmd CreateServiceBroker) Run(c *cli.Context) (err errors.Error) {
 	name := c.Args()[0]
 	username := c.Args()[1]
 	password := c.Args()[2]
@@ -48,9 +48,10 @@ func (cmd CreateServiceBroker) Run(c *cli.Context) {
 
 	apiResponse := cmd.serviceBrokerRepo.Create(name, url, username, password)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
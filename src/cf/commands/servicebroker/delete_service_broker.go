@@ -3,9 +3,9 @@ package servicebroker
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -33,17 +33,18 @@ func (cmd DeleteServiceBroker) GetRequirements(reqFactory requirements.Factory,
 
 	return
 }
-func (cmd DeleteServiceBroker) Run(c *cli.Context) {
+func (cmd DeleteServiceBroker) Run(c *cli.Context) (err errors.Error) {
 	brokerName := c.Args()[0]
 	force := c.Bool("f")
 
 	if !force {
-		response := cmd.ui.Confirm(
+		var confirmed bool
+		confirmed, err = terminal.Confirm(cmd.ui,
 			"Really delete %s?%s",
 			terminal.EntityNameColor(brokerName),
 			terminal.PromptColor(">"),
 		)
-		if !response {
+		if !confirmed {
 			return
 		}
 	}
@@ -56,7 +57,7 @@ func (cmd DeleteServiceBroker) Run(c *cli.Context) {
 	broker, apiResponse := cmd.repo.FindByName(brokerName)
 
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -68,7 +69,7 @@ func (cmd DeleteServiceBroker) Run(c *cli.Context) {
 
 	apiResponse = cmd.repo.Delete(broker.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -4,6 +4,8 @@ import (
 	"cf"
 	. "cf/commands/servicebroker"
 	"cf/configuration"
+	"cf/errors"
+	"cf/terminal"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
@@ -50,6 +52,18 @@ func TestDeleteConfirmingWithY(t *testing.T) {
 	})
 }
 
+func TestDeleteNonInteractivelyWithoutForce(t *testing.T) {
+	terminal.EnableNonInteractive()
+	defer terminal.DisableNonInteractive()
+
+	ui, _, repo := deleteServiceBroker(t, "", []string{"service-broker-to-delete"})
+
+	assert.Equal(t, len(ui.Prompts), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"non-interactive", "-f"}})
+	assert.Equal(t, testcmd.CommandRunError.ExitCode(), errors.EXIT_USAGE)
+	assert.Equal(t, repo.DeletedServiceBrokerGuid, "")
+}
+
 func TestDeleteConfirmingWithYes(t *testing.T) {
 	ui, _, repo := deleteServiceBroker(t, "Yes", []string{"service-broker-to-delete"})
 
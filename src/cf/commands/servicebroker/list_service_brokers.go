@@ -3,6 +3,7 @@ package servicebroker
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -25,7 +26,7 @@ func (cmd ListServiceBrokers) GetRequirements(reqFactory requirements.Factory, c
 	return
 }
 
-func (cmd ListServiceBrokers) Run(c *cli.Context) {
+func (cmd ListServiceBrokers) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting service brokers as %s...\n", terminal.EntityNameColor(cmd.config.Username()))
 
 	stopChan := make(chan bool)
@@ -50,11 +51,12 @@ func (cmd ListServiceBrokers) Run(c *cli.Context) {
 
 	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
-		cmd.ui.Failed("Failed fetching service brokers.\n%s", apiStatus.Message)
+		err = cmd.ui.Failed("Failed fetching service brokers.\n%s", apiStatus.Message)
 		return
 	}
 
 	if noServiceBrokers {
 		cmd.ui.Say("No service brokers found")
 	}
+	return
 }
@@ -3,9 +3,9 @@ package servicebroker
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -34,10 +34,10 @@ func (cmd RenameServiceBroker) GetRequirements(reqFactory requirements.Factory,
 	return
 }
 
-func (cmd RenameServiceBroker) Run(c *cli.Context) {
+func (cmd RenameServiceBroker) Run(c *cli.Context) (err errors.Error) {
 	serviceBroker, apiResponse := cmd.repo.FindByName(c.Args()[0])
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -52,9 +52,10 @@ func (cmd RenameServiceBroker) Run(c *cli.Context) {
 	apiResponse = cmd.repo.Rename(serviceBroker.Guid, newName)
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -5,9 +5,9 @@ import (
 	"cf/api"
 	"cf/commands/user"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -45,7 +45,7 @@ func (cmd CreateSpace) GetRequirements(reqFactory requirements.Factory, c *cli.C
 	return
 }
 
-func (cmd CreateSpace) Run(c *cli.Context) {
+func (cmd CreateSpace) Run(c *cli.Context) (err errors.Error) {
 	spaceName := c.Args()[0]
 	orgName := c.String("o")
 	orgGuid := ""
@@ -63,11 +63,11 @@ func (cmd CreateSpace) Run(c *cli.Context) {
 	if orgGuid == "" {
 		org, apiResponse := cmd.orgRepo.FindByName(orgName)
 		if apiResponse.IsNotFound() {
-			cmd.ui.Failed("Org %s does not exist or is not accessible", orgName)
+			err = cmd.ui.Failed("Org %s does not exist or is not accessible", orgName)
 			return
 		}
 		if apiResponse.IsError() {
-			cmd.ui.Failed("Error finding org %s\n%s", orgName, apiResponse.Message)
+			err = cmd.ui.Failed("Error finding org %s\n%s", orgName, apiResponse.Message)
 			return
 		}
 		orgGuid = org.Guid
@@ -80,24 +80,23 @@ func (cmd CreateSpace) Run(c *cli.Context) {
 			cmd.ui.Warn("Space %s already exists", spaceName)
 			return
 		}
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 	cmd.ui.Ok()
 
-	var err error
-
 	err = cmd.spaceRoleSetter.SetSpaceRole(space, cf.SPACE_MANAGER, cmd.config.UserGuid(), cmd.config.Username())
 	if err != nil {
-		cmd.ui.Failed(err.Error())
+		err = cmd.ui.FailWithError(err)
 		return
 	}
 
 	err = cmd.spaceRoleSetter.SetSpaceRole(space, cf.SPACE_DEVELOPER, cmd.config.UserGuid(), cmd.config.Username())
 	if err != nil {
-		cmd.ui.Failed(err.Error())
+		err = cmd.ui.FailWithError(err)
 		return
 	}
 
 	cmd.ui.Say("\nTIP: Use '%s' to target new space", terminal.CommandColor(cf.Name()+" target -o "+orgName+" -s "+space.Name))
+	return
 }
@@ -4,9 +4,10 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -43,7 +44,7 @@ func (cmd *DeleteSpace) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd *DeleteSpace) Run(c *cli.Context) {
+func (cmd *DeleteSpace) Run(c *cli.Context) (err errors.Error) {
 	spaceName := c.Args()[0]
 	force := c.Bool("f")
 
@@ -55,32 +56,51 @@ func (cmd *DeleteSpace) Run(c *cli.Context) {
 
 	space := cmd.spaceReq.GetSpace()
 
-	if !force {
-		response := cmd.ui.Confirm(
+	var confirmed bool
+	if cmd.config.IsSpaceProtected(cmd.config.OrganizationFields.Name, spaceName) {
+		appNames := []string{}
+		for _, app := range space.Applications {
+			appNames = append(appNames, app.Name)
+		}
+		serviceNames := []string{}
+		for _, instance := range space.ServiceInstances {
+			serviceNames = append(serviceNames, instance.Name)
+		}
+		cascades := []string{
+			terminal.CascadeLine("apps, with their routes", appNames),
+			terminal.CascadeLine("services", serviceNames),
+		}
+
+		confirmed, err = terminal.ConfirmProtectedDeletion(cmd.ui, "space", spaceName, cascades, force)
+		if !confirmed {
+			return
+		}
+	} else if !force {
+		confirmed, err = terminal.Confirm(cmd.ui,
 			"Really delete space %s and everything associated with it?%s",
 			terminal.EntityNameColor(spaceName),
 			terminal.PromptColor(">"),
 		)
-		if !response {
+		if !confirmed {
 			return
 		}
 	}
 
 	apiResponse := cmd.spaceRepo.Delete(space.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
 
-	config, err := cmd.configRepo.Get()
-	if err != nil {
-		cmd.ui.ConfigFailure(err)
+	config, configErr := cmd.configRepo.Get()
+	if configErr != nil {
+		err = cmd.ui.ConfigFailure(configErr)
 		return
 	}
 
-	if config.SpaceFields.Name == spaceName {
+	if config.SpaceFields.Name == spaceName && !net.IsDryRun() {
 		config.SpaceFields = cf.SpaceFields{}
 		cmd.configRepo.Save()
 		cmd.ui.Say("TIP: No space targeted, use '%s target -s' to target a space", cf.Name())
@@ -125,6 +125,55 @@ func TestDeleteSpaceCommandWith(t *testing.T) {
 	assert.False(t, ui.FailedWithUsage)
 }
 
+func TestDeleteProtectedSpaceRequiresTypingTheName(t *testing.T) {
+	space := defaultDeleteSpaceSpace()
+	app := cf.ApplicationFields{}
+	app.Name = "my-app"
+	space.Applications = []cf.ApplicationFields{app}
+	instance := cf.ServiceInstanceFields{}
+	instance.Name = "my-db"
+	space.ServiceInstances = []cf.ServiceInstanceFields{instance}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true, Space: space}
+
+	ui, spaceRepo := deleteProtectedSpace(t, "y", []string{"space-to-delete"}, reqFactory)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"space-to-delete", "is protected"},
+		{"apps", "my-app"},
+		{"services", "my-db"},
+		{"did not match"},
+	})
+	assert.Equal(t, spaceRepo.DeletedSpaceGuid, "")
+
+	ui, spaceRepo = deleteProtectedSpace(t, "space-to-delete", []string{"space-to-delete"}, reqFactory)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+	})
+	assert.Equal(t, spaceRepo.DeletedSpaceGuid, "space-to-delete-guid")
+
+	ui, spaceRepo = deleteProtectedSpace(t, "", []string{"-f", "space-to-delete"}, reqFactory)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"space-to-delete", "protected", "-f"},
+	})
+	assert.Equal(t, spaceRepo.DeletedSpaceGuid, "")
+}
+
+func deleteProtectedSpace(t *testing.T, confirmation string, args []string, reqFactory *testreq.FakeReqFactory) (ui *testterm.FakeUI, spaceRepo *testapi.FakeSpaceRepository) {
+	spaceRepo = &testapi.FakeSpaceRepository{}
+	ui = &testterm.FakeUI{Inputs: []string{confirmation}}
+
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	config := &configuration.Configuration{
+		OrganizationFields: org,
+		ProtectedSpaces:    []string{"my-org/space-to-delete"},
+	}
+
+	cmd := NewDeleteSpace(ui, config, spaceRepo, &testconfig.FakeConfigRepository{})
+	testcmd.RunCommand(cmd, testcmd.NewContext("delete-space", args), reqFactory)
+	return
+}
+
 func deleteSpace(t *testing.T, inputs []string, args []string, reqFactory *testreq.FakeReqFactory) (ui *testterm.FakeUI, spaceRepo *testapi.FakeSpaceRepository) {
 	spaceRepo = &testapi.FakeSpaceRepository{}
 	configRepo := &testconfig.FakeConfigRepository{}
@@ -3,6 +3,7 @@ package space
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -29,7 +30,7 @@ func (cmd ListSpaces) GetRequirements(reqFactory requirements.Factory, c *cli.Co
 	return
 }
 
-func (cmd ListSpaces) Run(c *cli.Context) {
+func (cmd ListSpaces) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting spaces in org %s as %s...\n",
 		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
 		terminal.EntityNameColor(cmd.config.Username()))
@@ -53,11 +54,12 @@ func (cmd ListSpaces) Run(c *cli.Context) {
 
 	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
-		cmd.ui.Failed("Failed fetching spaces.\n%s", apiStatus.Message)
+		err = cmd.ui.Failed("Failed fetching spaces.\n%s", apiStatus.Message)
 		return
 	}
 
 	if noSpaces {
 		cmd.ui.Say("No spaces found")
 	}
+	return
 }
@@ -3,9 +3,10 @@ package space
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -41,7 +42,7 @@ func (cmd *RenameSpace) GetRequirements(reqFactory requirements.Factory, c *cli.
 	return
 }
 
-func (cmd *RenameSpace) Run(c *cli.Context) {
+func (cmd *RenameSpace) Run(c *cli.Context) (err errors.Error) {
 	space := cmd.spaceReq.GetSpace()
 	newName := c.Args()[1]
 	cmd.ui.Say("Renaming space %s to %s in org %s as %s...",
@@ -53,14 +54,15 @@ func (cmd *RenameSpace) Run(c *cli.Context) {
 
 	apiResponse := cmd.spaceRepo.Rename(space.Guid, newName)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
-	if cmd.config.SpaceFields.Guid == space.Guid {
+	if cmd.config.SpaceFields.Guid == space.Guid && !net.IsDryRun() {
 		cmd.config.SpaceFields.Name = newName
 		cmd.configRepo.Save()
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -3,6 +3,7 @@ package commands
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
 	"github.com/codegangsta/cli"
@@ -26,7 +27,7 @@ func (cmd *Stacks) GetRequirements(reqFactory requirements.Factory, c *cli.Conte
 	return
 }
 
-func (cmd *Stacks) Run(c *cli.Context) {
+func (cmd *Stacks) Run(c *cli.Context) (err errors.Error) {
 	cmd.ui.Say("Getting stacks in org %s / space %s as %s...",
 		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
 		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
@@ -35,7 +36,7 @@ func (cmd *Stacks) Run(c *cli.Context) {
 
 	stacks, apiResponse := cmd.stacksRepo.FindAll()
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -54,4 +55,5 @@ func (cmd *Stacks) Run(c *cli.Context) {
 	}
 
 	cmd.ui.DisplayTable(table)
+	return
 }
@@ -4,9 +4,10 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
+	"fmt"
 	"github.com/codegangsta/cli"
 )
 
@@ -45,7 +46,7 @@ func (cmd Target) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 	return
 }
 
-func (cmd Target) Run(c *cli.Context) {
+func (cmd Target) Run(c *cli.Context) (err errors.Error) {
 	orgName := c.String("o")
 	spaceName := c.String("s")
 	shouldShowTarget := (orgName == "" && spaceName == "")
@@ -63,22 +64,20 @@ func (cmd Target) Run(c *cli.Context) {
 	}
 
 	if orgName != "" {
-		err := cmd.setOrganization(orgName)
+		err = cmd.setOrganization(orgName)
+		if err != nil {
+			return
+		}
 
 		if spaceName == "" && cmd.config.IsLoggedIn() {
 			cmd.showConfig()
 			cmd.ui.Say("No space targeted, use '%s' to target a space", terminal.CommandColor(cf.Name()+" target -s"))
 			return
 		}
-
-		if err != nil {
-			return
-		}
 	}
 
 	if spaceName != "" {
-		err := cmd.setSpace(spaceName)
-
+		err = cmd.setSpace(spaceName)
 		if err != nil {
 			return
 		}
@@ -87,58 +86,58 @@ func (cmd Target) Run(c *cli.Context) {
 	return
 }
 
-func (cmd Target) setOrganization(orgName string) (err error) {
+func (cmd Target) setOrganization(orgName string) (err errors.Error) {
 	if !cmd.config.IsLoggedIn() {
-		cmd.ui.Failed("You must be logged in to target an org. Use '%s'.", terminal.CommandColor(cf.Name()+" login"))
+		err = cmd.ui.FailWithError(errors.NewNotLoggedInError(fmt.Sprintf("You must be logged in to target an org. Use '%s'.", terminal.CommandColor(cf.Name()+" login"))))
 		return
 	}
 
 	org, apiResponse := cmd.orgRepo.FindByName(orgName)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Could not target org.\n%s", apiResponse.Message)
+		err = cmd.ui.Failed("Could not target org.\n%s", apiResponse.Message)
 		return
 	}
 
-	err = cmd.configRepo.SetOrganization(org.OrganizationFields)
-	if err != nil {
-		cmd.ui.Failed("Error setting org in config file.\n%s", err)
+	configErr := cmd.configRepo.SetOrganization(org.OrganizationFields)
+	if configErr != nil {
+		err = cmd.ui.Failed("Error setting org in config file.\n%s", configErr)
 		return
 	}
 	return
 }
 
-func (cmd Target) setSpace(spaceName string) (err error) {
+func (cmd Target) setSpace(spaceName string) (err errors.Error) {
 	if !cmd.config.IsLoggedIn() {
-		cmd.ui.Failed("You must be logged in to set a space. Use '%s login'.", cf.Name())
+		err = cmd.ui.FailWithError(errors.NewNotLoggedInError(fmt.Sprintf("You must be logged in to set a space. Use '%s login'.", cf.Name())))
 		return
 	}
 
 	if !cmd.config.HasOrganization() {
-		cmd.ui.Failed("An org must be targeted before targeting a space")
+		err = cmd.ui.Failed("An org must be targeted before targeting a space")
 		return
 	}
 
 	space, apiResponse := cmd.spaceRepo.FindByName(spaceName)
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed("Unable to access space %s.\n%s", spaceName, apiResponse.Message)
+		err = cmd.ui.Failed("Unable to access space %s.\n%s", spaceName, apiResponse.Message)
 		return
 	}
 
-	err = cmd.configRepo.SetSpace(space.SpaceFields)
-	if err != nil {
-		cmd.ui.Failed("Error setting space in config file.\n%s", err)
+	configErr := cmd.configRepo.SetSpace(space.SpaceFields)
+	if configErr != nil {
+		err = cmd.ui.Failed("Error setting space in config file.\n%s", configErr)
 		return
 	}
 	return
 }
 
-func (cmd Target) saveConfig() {
-	err := cmd.configRepo.Save()
-	if err != nil {
-		cmd.ui.Failed(err.Error())
-		return
+func (cmd Target) saveConfig() (err errors.Error) {
+	configErr := cmd.configRepo.Save()
+	if configErr != nil {
+		err = cmd.ui.Failed(configErr.Error())
 	}
+	return
 }
 
 func (cmd Target) showConfig() {
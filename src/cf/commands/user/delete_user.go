@@ -3,9 +3,9 @@ package user
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -34,15 +34,19 @@ func (cmd DeleteUserFields) GetRequirements(reqFactory requirements.Factory, c *
 	return
 }
 
-func (cmd DeleteUserFields) Run(c *cli.Context) {
+func (cmd DeleteUserFields) Run(c *cli.Context) (err errors.Error) {
 	username := c.Args()[0]
 	force := c.Bool("f")
 
-	if !force && !cmd.ui.Confirm("Really delete user %s?%s",
-		terminal.EntityNameColor(username),
-		terminal.PromptColor(">"),
-	) {
-		return
+	if !force {
+		var confirmed bool
+		confirmed, err = terminal.Confirm(cmd.ui, "Really delete user %s?%s",
+			terminal.EntityNameColor(username),
+			terminal.PromptColor(">"),
+		)
+		if !confirmed {
+			return
+		}
 	}
 
 	cmd.ui.Say("Deleting user %s as %s...",
@@ -52,7 +56,7 @@ func (cmd DeleteUserFields) Run(c *cli.Context) {
 
 	user, apiResponse := cmd.userRepo.FindByUsername(username)
 	if apiResponse.IsError() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 	if apiResponse.IsNotFound() {
@@ -63,9 +67,10 @@ func (cmd DeleteUserFields) Run(c *cli.Context) {
 
 	apiResponse = cmd.userRepo.Delete(user.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -4,6 +4,8 @@ import (
 	"cf"
 	. "cf/commands/user"
 	"cf/configuration"
+	"cf/errors"
+	"cf/terminal"
 	"github.com/stretchr/testify/assert"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
@@ -86,6 +88,19 @@ func TestDeleteUserWhenNotConfirming(t *testing.T) {
 	assert.Equal(t, userRepo.DeleteUserGuid, "")
 }
 
+func TestDeleteUserNonInteractivelyWithoutForce(t *testing.T) {
+	terminal.EnableNonInteractive()
+	defer terminal.DisableNonInteractive()
+
+	userRepo := &testapi.FakeUserRepository{}
+	ui := callDeleteUser(t, []string{"my-user"}, userRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.Equal(t, len(ui.Prompts), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"non-interactive", "-f"}})
+	assert.Equal(t, testcmd.CommandRunError.ExitCode(), errors.EXIT_USAGE)
+	assert.Equal(t, userRepo.DeleteUserGuid, "")
+}
+
 func TestDeleteUserWithForceOption(t *testing.T) {
 	foundUserFields := cf.UserFields{}
 	foundUserFields.Guid = "my-found-user-guid"
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -47,7 +47,7 @@ func (cmd *OrgUsers) GetRequirements(reqFactory requirements.Factory, c *cli.Con
 	return
 }
 
-func (cmd *OrgUsers) Run(c *cli.Context) {
+func (cmd *OrgUsers) Run(c *cli.Context) (err errors.Error) {
 	org := cmd.orgReq.GetOrganization()
 
 	cmd.ui.Say("Getting users in org %s as %s...",
@@ -74,8 +74,9 @@ func (cmd *OrgUsers) Run(c *cli.Context) {
 
 		apiStatus := <-statusChan
 		if apiStatus.IsNotSuccessful() {
-			cmd.ui.Failed("Failed fetching org-users for role %s.\n%s", apiStatus.Message, displayName)
+			err = cmd.ui.Failed("Failed fetching org-users for role %s.\n%s", apiStatus.Message, displayName)
 			return
 		}
 	}
+	return
 }
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -45,7 +45,7 @@ func (cmd *SetOrgRole) GetRequirements(reqFactory requirements.Factory, c *cli.C
 	return
 }
 
-func (cmd *SetOrgRole) Run(c *cli.Context) {
+func (cmd *SetOrgRole) Run(c *cli.Context) (err errors.Error) {
 	user := cmd.userReq.GetUser()
 	org := cmd.orgReq.GetOrganization()
 	role := cf.UserInputToOrgRole[c.Args()[2]]
@@ -59,9 +59,10 @@ func (cmd *SetOrgRole) Run(c *cli.Context) {
 
 	apiResponse := cmd.userRepo.SetOrgRole(user.Guid, org.Guid, role)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
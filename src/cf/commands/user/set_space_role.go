@@ -4,14 +4,14 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
 type SpaceRoleSetter interface {
-	SetSpaceRole(space cf.Space, role, userGuid, userName string) (err error)
+	SetSpaceRole(space cf.Space, role, userGuid, userName string) (err errors.Error)
 }
 
 type SetSpaceRole struct {
@@ -50,7 +50,7 @@ func (cmd *SetSpaceRole) GetRequirements(reqFactory requirements.Factory, c *cli
 	return
 }
 
-func (cmd *SetSpaceRole) Run(c *cli.Context) {
+func (cmd *SetSpaceRole) Run(c *cli.Context) (err errors.Error) {
 	spaceName := c.Args()[2]
 	role := cf.UserInputToSpaceRole[c.Args()[3]]
 	user := cmd.userReq.GetUser()
@@ -58,18 +58,18 @@ func (cmd *SetSpaceRole) Run(c *cli.Context) {
 
 	space, apiResponse := cmd.spaceRepo.FindByNameInOrg(spaceName, org.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
-	err := cmd.SetSpaceRole(space, role, user.Guid, user.Username)
+	err = cmd.SetSpaceRole(space, role, user.Guid, user.Username)
 	if err != nil {
-		cmd.ui.Failed(err.Error())
-		return
+		err = cmd.ui.FailWithError(err)
 	}
+	return
 }
 
-func (cmd *SetSpaceRole) SetSpaceRole(space cf.Space, role, userGuid, userName string) (err error) {
+func (cmd *SetSpaceRole) SetSpaceRole(space cf.Space, role, userGuid, userName string) (err errors.Error) {
 	cmd.ui.Say("Assigning role %s to user %s in org %s / space %s as %s...",
 		terminal.EntityNameColor(role),
 		terminal.EntityNameColor(userName),
@@ -80,7 +80,7 @@ func (cmd *SetSpaceRole) SetSpaceRole(space cf.Space, role, userGuid, userName s
 
 	apiResponse := cmd.userRepo.SetSpaceRole(userGuid, space.Guid, space.Organization.Guid, role)
 	if apiResponse.IsNotSuccessful() {
-		err = errors.New(apiResponse.Message)
+		err = apiResponse.ToError()
 		return
 	}
 
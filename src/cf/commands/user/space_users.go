@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -49,13 +49,14 @@ func (cmd *SpaceUsers) GetRequirements(reqFactory requirements.Factory, c *cli.C
 	return
 }
 
-func (cmd *SpaceUsers) Run(c *cli.Context) {
+func (cmd *SpaceUsers) Run(c *cli.Context) (err errors.Error) {
 	spaceName := c.Args()[1]
 	org := cmd.orgReq.GetOrganization()
 
 	space, apiResponse := cmd.spaceRepo.FindByNameInOrg(spaceName, org.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
 	cmd.ui.Say("Getting users in org %s / space %s as %s",
@@ -83,8 +84,9 @@ func (cmd *SpaceUsers) Run(c *cli.Context) {
 
 		apiStatus := <-statusChan
 		if apiStatus.IsNotSuccessful() {
-			cmd.ui.Failed("Failed fetching space-users for role %s.\n%s", apiStatus.Message, displayName)
+			err = cmd.ui.Failed("Failed fetching space-users for role %s.\n%s", apiStatus.Message, displayName)
 			return
 		}
 	}
+	return
 }
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -46,7 +46,7 @@ func (cmd *UnsetOrgRole) GetRequirements(reqFactory requirements.Factory, c *cli
 	return
 }
 
-func (cmd *UnsetOrgRole) Run(c *cli.Context) {
+func (cmd *UnsetOrgRole) Run(c *cli.Context) (err errors.Error) {
 	role := cf.UserInputToOrgRole[c.Args()[2]]
 	user := cmd.userReq.GetUser()
 	org := cmd.orgReq.GetOrganization()
@@ -61,9 +61,10 @@ func (cmd *UnsetOrgRole) Run(c *cli.Context) {
 	apiResponse := cmd.userRepo.UnsetOrgRole(user.Guid, org.Guid, role)
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
@@ -4,9 +4,9 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/requirements"
 	"cf/terminal"
-	"errors"
 	"github.com/codegangsta/cli"
 )
 
@@ -47,7 +47,7 @@ func (cmd *UnsetSpaceRole) GetRequirements(reqFactory requirements.Factory, c *c
 	return
 }
 
-func (cmd *UnsetSpaceRole) Run(c *cli.Context) {
+func (cmd *UnsetSpaceRole) Run(c *cli.Context) (err errors.Error) {
 	spaceName := c.Args()[2]
 	role := cf.UserInputToSpaceRole[c.Args()[3]]
 
@@ -55,7 +55,7 @@ func (cmd *UnsetSpaceRole) Run(c *cli.Context) {
 	org := cmd.orgReq.GetOrganization()
 	space, apiResponse := cmd.spaceRepo.FindByNameInOrg(spaceName, org.Guid)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
@@ -70,9 +70,10 @@ func (cmd *UnsetSpaceRole) Run(c *cli.Context) {
 	apiResponse = cmd.userRepo.UnsetSpaceRole(user.Guid, space.Guid, role)
 
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Failed(apiResponse.Message)
+		err = cmd.ui.FailWithError(apiResponse.ToError())
 		return
 	}
 
 	cmd.ui.Ok()
+	return
 }
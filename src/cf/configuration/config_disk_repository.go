@@ -116,7 +116,7 @@ func (repo ConfigurationDiskRepository) ClearSession() (err error) {
 // Keep this one public for configtest/configuration.go
 func ConfigFile() (file string, err error) {
 
-	configDir := filepath.Join(userHomeDir(), ".cf")
+	configDir := ConfigDir()
 
 	err = os.MkdirAll(configDir, dirPermissions)
 
@@ -128,6 +128,10 @@ func ConfigFile() (file string, err error) {
 	return
 }
 
+func ConfigDir() string {
+	return filepath.Join(userHomeDir(), ".cf")
+}
+
 // See: http://stackoverflow.com/questions/7922270/obtain-users-home-directory
 // we can't cross compile using cgo and use user.Current()
 func userHomeDir() string {
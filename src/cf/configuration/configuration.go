@@ -3,6 +3,7 @@ package configuration
 import (
 	"cf"
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -17,6 +18,9 @@ type Configuration struct {
 	OrganizationFields      cf.OrganizationFields
 	SpaceFields             cf.SpaceFields
 	ApplicationStartTimeout time.Duration // will be used as seconds
+	ProtectedTargets        []string      // api endpoints
+	ProtectedOrganizations  []string      // org names
+	ProtectedSpaces         []string      // "org/space" names
 }
 
 func (c Configuration) UserEmail() (email string) {
@@ -43,6 +47,57 @@ func (c Configuration) HasSpace() bool {
 	return c.SpaceFields.Guid != "" && c.SpaceFields.Name != ""
 }
 
+func (c Configuration) IsTargetProtected() bool {
+	return containsName(c.ProtectedTargets, c.Target)
+}
+
+func (c Configuration) IsOrganizationProtected(orgName string) bool {
+	return c.IsTargetProtected() || containsName(c.ProtectedOrganizations, orgName)
+}
+
+func (c Configuration) IsSpaceProtected(orgName, spaceName string) bool {
+	return c.IsOrganizationProtected(orgName) || containsName(c.ProtectedSpaces, orgName+"/"+spaceName)
+}
+
+func (c *Configuration) SetTargetProtected(protected bool) {
+	c.ProtectedTargets = setName(c.ProtectedTargets, c.Target, protected)
+}
+
+func (c *Configuration) SetOrganizationProtected(orgName string, protected bool) {
+	c.ProtectedOrganizations = setName(c.ProtectedOrganizations, orgName, protected)
+}
+
+func (c *Configuration) SetSpaceProtected(orgName, spaceName string, protected bool) {
+	c.ProtectedSpaces = setName(c.ProtectedSpaces, orgName+"/"+spaceName, protected)
+}
+
+// setName adds name to names or removes every spelling of it, matching names
+// the same way containsName does.
+func setName(names []string, name string, include bool) (result []string) {
+	if include {
+		if containsName(names, name) {
+			return names
+		}
+		return append(names, name)
+	}
+
+	for _, n := range names {
+		if !containsName([]string{n}, name) {
+			result = append(result, n)
+		}
+	}
+	return
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(strings.TrimRight(n, "/"), strings.TrimRight(name, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
 type TokenInfo struct {
 	Username string `json:"user_name"`
 	Email    string `json:"email"`
@@ -40,3 +40,54 @@ func TestUserGuidWithInvalidAccessToken(t *testing.T) {
 	config.AccessToken = "bearer eyJhbGciOiJSUzI1NiJ9"
 	assert.Empty(t, config.UserGuid())
 }
+
+func TestProtectedTargets(t *testing.T) {
+	config := Configuration{
+		Target:           "https://api.prod.example.com",
+		ProtectedTargets: []string{"https://api.prod.example.com/"},
+	}
+
+	assert.True(t, config.IsTargetProtected())
+	assert.True(t, config.IsOrganizationProtected("any-org"))
+	assert.True(t, config.IsSpaceProtected("any-org", "any-space"))
+
+	config.Target = "https://api.staging.example.com"
+	assert.False(t, config.IsTargetProtected())
+	assert.False(t, config.IsOrganizationProtected("any-org"))
+}
+
+func TestProtectedOrganizationsAndSpaces(t *testing.T) {
+	config := Configuration{
+		ProtectedOrganizations: []string{"prod-org"},
+		ProtectedSpaces:        []string{"dev-org/production"},
+	}
+
+	assert.True(t, config.IsOrganizationProtected("prod-org"))
+	assert.True(t, config.IsOrganizationProtected("PROD-ORG"))
+	assert.True(t, config.IsSpaceProtected("prod-org", "any-space"))
+	assert.True(t, config.IsSpaceProtected("dev-org", "production"))
+
+	assert.False(t, config.IsOrganizationProtected("dev-org"))
+	assert.False(t, config.IsSpaceProtected("dev-org", "development"))
+}
+
+func TestSettingProtection(t *testing.T) {
+	config := Configuration{Target: "https://api.prod.example.com"}
+
+	config.SetTargetProtected(true)
+	config.SetOrganizationProtected("prod-org", true)
+	config.SetOrganizationProtected("PROD-ORG", true)
+	config.SetSpaceProtected("dev-org", "production", true)
+
+	assert.Equal(t, config.ProtectedTargets, []string{"https://api.prod.example.com"})
+	assert.Equal(t, config.ProtectedOrganizations, []string{"prod-org"})
+	assert.Equal(t, config.ProtectedSpaces, []string{"dev-org/production"})
+
+	config.SetTargetProtected(false)
+	config.SetOrganizationProtected("Prod-Org", false)
+	config.SetSpaceProtected("dev-org", "production", false)
+
+	assert.False(t, config.IsTargetProtected())
+	assert.False(t, config.IsOrganizationProtected("prod-org"))
+	assert.False(t, config.IsSpaceProtected("dev-org", "production"))
+}
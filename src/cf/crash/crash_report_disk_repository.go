@@ -0,0 +1,150 @@
+package crash
+
+import (
+	"cf/configuration"
+	"cf/net"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	reportPrefix    = "crash-"
+	reportExtension = ".log"
+	reportTimestamp = "20060102-150405.000"
+	filePermissions = 0600
+	dirPermissions  = 0700
+)
+
+type ReportInfo struct {
+	Name string
+	Path string
+	Time time.Time
+	Size int64
+}
+
+type CrashReportRepository interface {
+	Save(report Report) (path string, err error)
+	List() (reports []ReportInfo, err error)
+	Read(name string) (contents string, err error)
+	Redact(name string, secrets []string) (err error)
+}
+
+type CrashReportDiskRepository struct {
+	dir string
+}
+
+func NewCrashReportDiskRepository() (repo CrashReportDiskRepository) {
+	return NewCrashReportDiskRepositoryWithDir(filepath.Join(configuration.ConfigDir(), "crash"))
+}
+
+func NewCrashReportDiskRepositoryWithDir(dir string) (repo CrashReportDiskRepository) {
+	repo.dir = dir
+	return
+}
+
+// Save writes the report to a file named after its time. Reports from the
+// same millisecond get a numbered suffix instead of replacing each other.
+func (repo CrashReportDiskRepository) Save(report Report) (path string, err error) {
+	err = os.MkdirAll(repo.dir, dirPermissions)
+	if err != nil {
+		return
+	}
+
+	baseName := reportPrefix + report.Time.Format(reportTimestamp)
+	path = filepath.Join(repo.dir, baseName+reportExtension)
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePermissions)
+	for suffix := 2; os.IsExist(err); suffix++ {
+		path = filepath.Join(repo.dir, fmt.Sprintf("%s-%d%s", baseName, suffix, reportExtension))
+		file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePermissions)
+	}
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(report.String())
+	return
+}
+
+func (repo CrashReportDiskRepository) List() (reports []ReportInfo, err error) {
+	fileInfos, err := ioutil.ReadDir(repo.dir)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !strings.HasPrefix(name, reportPrefix) || !strings.HasSuffix(name, reportExtension) {
+			continue
+		}
+
+		reports = append(reports, ReportInfo{
+			Name: name,
+			Path: filepath.Join(repo.dir, name),
+			Time: fileInfo.ModTime(),
+			Size: fileInfo.Size(),
+		})
+	}
+
+	sort.Sort(reportsByNewest(reports))
+	return
+}
+
+func (repo CrashReportDiskRepository) Read(name string) (contents string, err error) {
+	path, err := repo.reportPath(name)
+	if err != nil {
+		return
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	contents = string(bytes)
+	return
+}
+
+func (repo CrashReportDiskRepository) Redact(name string, secrets []string) (err error) {
+	contents, err := repo.Read(name)
+	if err != nil {
+		return
+	}
+
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		contents = strings.Replace(contents, secret, net.PRIVATE_DATA_PLACEHOLDER, -1)
+	}
+
+	path, err := repo.reportPath(name)
+	if err != nil {
+		return
+	}
+
+	return ioutil.WriteFile(path, []byte(contents), filePermissions)
+}
+
+func (repo CrashReportDiskRepository) reportPath(name string) (path string, err error) {
+	if name != filepath.Base(name) {
+		err = errors.New("Crash report name must not contain a path: " + name)
+		return
+	}
+
+	path = filepath.Join(repo.dir, name)
+	return
+}
+
+type reportsByNewest []ReportInfo
+
+func (reports reportsByNewest) Len() int           { return len(reports) }
+func (reports reportsByNewest) Swap(i, j int)      { reports[i], reports[j] = reports[j], reports[i] }
+func (reports reportsByNewest) Less(i, j int) bool { return reports[i].Name > reports[j].Name }
@@ -0,0 +1,99 @@
+package crash_test
+
+import (
+	. "cf/crash"
+	"fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSavingAndListingReports(t *testing.T) {
+	fileutils.TempDir("crash-reports", func(dir string, err error) {
+		assert.NoError(t, err)
+		repo := NewCrashReportDiskRepositoryWithDir(filepath.Join(dir, "crash"))
+
+		reports, err := repo.List()
+		assert.NoError(t, err)
+		assert.Equal(t, len(reports), 0)
+
+		olderReport := Report{Time: time.Date(2014, 1, 2, 3, 4, 5, 0, time.UTC), Error: "older"}
+		newerReport := Report{Time: time.Date(2014, 1, 2, 3, 4, 5, 250000000, time.UTC), Error: "newer"}
+
+		path, err := repo.Save(olderReport)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Base(path), "crash-20140102-030405.000.log")
+
+		_, err = repo.Save(newerReport)
+		assert.NoError(t, err)
+
+		ioutil.WriteFile(filepath.Join(dir, "crash", "not-a-report.txt"), []byte("hi"), 0600)
+
+		reports, err = repo.List()
+		assert.NoError(t, err)
+		assert.Equal(t, len(reports), 2)
+		assert.Equal(t, reports[0].Name, "crash-20140102-030405.250.log")
+		assert.Equal(t, reports[1].Name, "crash-20140102-030405.000.log")
+
+		contents, err := repo.Read(reports[1].Name)
+		assert.NoError(t, err)
+		assert.Contains(t, contents, "older")
+	})
+}
+
+func TestSavingReportsFromTheSameMoment(t *testing.T) {
+	fileutils.TempDir("crash-reports", func(dir string, err error) {
+		assert.NoError(t, err)
+		repo := NewCrashReportDiskRepositoryWithDir(dir)
+		reportTime := time.Date(2014, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		firstPath, err := repo.Save(Report{Time: reportTime, Error: "first"})
+		assert.NoError(t, err)
+		secondPath, err := repo.Save(Report{Time: reportTime, Error: "second"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, filepath.Base(secondPath), "crash-20140102-030405.000-2.log")
+
+		contents, err := repo.Read(filepath.Base(firstPath))
+		assert.NoError(t, err)
+		assert.Contains(t, contents, "first")
+
+		contents, err = repo.Read(filepath.Base(secondPath))
+		assert.NoError(t, err)
+		assert.Contains(t, contents, "second")
+	})
+}
+
+func TestRedactingAReport(t *testing.T) {
+	fileutils.TempDir("crash-reports", func(dir string, err error) {
+		assert.NoError(t, err)
+		repo := NewCrashReportDiskRepositoryWithDir(dir)
+
+		report := Report{
+			Time:   time.Date(2014, 1, 2, 3, 4, 5, 0, time.UTC),
+			Target: "https://api.secret-company.example.com",
+			Error:  "could not find app secret-app",
+		}
+
+		path, err := repo.Save(report)
+		assert.NoError(t, err)
+
+		err = repo.Redact(filepath.Base(path), []string{"secret-company", "secret-app"})
+		assert.NoError(t, err)
+
+		contents, err := repo.Read(filepath.Base(path))
+		assert.NoError(t, err)
+		assert.Contains(t, contents, "https://api.[PRIVATE DATA HIDDEN].example.com")
+		assert.Contains(t, contents, "could not find app [PRIVATE DATA HIDDEN]")
+		assert.NotContains(t, contents, "secret")
+	})
+}
+
+func TestReadingAReportRejectsPaths(t *testing.T) {
+	repo := NewCrashReportDiskRepositoryWithDir("/tmp/crash")
+
+	_, err := repo.Read("../config.json")
+	assert.Error(t, err)
+}
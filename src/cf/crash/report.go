@@ -0,0 +1,141 @@
+package crash
+
+import (
+	"cf"
+	"cf/configuration"
+	"cf/net"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+type Report struct {
+	Time        time.Time
+	Error       string
+	StackTrace  string
+	CommandLine string
+	Version     string
+	OS          string
+	Target      string
+	ApiVersion  string
+	Exchanges   []string
+}
+
+func NewReport(panicValue interface{}, stackTrace string, args []string, config *configuration.Configuration) (report Report) {
+	report.Time = time.Now()
+	report.Error = fmt.Sprintf("%v", panicValue)
+	report.StackTrace = stackTrace
+	report.CommandLine = strings.Join(SanitizeArgs(args), " ")
+	report.Version = cf.Version
+	report.OS = runtime.GOOS + "/" + runtime.GOARCH
+	report.Exchanges = net.RecentExchanges()
+
+	if config != nil {
+		report.Target = config.Target
+		report.ApiVersion = config.ApiVersion
+	}
+	return
+}
+
+func (report Report) String() string {
+	sections := []string{
+		fmt.Sprintf("Time:        %s", report.Time.Format(time.RFC3339)),
+		fmt.Sprintf("Command:     %s", report.CommandLine),
+		fmt.Sprintf("CLI version: %s", report.Version),
+		fmt.Sprintf("OS:          %s", report.OS),
+		fmt.Sprintf("API target:  %s", report.Target),
+		fmt.Sprintf("API version: %s", report.ApiVersion),
+		"",
+		"ERROR:",
+		report.Error,
+		"",
+		"STACK TRACE:",
+		report.StackTrace,
+		"",
+		fmt.Sprintf("RECENT HTTP EXCHANGES (%d):", len(report.Exchanges)),
+	}
+
+	for _, exchange := range report.Exchanges {
+		sections = append(sections, "", exchange)
+	}
+
+	return strings.Join(sections, "\n") + "\n"
+}
+
+// positional arguments, counted from the command name, that hold secrets
+var secretArgsByCommand = map[string][]int{
+	"auth":                      {2},
+	"create-service-auth-token": {3},
+	"create-service-broker":     {3},
+	"create-user":               {2},
+	"update-service-auth-token": {3},
+	"update-service-broker":     {3},
+}
+
+// flags whose values hold secrets
+var secretFlagsByCommand = map[string][]string{
+	"create-user-provided-service": {"p"},
+	"cups":                         {"p"},
+	"l":                            {"p"},
+	"login":                        {"p"},
+	"update-user-provided-service": {"p"},
+	"uups":                         {"p"},
+}
+
+func SanitizeArgs(args []string) (sanitized []string) {
+	sanitized = make([]string, len(args))
+	copy(sanitized, args)
+
+	if len(sanitized) < 2 {
+		return
+	}
+
+	cmdName := sanitized[1]
+	secretFlags := secretFlagsByCommand[cmdName]
+
+	position := 0
+	for index := 2; index < len(sanitized); index++ {
+		arg := sanitized[index]
+
+		if strings.HasPrefix(arg, "-") {
+			flagName := strings.TrimLeft(arg, "-")
+			if parts := strings.SplitN(flagName, "=", 2); len(parts) == 2 {
+				if containsString(secretFlags, parts[0]) {
+					sanitized[index] = arg[:strings.Index(arg, "=")+1] + net.PRIVATE_DATA_PLACEHOLDER
+				}
+				continue
+			}
+
+			if containsString(secretFlags, flagName) && index+1 < len(sanitized) {
+				index++
+				sanitized[index] = net.PRIVATE_DATA_PLACEHOLDER
+			}
+			continue
+		}
+
+		position++
+		if containsInt(secretArgsByCommand[cmdName], position) {
+			sanitized[index] = net.PRIVATE_DATA_PLACEHOLDER
+		}
+	}
+	return
+}
+
+func containsString(list []string, item string) bool {
+	for _, value := range list {
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(list []int, item int) bool {
+	for _, value := range list {
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
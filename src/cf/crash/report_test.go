@@ -0,0 +1,63 @@
+package crash_test
+
+import (
+	"cf/configuration"
+	. "cf/crash"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeArgsHidesPositionalSecrets(t *testing.T) {
+	args := SanitizeArgs([]string{"cf", "auth", "user@example.com", "my-password"})
+	assert.Equal(t, args, []string{"cf", "auth", "user@example.com", "[PRIVATE DATA HIDDEN]"})
+
+	args = SanitizeArgs([]string{"cf", "create-service-broker", "my-broker", "my-user", "my-password", "http://example.com"})
+	assert.Equal(t, args, []string{"cf", "create-service-broker", "my-broker", "my-user", "[PRIVATE DATA HIDDEN]", "http://example.com"})
+}
+
+func TestSanitizeArgsHidesSecretFlags(t *testing.T) {
+	args := SanitizeArgs([]string{"cf", "login", "-u", "user@example.com", "-p", "my-password", "-o", "my-org"})
+	assert.Equal(t, args, []string{"cf", "login", "-u", "user@example.com", "-p", "[PRIVATE DATA HIDDEN]", "-o", "my-org"})
+
+	args = SanitizeArgs([]string{"cf", "cups", "my-service", "--p={\"password\":\"secret\"}"})
+	assert.Equal(t, args, []string{"cf", "cups", "my-service", "--p=[PRIVATE DATA HIDDEN]"})
+}
+
+func TestSanitizeArgsLeavesOtherCommandsAlone(t *testing.T) {
+	args := SanitizeArgs([]string{"cf", "push", "my-app", "-p", "path/to/app"})
+	assert.Equal(t, args, []string{"cf", "push", "my-app", "-p", "path/to/app"})
+
+	args = SanitizeArgs([]string{"cf"})
+	assert.Equal(t, args, []string{"cf"})
+}
+
+func TestNewReport(t *testing.T) {
+	config := &configuration.Configuration{
+		Target:     "https://api.example.com",
+		ApiVersion: "2.1.0",
+	}
+
+	report := NewReport(errors.New("oh no"), "the stack trace", []string{"cf", "auth", "user", "password"}, config)
+
+	assert.Equal(t, report.Error, "oh no")
+	assert.Equal(t, report.CommandLine, "cf auth user [PRIVATE DATA HIDDEN]")
+	assert.Equal(t, report.Target, "https://api.example.com")
+	assert.Equal(t, report.ApiVersion, "2.1.0")
+	assert.NotEmpty(t, report.Version)
+	assert.NotEmpty(t, report.OS)
+
+	output := report.String()
+	assert.Contains(t, output, "oh no")
+	assert.Contains(t, output, "the stack trace")
+	assert.Contains(t, output, "2.1.0")
+	assert.False(t, strings.Contains(output, "password"))
+}
+
+func TestNewReportWithoutConfig(t *testing.T) {
+	report := NewReport("oh no", "the stack trace", []string{"cf", "apps"}, nil)
+
+	assert.Equal(t, report.Error, "oh no")
+	assert.Equal(t, report.Target, "")
+}
@@ -40,6 +40,14 @@ type Organization struct {
 	Domains []DomainFields
 }
 
+// OrganizationResourceCounts is how many of each resource all the spaces of
+// an org hold together.
+type OrganizationResourceCounts struct {
+	Apps             int
+	ServiceInstances int
+	Routes           int
+}
+
 type SpaceFields struct {
 	BasicFields
 }
@@ -84,9 +92,12 @@ func (model Application) ToParams() (params AppParams) {
 		"state":      strings.ToUpper(model.State),
 		"stack_guid": model.Stack.Guid,
 		"space_guid": model.SpaceGuid,
-		"env":        generic.NewMap(model.EnvironmentVars),
 	}))
 
+	// an empty env would remove every variable the app has
+	if len(model.EnvironmentVars) > 0 {
+		params.Set("env", generic.NewMap(model.EnvironmentVars))
+	}
 	return
 }
 
@@ -187,6 +198,16 @@ type Domain struct {
 	DomainFields
 }
 
+// AppEnvironment is everything an app receives in its environment, grouped
+// by where it comes from.
+type AppEnvironment struct {
+	UserProvided        map[string]interface{}
+	SystemProvided      map[string]interface{}
+	ApplicationProvided map[string]interface{}
+	Running             map[string]interface{}
+	Staging             map[string]interface{}
+}
+
 type EventFields struct {
 	InstanceIndex   int
 	Timestamp       time.Time
@@ -194,6 +215,20 @@ type EventFields struct {
 	ExitStatus      int
 }
 
+type AuditEventFields struct {
+	Guid             string
+	Type             string
+	Timestamp        time.Time
+	ActorGuid        string
+	ActorType        string
+	ActorName        string
+	ActeeGuid        string
+	ActeeType        string
+	ActeeName        string
+	SpaceGuid        string
+	OrganizationGuid string
+}
+
 type RouteFields struct {
 	Guid string
 	Host string
@@ -287,6 +322,14 @@ func (inst ServiceInstance) IsUserProvided() bool {
 	return inst.ServicePlan.Guid == ""
 }
 
+// ServiceInstanceDeclaration is a service instance an app asks for in its
+// manifest along with what to create it from when it doesn't exist yet.
+type ServiceInstanceDeclaration struct {
+	Name     string
+	Offering string
+	Plan     string
+}
+
 type ServiceBindingFields struct {
 	Guid    string
 	Url     string
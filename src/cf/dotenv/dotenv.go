@@ -0,0 +1,152 @@
+// Package dotenv reads and writes env variables in the .env file format:
+// one NAME=VALUE per line, with optional quoting, comments and export prefix.
+package dotenv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// Parse reads variables until the end of reader. Later definitions of a name
+// win over earlier ones.
+func Parse(reader io.Reader) (vars map[string]string, err error) {
+	vars = map[string]string{}
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			err = errors.New(fmt.Sprintf("line %d: expected NAME=VALUE", lineNumber))
+			return
+		}
+
+		name := strings.TrimSpace(parts[0])
+		if !validName.MatchString(name) {
+			err = errors.New(fmt.Sprintf("line %d: invalid variable name '%s'", lineNumber, name))
+			return
+		}
+
+		var value string
+		value, err = parseValue(strings.TrimSpace(parts[1]))
+		if err != nil {
+			err = errors.New(fmt.Sprintf("line %d: %s", lineNumber, err.Error()))
+			return
+		}
+
+		vars[name] = value
+	}
+
+	err = scanner.Err()
+	return
+}
+
+func parseValue(raw string) (value string, err error) {
+	switch {
+	case strings.HasPrefix(raw, "'"):
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			err = errors.New("unterminated single quoted value")
+			return
+		}
+		value = raw[1 : end+1]
+		err = checkTrailing(raw[end+2:])
+	case strings.HasPrefix(raw, `"`):
+		var end int
+		value, end, err = unescapeDoubleQuoted(raw)
+		if err != nil {
+			return
+		}
+		err = checkTrailing(raw[end:])
+	default:
+		if index := strings.Index(raw, " #"); index >= 0 {
+			raw = raw[:index]
+		}
+		value = strings.TrimSpace(raw)
+	}
+	return
+}
+
+// unescapeDoubleQuoted returns the value of the double quoted string that
+// raw starts with, and the index just past its closing quote.
+func unescapeDoubleQuoted(raw string) (value string, end int, err error) {
+	chars := []byte{}
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '"':
+			value = string(chars)
+			end = i + 1
+			return
+		case '\\':
+			i++
+			if i == len(raw) {
+				break
+			}
+			switch raw[i] {
+			case 'n':
+				chars = append(chars, '\n')
+			case 't':
+				chars = append(chars, '\t')
+			case 'r':
+				chars = append(chars, '\r')
+			default:
+				chars = append(chars, raw[i])
+			}
+		default:
+			chars = append(chars, raw[i])
+		}
+	}
+
+	err = errors.New("unterminated double quoted value")
+	return
+}
+
+func checkTrailing(rest string) (err error) {
+	rest = strings.TrimSpace(rest)
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		err = errors.New("unexpected text after quoted value")
+	}
+	return
+}
+
+// Write writes vars sorted by name, quoting the values that need it so that
+// Parse reads them back unchanged.
+func Write(writer io.Writer, vars map[string]string) (err error) {
+	names := []string{}
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		_, err = fmt.Fprintf(writer, "%s=%s\n", name, quoteValue(vars[name]))
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+var doubleQuoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func quoteValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n\"'#\\") {
+		return value
+	}
+	return `"` + doubleQuoteEscaper.Replace(value) + `"`
+}
@@ -0,0 +1,70 @@
+package dotenv
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	vars, err := Parse(strings.NewReader(`
+# database settings
+DATABASE_URL=mysql://example.com/my-db
+export API_KEY = abc123
+GREETING="hello \"world\"\nbye"
+LITERAL='no \n escapes here' # trailing comment
+UNQUOTED=some value # trailing comment
+EMPTY=
+DATABASE_URL=mysql://example.com/other-db
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, vars, map[string]string{
+		"DATABASE_URL": "mysql://example.com/other-db",
+		"API_KEY":      "abc123",
+		"GREETING":     "hello \"world\"\nbye",
+		"LITERAL":      `no \n escapes here`,
+		"UNQUOTED":     "some value",
+		"EMPTY":        "",
+	})
+}
+
+func TestParseReportsTheLineOfAnError(t *testing.T) {
+	_, err := Parse(strings.NewReader("GOOD=1\nNOT A VARIABLE\n"))
+	assert.Equal(t, err.Error(), "line 2: expected NAME=VALUE")
+
+	_, err = Parse(strings.NewReader("1BAD=1\n"))
+	assert.Equal(t, err.Error(), "line 1: invalid variable name '1BAD'")
+
+	_, err = Parse(strings.NewReader("OPEN=\"never closed\n"))
+	assert.Equal(t, err.Error(), "line 1: unterminated double quoted value")
+
+	_, err = Parse(strings.NewReader("OPEN='closed' extra\n"))
+	assert.Equal(t, err.Error(), "line 1: unexpected text after quoted value")
+}
+
+func TestWriteRoundTrips(t *testing.T) {
+	vars := map[string]string{
+		"PLAIN":     "value",
+		"SPACES":    "two words",
+		"QUOTES":    `say "hi"`,
+		"MULTILINE": "one\ntwo",
+		"EMPTY":     "",
+	}
+
+	buffer := &bytes.Buffer{}
+	err := Write(buffer, vars)
+	assert.NoError(t, err)
+
+	assert.Equal(t, buffer.String(), `EMPTY=""
+MULTILINE="one\ntwo"
+PLAIN=value
+QUOTES="say \"hi\""
+SPACES="two words"
+`)
+
+	parsed, err := Parse(buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, parsed, vars)
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"net/http"
+)
+
+// Exit codes returned by cf. They are documented in the app help.
+const (
+	EXIT_FAILED        = 1
+	EXIT_USAGE         = 2
+	EXIT_NOT_LOGGED_IN = 3
+	EXIT_NOT_FOUND     = 4
+	EXIT_PERMISSION    = 5
+	EXIT_SERVER        = 6
+	EXIT_TIMEOUT       = 7
+)
+
+type Error interface {
+	error
+	ExitCode() int
+}
+
+type cfError struct {
+	message  string
+	exitCode int
+}
+
+func (err cfError) Error() string {
+	return err.message
+}
+
+func (err cfError) ExitCode() int {
+	return err.exitCode
+}
+
+func New(message string) Error {
+	return cfError{message, EXIT_FAILED}
+}
+
+func NewUsageError(message string) Error {
+	return cfError{message, EXIT_USAGE}
+}
+
+func NewNotLoggedInError(message string) Error {
+	return cfError{message, EXIT_NOT_LOGGED_IN}
+}
+
+func NewNotFoundError(message string) Error {
+	return cfError{message, EXIT_NOT_FOUND}
+}
+
+func NewPermissionError(message string) Error {
+	return cfError{message, EXIT_PERMISSION}
+}
+
+func NewServerError(message string) Error {
+	return cfError{message, EXIT_SERVER}
+}
+
+func NewTimeoutError(message string) Error {
+	return cfError{message, EXIT_TIMEOUT}
+}
+
+func NewErrorFromStatusCode(statusCode int, message string) Error {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return NewNotLoggedInError(message)
+	case statusCode == http.StatusForbidden:
+		return NewPermissionError(message)
+	case statusCode == http.StatusNotFound:
+		return NewNotFoundError(message)
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusGatewayTimeout:
+		return NewTimeoutError(message)
+	case statusCode >= 500:
+		return NewServerError(message)
+	}
+	return New(message)
+}
+
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if cfErr, ok := err.(Error); ok {
+		return cfErr.ExitCode()
+	}
+	return EXIT_FAILED
+}
@@ -0,0 +1,30 @@
+package errors_test
+
+import (
+	. "cf/errors"
+	goerrors "errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExitCodes(t *testing.T) {
+	assert.Equal(t, ExitCode(nil), 0)
+	assert.Equal(t, ExitCode(goerrors.New("plain error")), EXIT_FAILED)
+	assert.Equal(t, ExitCode(New("failed")), EXIT_FAILED)
+	assert.Equal(t, ExitCode(NewUsageError("usage")), EXIT_USAGE)
+	assert.Equal(t, ExitCode(NewNotLoggedInError("not logged in")), EXIT_NOT_LOGGED_IN)
+	assert.Equal(t, ExitCode(NewNotFoundError("not found")), EXIT_NOT_FOUND)
+	assert.Equal(t, ExitCode(NewPermissionError("forbidden")), EXIT_PERMISSION)
+	assert.Equal(t, ExitCode(NewServerError("server")), EXIT_SERVER)
+	assert.Equal(t, ExitCode(NewTimeoutError("timeout")), EXIT_TIMEOUT)
+}
+
+func TestNewErrorFromStatusCode(t *testing.T) {
+	assert.Equal(t, NewErrorFromStatusCode(401, "msg").ExitCode(), EXIT_NOT_LOGGED_IN)
+	assert.Equal(t, NewErrorFromStatusCode(403, "msg").ExitCode(), EXIT_PERMISSION)
+	assert.Equal(t, NewErrorFromStatusCode(404, "msg").ExitCode(), EXIT_NOT_FOUND)
+	assert.Equal(t, NewErrorFromStatusCode(504, "msg").ExitCode(), EXIT_TIMEOUT)
+	assert.Equal(t, NewErrorFromStatusCode(500, "msg").ExitCode(), EXIT_SERVER)
+	assert.Equal(t, NewErrorFromStatusCode(400, "msg").ExitCode(), EXIT_FAILED)
+	assert.Equal(t, NewErrorFromStatusCode(400, "msg").Error(), "msg")
+}
@@ -0,0 +1,125 @@
+package manifest
+
+import (
+	"bytes"
+	"cf"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AppManifest is a manifest built from apps that already exist, so that
+// pushing it again reproduces them. It only uses keys from manifestKeys.
+type AppManifest struct {
+	applications []appManifestEntry
+}
+
+type appManifestEntry struct {
+	app      cf.Application
+	routes   []cf.RouteSummary
+	services []string
+}
+
+func NewAppManifest() *AppManifest {
+	return &AppManifest{}
+}
+
+// AddApplication adds app to the manifest with the routes it is mapped to and
+// the names of the service instances bound to it.
+func (m *AppManifest) AddApplication(app cf.Application, routes []cf.RouteSummary, services []string) {
+	m.applications = append(m.applications, appManifestEntry{
+		app:      app,
+		routes:   routes,
+		services: services,
+	})
+}
+
+// YAML writes the manifest in the block style manifests are usually written
+// in, with the keys of each app always in the same order.
+func (m *AppManifest) YAML() []byte {
+	buffer := new(bytes.Buffer)
+	buffer.WriteString("---\napplications:\n")
+
+	for _, entry := range m.applications {
+		app := entry.app
+		prefix := "- "
+		writeLine := func(format string, args ...interface{}) {
+			buffer.WriteString(prefix + fmt.Sprintf(format, args...) + "\n")
+			prefix = "  "
+		}
+
+		writeLine("name: %s", yamlScalar(app.Name))
+		if app.Memory > 0 {
+			writeLine("memory: %dM", app.Memory)
+		}
+		if app.DiskQuota > 0 {
+			writeLine("disk_quota: %dM", app.DiskQuota)
+		}
+		if app.InstanceCount > 0 {
+			writeLine("instances: %d", app.InstanceCount)
+		}
+		if app.BuildpackUrl != "" {
+			writeLine("buildpack: %s", yamlScalar(app.BuildpackUrl))
+		}
+		if app.Command != "" {
+			writeLine("command: %s", yamlScalar(app.Command))
+		}
+		if app.Stack.Name != "" {
+			writeLine("stack: %s", yamlScalar(app.Stack.Name))
+		}
+
+		if len(app.EnvironmentVars) > 0 {
+			writeLine("env:")
+			names := []string{}
+			for name, _ := range app.EnvironmentVars {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				writeLine("  %s: %s", yamlScalar(name), yamlScalar(app.EnvironmentVars[name]))
+			}
+		}
+
+		if len(entry.services) > 0 {
+			writeLine("services:")
+			for _, service := range entry.services {
+				writeLine("- %s", yamlScalar(service))
+			}
+		}
+
+		if len(entry.routes) == 0 {
+			writeLine("no-route: true")
+			continue
+		}
+
+		writeLine("routes:")
+		for _, route := range entry.routes {
+			writeLine("- %s", yamlScalar(route.URL()))
+		}
+	}
+	return buffer.Bytes()
+}
+
+var plainYAMLScalarRegex = regexp.MustCompile(`^[A-Za-z0-9_./][A-Za-z0-9_./-]*$`)
+
+var yamlKeywords = map[string]bool{
+	"null": true, "true": true, "false": true,
+	"yes": true, "no": true, "on": true, "off": true,
+	"y": true, "n": true,
+}
+
+// yamlScalar leaves value unquoted when YAML reads it back as the same
+// string, and quotes it otherwise.
+func yamlScalar(value string) string {
+	if !plainYAMLScalarRegex.MatchString(value) || yamlKeywords[strings.ToLower(value)] {
+		return strconv.Quote(value)
+	}
+
+	_, err := strconv.ParseFloat(value, 64)
+	if err == nil {
+		return strconv.Quote(value)
+	}
+	return value
+}
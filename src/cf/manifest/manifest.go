@@ -7,28 +7,57 @@ import (
 	"fmt"
 	"generic"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 var manifestKeys = map[string]func(appParams, yamlMap generic.Map, key string, errs *ManifestErrors){
-	"buildpack":  setStringVal,
-	"disk_quota": setStringVal,
-	"domain":     setStringVal,
-	"host":       setStringVal,
-	"name":       setStringVal,
-	"path":       setStringVal,
-	"stack":      setStringVal,
-	"command":    setStringOrNullVal,
-	"memory":     setBytesVal,
-	"instances":  setIntVal,
-	"timeout":    setTimeoutVal,
-	"no-route":   setBoolVal,
-	"services":   setSliceOrEmptyVal,
-	"env":        setEnvVarOrEmptyMap,
+	"buildpack":    setStringVal,
+	"disk_quota":   setBytesVal,
+	"domain":       setStringVal,
+	"domains":      setSliceOrEmptyVal,
+	"host":         setStringVal,
+	"hosts":        setSliceOrEmptyVal,
+	"name":         setStringVal,
+	"path":         setStringVal,
+	"stack":        setStringVal,
+	"command":      setStringOrNullVal,
+	"memory":       setBytesVal,
+	"instances":    setIntVal,
+	"timeout":      setTimeoutVal,
+	"no-route":     setBoolVal,
+	"random-route": setBoolVal,
+	"routes":       setSliceOrEmptyVal,
+	"services":     setServicesVal,
+	"env":          setEnvVarOrEmptyMap,
+}
+
+// manifestParamKeys lists the manifest keys that are stored in the app
+// params under a different name.
+var manifestParamKeys = map[string]string{
+	"timeout": "health_check_timeout",
 }
 
 type Manifest struct {
 	Applications cf.AppSet
+	Sources      []ParamSources
+}
+
+// ParamSources tells where each of an app's params was set in the manifest,
+// keyed the same way as the params.
+type ParamSources map[string]string
+
+// SourceOf describes where the manifest set key for the app at index. It
+// falls back to "manifest" when the manifest wasn't read from files.
+func (m *Manifest) SourceOf(index int, key string) string {
+	if index < len(m.Sources) {
+		source, ok := m.Sources[index][key]
+		if ok {
+			return source
+		}
+	}
+	return "manifest"
 }
 
 func NewEmptyManifest() (m *Manifest) {
@@ -111,6 +140,104 @@ func mapToAppSet(data generic.Map) (appSet cf.AppSet, errs ManifestErrors) {
 	return
 }
 
+// sourceLabels mirrors a manifest file with each value replaced by where it
+// was set, so that merging the labels the way the manifest itself is merged
+// tells which file, and which part of it, each app param came from.
+func sourceLabels(data generic.Map, fileName string) (labels generic.Map) {
+	labels = generic.NewMap()
+	generic.Each(data, func(key, value interface{}) {
+		switch key {
+		case "inherit":
+		case "applications":
+			appMaps, ok := value.([]interface{})
+			if !ok {
+				return
+			}
+
+			appLabels := []interface{}{}
+			for _, appData := range appMaps {
+				if generic.IsMappable(appData) {
+					appData = labelValue(appData, fileName)
+				}
+				appLabels = append(appLabels, appData)
+			}
+			labels.Set(key, appLabels)
+		default:
+			labels.Set(key, labelValue(value, fileName+" (global)"))
+		}
+	})
+	return
+}
+
+func labelValue(value interface{}, label string) interface{} {
+	switch {
+	case generic.IsMappable(value):
+		labels := generic.NewMap()
+		generic.Each(generic.NewMap(value), func(key, item interface{}) {
+			labels.Set(key, labelValue(item, label))
+		})
+		return labels
+	case generic.IsSliceable(value):
+		return []interface{}{label}
+	}
+	return label
+}
+
+func mapToAppSources(labels generic.Map) (sources []ParamSources) {
+	appMaps, ok := labels.Get("applications").([]interface{})
+	if !ok {
+		return
+	}
+	labels.Delete("applications")
+
+	for _, appLabels := range appMaps {
+		if !generic.IsMappable(appLabels) {
+			continue
+		}
+
+		appMap := generic.DeepMerge(labels, generic.NewMap(appLabels))
+		paramSources := ParamSources{}
+		for key, _ := range manifestKeys {
+			if !appMap.Has(key) {
+				continue
+			}
+
+			paramKey, renamed := manifestParamKeys[key]
+			if !renamed {
+				paramKey = key
+			}
+			labels := collectLabels(appMap.Get(key), []string{})
+			sort.Strings(labels)
+			paramSources[paramKey] = strings.Join(labels, ", ")
+		}
+		sources = append(sources, paramSources)
+	}
+	return
+}
+
+func collectLabels(value interface{}, labels []string) []string {
+	switch value := value.(type) {
+	case string:
+		for _, label := range labels {
+			if label == value {
+				return labels
+			}
+		}
+		return append(labels, value)
+	case []interface{}:
+		for _, item := range value {
+			labels = collectLabels(item, labels)
+		}
+	default:
+		if generic.IsMappable(value) {
+			generic.Each(generic.NewMap(value), func(_, item interface{}) {
+				labels = collectLabels(item, labels)
+			})
+		}
+	}
+	return labels
+}
+
 func mapToAppParams(yamlMap generic.Map) (appParams cf.AppParams, errs ManifestErrors) {
 	appParams = cf.NewEmptyAppParams()
 
@@ -163,7 +290,13 @@ func setStringOrNullVal(appMap, yamlMap generic.Map, key string, errs *ManifestE
 }
 
 func setBytesVal(appMap, yamlMap generic.Map, key string, errs *ManifestErrors) {
-	value, err := formatters.ToMegabytes(yamlMap.Get(key).(string))
+	stringVal, ok := yamlMap.Get(key).(string)
+	if !ok {
+		*errs = append(*errs, errors.New(fmt.Sprintf("Expected %s to be a size such as 256M.", key)))
+		return
+	}
+
+	value, err := formatters.ToMegabytes(stringVal)
 	if err != nil {
 		*errs = append(*errs, errors.New(fmt.Sprintf("Unexpected value for %s :\n%s", key, err.Error())))
 		return
@@ -289,6 +422,73 @@ func setSliceOrEmptyVal(appMap, yamlMap generic.Map, key string, errs *ManifestE
 	return
 }
 
+// setServicesVal reads services given either as the names of existing
+// instances or as dictionaries with a name, service and plan. The names of
+// both go into services, and the dictionaries into declared_services so push
+// can create the instances that are missing.
+func setServicesVal(appMap, yamlMap generic.Map, key string, errs *ManifestErrors) {
+	input, ok := yamlMap.Get(key).([]interface{})
+	if !ok {
+		*errs = append(*errs, errors.New(fmt.Sprintf("Expected %s to be a list of service instance names or dictionaries.", key)))
+		return
+	}
+
+	names := []string{}
+	declarations := []cf.ServiceInstanceDeclaration{}
+	for _, value := range input {
+		name, ok := value.(string)
+		if ok {
+			names = append(names, name)
+			continue
+		}
+
+		if !generic.IsMappable(value) {
+			*errs = append(*errs, errors.New(fmt.Sprintf("Expected %s to be a list of service instance names or dictionaries.", key)))
+			return
+		}
+
+		declaration, err := serviceInstanceDeclaration(generic.NewMap(value))
+		if err != nil {
+			*errs = append(*errs, err)
+			return
+		}
+		names = append(names, declaration.Name)
+		declarations = append(declarations, declaration)
+	}
+
+	appMap.Set(key, names)
+	appMap.Set("declared_services", declarations)
+}
+
+func serviceInstanceDeclaration(serviceMap generic.Map) (declaration cf.ServiceInstanceDeclaration, err error) {
+	fields := map[string]*string{
+		"name":    &declaration.Name,
+		"service": &declaration.Offering,
+		"plan":    &declaration.Plan,
+	}
+
+	generic.Each(serviceMap, func(key, value interface{}) {
+		field, known := fields[key.(string)]
+		if !known {
+			err = errors.New(fmt.Sprintf("Unknown key '%s' in services, expected name, service and plan", key))
+			return
+		}
+
+		stringValue, ok := value.(string)
+		if ok {
+			*field = stringValue
+		}
+	})
+	if err != nil {
+		return
+	}
+
+	if declaration.Name == "" || declaration.Offering == "" || declaration.Plan == "" {
+		err = errors.New("Expected each service given as a dictionary to have a name, service and plan.")
+	}
+	return
+}
+
 func validateEnvVars(input interface{}) (errs ManifestErrors) {
 	envVars := generic.NewMap(input)
 	generic.Each(envVars, func(key, value interface{}) {
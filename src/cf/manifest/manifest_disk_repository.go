@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"errors"
+	"fmt"
 	"generic"
 	"github.com/cloudfoundry/gamble"
 	"io"
@@ -13,6 +14,8 @@ import (
 type ManifestRepository interface {
 	ReadManifest(path string) (manifest *Manifest, errs ManifestErrors)
 	ManifestPath(userSpecifiedPath string) (manifestDir, manifestFilename string, err error)
+	ValidateManifest(path string) (problems []ManifestProblem, err error)
+	WriteManifest(path string, appManifest *AppManifest, overwrite bool) (err error)
 }
 
 type ManifestDiskRepository struct {
@@ -25,7 +28,7 @@ func NewManifestDiskRepository() (repo ManifestRepository) {
 func (repo ManifestDiskRepository) ReadManifest(path string) (m *Manifest, errs ManifestErrors) {
 	m = NewEmptyManifest()
 
-	mapp, err := repo.readAllYAMLFiles(path)
+	mapp, sources, err := repo.readAllYAMLFiles(path, filepath.Dir(path))
 	if err != nil {
 		errs = append(errs, err)
 		return
@@ -35,10 +38,15 @@ func (repo ManifestDiskRepository) ReadManifest(path string) (m *Manifest, errs
 	if !errs.Empty() {
 		return
 	}
+
+	m.Sources = mapToAppSources(sources)
 	return
 }
 
-func (repo ManifestDiskRepository) readAllYAMLFiles(path string) (mergedMap generic.Map, err error) {
+// readAllYAMLFiles reads the manifest at path merged with the ones it
+// inherits from. Alongside it comes the same structure with each value
+// replaced by the file it was read from, relative to rootDir.
+func (repo ManifestDiskRepository) readAllYAMLFiles(path, rootDir string) (mergedMap, mergedSources generic.Map, err error) {
 	file, err := os.Open(filepath.Clean(path))
 	if err != nil {
 		return
@@ -50,8 +58,15 @@ func (repo ManifestDiskRepository) readAllYAMLFiles(path string) (mergedMap gene
 		return
 	}
 
+	sourceName, relErr := filepath.Rel(rootDir, path)
+	if relErr != nil {
+		sourceName = path
+	}
+	sources := sourceLabels(mapp, filepath.ToSlash(sourceName))
+
 	if !mapp.Has("inherit") {
 		mergedMap = mapp
+		mergedSources = sources
 		return
 	}
 
@@ -65,12 +80,99 @@ func (repo ManifestDiskRepository) readAllYAMLFiles(path string) (mergedMap gene
 		inheritedPath = filepath.Join(filepath.Dir(path), inheritedPath)
 	}
 
-	inheritedMap, err := repo.readAllYAMLFiles(inheritedPath)
+	inheritedMap, inheritedSources, err := repo.readAllYAMLFiles(inheritedPath, rootDir)
 	if err != nil {
 		return
 	}
 
 	mergedMap = generic.DeepMerge(inheritedMap, mapp)
+	mergedSources = generic.DeepMerge(inheritedSources, sources)
+	return
+}
+
+// ValidateManifest checks the manifest at path and the ones it inherits from,
+// returning every problem found rather than stopping at the first one. An
+// error means the manifest itself couldn't be read.
+func (repo ManifestDiskRepository) ValidateManifest(path string) (problems []ManifestProblem, err error) {
+	contents, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return
+	}
+
+	files, problems := repo.readManifestChain(path, filepath.Dir(path), string(contents), map[string]bool{})
+	problems = append(problems, validateManifestFiles(files)...)
+	return
+}
+
+// readManifestChain parses the manifest at path and, following inherit, the
+// ones it builds on. Files that can't be read or parsed are reported as
+// problems where they are referenced.
+func (repo ManifestDiskRepository) readManifestChain(path, rootDir, contents string, visited map[string]bool) (files []manifestFile, problems []ManifestProblem) {
+	fileName, relErr := filepath.Rel(rootDir, path)
+	if relErr != nil {
+		fileName = path
+	}
+	fileName = filepath.ToSlash(fileName)
+
+	absPath, err := filepath.Abs(path)
+	if err == nil {
+		visited[absPath] = true
+	}
+
+	document, err := gamble.Parse(contents)
+	if err != nil {
+		problems = append(problems, ManifestProblem{File: fileName, Message: err.Error()})
+		return
+	}
+	if document == nil {
+		document = map[string]interface{}{}
+	}
+	if !generic.IsMappable(document) {
+		problems = append(problems, ManifestProblem{File: fileName, Message: "Expected the manifest to be a dictionary"})
+		return
+	}
+
+	file := manifestFile{
+		name:  fileName,
+		data:  generic.NewMap(document),
+		lines: findManifestLines(contents),
+	}
+	files = append(files, file)
+
+	if !file.data.Has("inherit") {
+		return
+	}
+
+	inheritProblem := ManifestProblem{File: fileName, Line: file.lines.keys["inherit"]}
+
+	inheritedPath, ok := file.data.Get("inherit").(string)
+	if !ok {
+		inheritProblem.Message = "invalid inherit path in manifest"
+		problems = append(problems, inheritProblem)
+		return
+	}
+
+	if !filepath.IsAbs(inheritedPath) {
+		inheritedPath = filepath.Join(filepath.Dir(path), inheritedPath)
+	}
+
+	absInheritedPath, err := filepath.Abs(inheritedPath)
+	if err == nil && visited[absInheritedPath] {
+		inheritProblem.Message = fmt.Sprintf("inherit loops back to %s", inheritedPath)
+		problems = append(problems, inheritProblem)
+		return
+	}
+
+	inheritedContents, err := ioutil.ReadFile(inheritedPath)
+	if err != nil {
+		inheritProblem.Message = fmt.Sprintf("Error reading inherited manifest: %s", err)
+		problems = append(problems, inheritProblem)
+		return
+	}
+
+	inheritedFiles, inheritedProblems := repo.readManifestChain(inheritedPath, rootDir, string(inheritedContents), visited)
+	files = append(files, inheritedFiles...)
+	problems = append(problems, inheritedProblems...)
 	return
 }
 
@@ -115,3 +217,27 @@ func (repo ManifestDiskRepository) ManifestPath(userSpecifiedPath string) (manif
 	fileInfo, err = os.Stat(userSpecifiedPath)
 	return
 }
+
+// WriteManifest writes the manifest readable by the current user only, since
+// it holds the apps' env. An existing file is left alone unless overwrite is
+// set; os.IsExist tells that case apart from other errors.
+func (repo ManifestDiskRepository) WriteManifest(path string, appManifest *AppManifest, overwrite bool) (err error) {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	err = file.Chmod(0600)
+	if err != nil {
+		return
+	}
+
+	_, err = file.Write(appManifest.YAML())
+	return
+}
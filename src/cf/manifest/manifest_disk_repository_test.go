@@ -1,9 +1,12 @@
 package manifest_test
 
 import (
+	"cf"
 	. "cf/manifest"
+	"fileutils"
 	"generic"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -87,6 +90,172 @@ func TestManifestWithInheritance(t *testing.T) {
 	assert.Equal(t, services, []string{"base-service", "foo-service"})
 }
 
+func TestManifestWithInheritanceKnowsWhereParamsCameFrom(t *testing.T) {
+	repo := NewManifestDiskRepository()
+	m, err := repo.ReadManifest("../../fixtures/inherited-manifest.yml")
+	assert.NoError(t, err)
+
+	assert.Equal(t, m.SourceOf(0, "name"), "base-manifest.yml")
+	assert.Equal(t, m.SourceOf(0, "services"), "base-manifest.yml (global)")
+	assert.Equal(t, m.SourceOf(0, "env"), "base-manifest.yml (global), inherited-manifest.yml (global)")
+
+	assert.Equal(t, m.SourceOf(1, "name"), "inherited-manifest.yml")
+	assert.Equal(t, m.SourceOf(1, "services"), "base-manifest.yml (global), inherited-manifest.yml")
+}
+
+func TestValidateManifestReportsEveryProblemWithItsLocation(t *testing.T) {
+	repo := NewManifestDiskRepository()
+	problems, err := repo.ValidateManifest("../../fixtures/invalid-manifest.yml")
+	assert.NoError(t, err)
+
+	messages := []string{}
+	for _, problem := range problems {
+		messages = append(messages, problem.String())
+	}
+
+	assert.Equal(t, len(messages), 6)
+	assert.Contains(t, messages[0], "invalid-manifest.yml:3: Unexpected value for memory")
+	assert.Equal(t, messages[1], "invalid-manifest.yml:6: app web: Unknown key 'instance', did you mean 'instances'?")
+	assert.Equal(t, messages[2], "invalid-manifest.yml:7: app web: Expected hosts to be a list of strings.")
+	assert.Contains(t, messages[3], "invalid-manifest.yml:10: app worker: ")
+	assert.Contains(t, messages[3], "soon")
+	assert.Equal(t, messages[4], "invalid-manifest.yml:11: app #3: app name is a required field")
+	assert.Equal(t, messages[5], "invalid-base-manifest.yml:2: Unknown key 'buildpak', did you mean 'buildpack'?")
+}
+
+func TestValidateManifestWithAValidManifest(t *testing.T) {
+	repo := NewManifestDiskRepository()
+	problems, err := repo.ValidateManifest("../../fixtures/inherited-manifest.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, len(problems), 0)
+}
+
+func TestValidateManifestWithAMissingManifest(t *testing.T) {
+	repo := NewManifestDiskRepository()
+	_, err := repo.ValidateManifest("../../fixtures/no-such-manifest.yml")
+	assert.Error(t, err)
+}
+
+func TestValidateManifestWithAnInheritLoop(t *testing.T) {
+	fileutils.TempDir("manifest_test", func(dir string, err error) {
+		assert.NoError(t, err)
+
+		err = ioutil.WriteFile(filepath.Join(dir, "a.yml"), []byte("inherit: b.yml\nname: app\n"), 0644)
+		assert.NoError(t, err)
+		err = ioutil.WriteFile(filepath.Join(dir, "b.yml"), []byte("---\ninherit: a.yml\n"), 0644)
+		assert.NoError(t, err)
+
+		repo := NewManifestDiskRepository()
+		problems, err := repo.ValidateManifest(filepath.Join(dir, "a.yml"))
+		assert.NoError(t, err)
+		assert.Equal(t, len(problems), 1)
+		assert.Equal(t, problems[0].File, "b.yml")
+		assert.Equal(t, problems[0].Line, 2)
+		assert.Contains(t, problems[0].Message, "inherit loops back to")
+	})
+}
+
+func TestWriteManifestCanBeReadBackForPush(t *testing.T) {
+	web := cf.Application{}
+	web.Name = "web"
+	web.Memory = 256
+	web.DiskQuota = 2048
+	web.InstanceCount = 2
+	web.BuildpackUrl = "https://github.com/example/buildpack.git"
+	web.Command = "bundle exec rackup -p $PORT"
+	web.Stack = cf.Stack{}
+	web.Stack.Name = "lucid64"
+	web.EnvironmentVars = map[string]string{"RACK_ENV": "production", "PORT_OFFSET": "10", "DEBUG": "no"}
+
+	webRoute := cf.RouteSummary{}
+	webRoute.Host = "www"
+	webRoute.Domain.Name = "example.com"
+	apexRoute := cf.RouteSummary{}
+	apexRoute.Domain.Name = "example.org"
+
+	worker := cf.Application{}
+	worker.Name = "worker"
+	worker.Memory = 1024
+
+	appManifest := NewAppManifest()
+	appManifest.AddApplication(web, []cf.RouteSummary{webRoute, apexRoute}, []string{"db", "cache"})
+	appManifest.AddApplication(worker, []cf.RouteSummary{}, []string{})
+
+	fileutils.TempDir("manifest_test", func(dir string, err error) {
+		assert.NoError(t, err)
+		path := filepath.Join(dir, "manifest.yml")
+
+		repo := NewManifestDiskRepository()
+		err = repo.WriteManifest(path, appManifest, false)
+		assert.NoError(t, err)
+
+		problems, err := repo.ValidateManifest(path)
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+
+		m, errs := repo.ReadManifest(path)
+		assert.True(t, errs.Empty())
+		assert.Equal(t, len(m.Applications), 2)
+
+		app := m.Applications[0]
+		assert.Equal(t, app.Get("name"), "web")
+		assert.Equal(t, app.Get("memory"), uint64(256))
+		assert.Equal(t, app.Get("disk_quota"), uint64(2048))
+		assert.Equal(t, app.Get("instances"), 2)
+		assert.Equal(t, app.Get("buildpack"), "https://github.com/example/buildpack.git")
+		assert.Equal(t, app.Get("command"), "bundle exec rackup -p $PORT")
+		assert.Equal(t, app.Get("stack"), "lucid64")
+		assert.Equal(t, app.Get("services"), []string{"db", "cache"})
+		assert.Equal(t, app.Get("routes"), []string{"www.example.com", "example.org"})
+
+		env := app.Get("env").(generic.Map)
+		assert.Equal(t, env.Get("RACK_ENV"), "production")
+		assert.Equal(t, env.Get("PORT_OFFSET"), "10")
+		assert.Equal(t, env.Get("DEBUG"), "no")
+
+		app = m.Applications[1]
+		assert.Equal(t, app.Get("name"), "worker")
+		assert.Equal(t, app.Get("memory"), uint64(1024))
+		assert.True(t, app.Get("no-route").(bool))
+		assert.False(t, app.Has("instances"))
+	})
+}
+
+func TestWriteManifestOnlyOverwritesWhenAsked(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "web"
+	appManifest := NewAppManifest()
+	appManifest.AddApplication(app, []cf.RouteSummary{}, []string{})
+
+	fileutils.TempDir("manifest_test", func(dir string, err error) {
+		assert.NoError(t, err)
+		path := filepath.Join(dir, "manifest.yml")
+		err = ioutil.WriteFile(path, []byte("original"), 0644)
+		assert.NoError(t, err)
+
+		repo := NewManifestDiskRepository()
+		err = repo.WriteManifest(path, appManifest, false)
+		assert.True(t, os.IsExist(err))
+
+		contents, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, string(contents), "original")
+
+		err = repo.WriteManifest(path, appManifest, true)
+		assert.NoError(t, err)
+
+		contents, err = ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), "- name: web\n")
+
+		if runtime.GOOS != "windows" {
+			fileInfo, err := os.Stat(path)
+			assert.NoError(t, err)
+			assert.Equal(t, fileInfo.Mode().Perm(), os.FileMode(0600))
+		}
+	})
+}
+
 func TestPushingWithAbsoluteAppPathFromManifestFile(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		pushingWithAbsoluteWindowsPath(t)
@@ -1,6 +1,7 @@
 package manifest_test
 
 import (
+	"cf"
 	"cf/manifest"
 	"generic"
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,89 @@ func TestManifestWithGlobalAndAppSpecificProperties(t *testing.T) {
 	assert.True(t, apps[0].Get("no-route").(bool))
 }
 
+func TestManifestWithManyRoutes(t *testing.T) {
+	m, errs := manifest.NewManifest(generic.NewMap(map[string]interface{}{
+		"domains": []interface{}{"example.com", "example.org"},
+		"applications": []interface{}{
+			map[string]interface{}{
+				"name":         "shop",
+				"hosts":        []interface{}{"www", "api"},
+				"routes":       []interface{}{"shop.example.net"},
+				"random-route": true,
+			},
+		},
+	}))
+	assert.True(t, errs.Empty())
+
+	app := m.Applications[0]
+	assert.Equal(t, app.Get("domains"), []string{"example.com", "example.org"})
+	assert.Equal(t, app.Get("hosts"), []string{"www", "api"})
+	assert.Equal(t, app.Get("routes"), []string{"shop.example.net"})
+	assert.True(t, app.Get("random-route").(bool))
+}
+
+func TestManifestWithServicesDeclaredAsDictionaries(t *testing.T) {
+	m, errs := manifest.NewManifest(generic.NewMap(map[string]interface{}{
+		"services": []interface{}{"logs"},
+		"applications": []interface{}{
+			map[string]interface{}{
+				"name": "shop",
+				"services": []interface{}{
+					map[string]interface{}{"name": "db", "service": "p-mysql", "plan": "100mb"},
+				},
+			},
+		},
+	}))
+	assert.True(t, errs.Empty())
+
+	app := m.Applications[0]
+	assert.Equal(t, app.Get("services"), []string{"logs", "db"})
+	assert.Equal(t, app.Get("declared_services"), []cf.ServiceInstanceDeclaration{
+		{Name: "db", Offering: "p-mysql", Plan: "100mb"},
+	})
+}
+
+func TestManifestWithIncompleteServiceDictionaries(t *testing.T) {
+	_, errs := manifest.NewManifest(generic.NewMap(map[string]interface{}{
+		"applications": []interface{}{
+			map[string]interface{}{
+				"name": "shop",
+				"services": []interface{}{
+					map[string]interface{}{"name": "db", "service": "p-mysql"},
+				},
+			},
+		},
+	}))
+	assert.False(t, errs.Empty())
+	assert.Contains(t, errs.Error(), "name, service and plan")
+
+	_, errs = manifest.NewManifest(generic.NewMap(map[string]interface{}{
+		"applications": []interface{}{
+			map[string]interface{}{
+				"name": "shop",
+				"services": []interface{}{
+					map[string]interface{}{"name": "db", "service": "p-mysql", "plan": "100mb", "plans": "free"},
+				},
+			},
+		},
+	}))
+	assert.False(t, errs.Empty())
+	assert.Contains(t, errs.Error(), "Unknown key 'plans' in services")
+}
+
+func TestManifestWithRoutesThatAreNotAList(t *testing.T) {
+	_, errs := manifest.NewManifest(generic.NewMap(map[string]interface{}{
+		"applications": []interface{}{
+			map[string]interface{}{
+				"name":  "shop",
+				"hosts": "www",
+			},
+		},
+	}))
+	assert.False(t, errs.Empty())
+	assert.Contains(t, errs.Error(), "Expected hosts to be a list of strings.")
+}
+
 func TestManifestWithInvalidMemory(t *testing.T) {
 	_, err := manifest.NewManifest(generic.NewMap(map[string]interface{}{
 		"instances": "3",
@@ -43,6 +127,20 @@ func TestManifestWithInvalidMemory(t *testing.T) {
 	assert.Contains(t, err.Error(), "memory")
 }
 
+func TestManifestWithDiskQuotaConvertsItToMegabytes(t *testing.T) {
+	m, errs := manifest.NewManifest(generic.NewMap(map[string]interface{}{
+		"applications": []interface{}{
+			map[string]interface{}{
+				"name":       "bitcoin-miner",
+				"disk_quota": "1G",
+			},
+		},
+	}))
+
+	assert.True(t, errs.Empty())
+	assert.Equal(t, m.Applications[0].Get("disk_quota"), uint64(1024))
+}
+
 func TestParsingManifestWithTimeoutSetsHealthCheckTimeout(t *testing.T) {
 	m, err := manifest.NewManifest(generic.NewMap(map[string]interface{}{
 		"applications": []interface{}{
@@ -143,3 +241,13 @@ func TestParsingEmptyManifestDoesNotSetCommand(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, m.Applications[0].Has("command"))
 }
+
+func TestManifestSourcesDefaultToTheManifest(t *testing.T) {
+	m, errs := manifest.NewManifest(generic.NewMap(map[string]interface{}{
+		"applications": []interface{}{
+			map[string]interface{}{"name": "app-name", "timeout": 360},
+		},
+	}))
+	assert.True(t, errs.Empty())
+	assert.Equal(t, m.SourceOf(0, "health_check_timeout"), "manifest")
+}
@@ -0,0 +1,312 @@
+package manifest
+
+import (
+	"cf"
+	"errors"
+	"fmt"
+	"generic"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ManifestProblem is something wrong with a manifest, with where it was
+// found. Line is 0 when the line couldn't be worked out and App is empty for
+// problems outside of an application.
+type ManifestProblem struct {
+	File    string
+	Line    int
+	App     string
+	Message string
+}
+
+func (problem ManifestProblem) String() string {
+	location := problem.File
+	if problem.Line > 0 {
+		location = fmt.Sprintf("%s:%d", problem.File, problem.Line)
+	}
+
+	if problem.App != "" {
+		return fmt.Sprintf("%s: app %s: %s", location, problem.App, problem.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, problem.Message)
+}
+
+// manifestFile is a manifest read for validation, along with where each of
+// its keys is.
+type manifestFile struct {
+	name  string
+	data  generic.Map
+	lines manifestLines
+}
+
+// manifestLines tells which line each key of a manifest is on.
+type manifestLines struct {
+	keys map[string]int
+	apps []appLines
+}
+
+type appLines struct {
+	line int
+	keys map[string]int
+}
+
+var topLevelOnlyKeys = []string{"applications", "inherit"}
+
+var yamlKeyRegex = regexp.MustCompile(`^["']?([^"'\s:#][^"':#]*?)["']?\s*:(\s|$)`)
+
+// findManifestLines works out where the keys of a manifest are. It follows the
+// block style manifests are written in; keys written in flow style, such as
+// {name: app}, are left without a line.
+func findManifestLines(contents string) (lines manifestLines) {
+	lines.keys = map[string]int{}
+
+	inApplications := false
+	appIndent := -1
+	keyIndent := -1
+
+	for index, text := range strings.Split(contents, "\n") {
+		lineNumber := index + 1
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || trimmed == "---" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indent := len(text) - len(strings.TrimLeft(text, " "))
+		isListItem := trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+
+		if indent == 0 && !isListItem {
+			key := yamlKey(trimmed)
+			if key != "" {
+				lines.keys[key] = lineNumber
+			}
+			inApplications = key == "applications"
+			appIndent = -1
+			continue
+		}
+
+		if !inApplications {
+			continue
+		}
+
+		if isListItem && (appIndent == -1 || indent == appIndent) {
+			appIndent = indent
+			rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			keyIndent = indent + len(trimmed) - len(rest)
+
+			app := appLines{line: lineNumber, keys: map[string]int{}}
+			key := yamlKey(rest)
+			if key != "" {
+				app.keys[key] = lineNumber
+			}
+			lines.apps = append(lines.apps, app)
+			continue
+		}
+
+		if len(lines.apps) > 0 && indent == keyIndent {
+			key := yamlKey(trimmed)
+			if key != "" {
+				lines.apps[len(lines.apps)-1].keys[key] = lineNumber
+			}
+		}
+	}
+	return
+}
+
+func yamlKey(text string) string {
+	match := yamlKeyRegex.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+func (lines manifestLines) appLine(index int, key string) int {
+	if index >= len(lines.apps) {
+		return 0
+	}
+
+	app := lines.apps[index]
+	line, found := app.keys[key]
+	if found {
+		return line
+	}
+	return app.line
+}
+
+// validateManifestFiles checks each file of an inherit chain on its own, so
+// that every problem points at the file and line it comes from.
+func validateManifestFiles(files []manifestFile) (problems []ManifestProblem) {
+	chainHasName := false
+	for _, file := range files {
+		chainHasName = chainHasName || file.data.Has("name")
+	}
+
+	for _, file := range files {
+		fileProblems := validateManifestFile(file, chainHasName)
+		sort.Stable(problemsByLine(fileProblems))
+		problems = append(problems, fileProblems...)
+	}
+	return
+}
+
+func validateManifestFile(file manifestFile, chainHasName bool) (problems []ManifestProblem) {
+	allowedKeys := append(manifestKeyNames(), topLevelOnlyKeys...)
+
+	generic.Each(file.data, func(key, value interface{}) {
+		keyName := key.(string)
+		line := file.lines.keys[keyName]
+
+		switch keyName {
+		case "inherit":
+			return
+		case "applications":
+			appMaps, ok := value.([]interface{})
+			if !ok {
+				problems = append(problems, ManifestProblem{File: file.name, Line: line, Message: "Expected applications to be a list"})
+				return
+			}
+
+			for index, appData := range appMaps {
+				problems = append(problems, validateManifestApp(file, index, appData, chainHasName)...)
+			}
+			return
+		}
+
+		for _, err := range validateManifestKey(file.data, keyName, allowedKeys) {
+			problems = append(problems, ManifestProblem{File: file.name, Line: line, Message: err.Error()})
+		}
+	})
+	return
+}
+
+func validateManifestApp(file manifestFile, index int, appData interface{}, chainHasName bool) (problems []ManifestProblem) {
+	appName := fmt.Sprintf("#%d", index+1)
+
+	if !generic.IsMappable(appData) {
+		problems = append(problems, ManifestProblem{
+			File:    file.name,
+			Line:    file.lines.appLine(index, ""),
+			App:     appName,
+			Message: "Expected application to be a dictionary",
+		})
+		return
+	}
+
+	appMap := generic.NewMap(appData)
+	name, ok := appMap.Get("name").(string)
+	if ok {
+		appName = name
+	}
+
+	if !appMap.Has("name") && !chainHasName {
+		problems = append(problems, ManifestProblem{
+			File:    file.name,
+			Line:    file.lines.appLine(index, ""),
+			App:     appName,
+			Message: "app name is a required field",
+		})
+	}
+
+	generic.Each(appMap, func(key, _ interface{}) {
+		keyName := key.(string)
+		for _, err := range validateManifestKey(appMap, keyName, manifestKeyNames()) {
+			problems = append(problems, ManifestProblem{
+				File:    file.name,
+				Line:    file.lines.appLine(index, keyName),
+				App:     appName,
+				Message: err.Error(),
+			})
+		}
+	})
+	return
+}
+
+// validateManifestKey checks the value at key the same way the manifest is
+// read, and flags keys the manifest doesn't know about.
+func validateManifestKey(yamlMap generic.Map, key string, allowedKeys []string) (errs ManifestErrors) {
+	handler, known := manifestKeys[key]
+	if !known {
+		message := fmt.Sprintf("Unknown key '%s'", key)
+		suggestion := closestKey(key, allowedKeys)
+		if suggestion != "" {
+			message = fmt.Sprintf("%s, did you mean '%s'?", message, suggestion)
+		}
+		errs = append(errs, errors.New(message))
+		return
+	}
+
+	if yamlMap.IsNil(key) {
+		if key != "command" {
+			errs = append(errs, errors.New(fmt.Sprintf("%s should not be null", key)))
+		}
+		return
+	}
+
+	errs = append(errs, walkMapLookingForProperties(yamlMap.Get(key))...)
+	handler(cf.NewEmptyAppParams(), yamlMap, key, &errs)
+	return
+}
+
+func manifestKeyNames() (names []string) {
+	for key, _ := range manifestKeys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	return
+}
+
+// closestKey suggests the known key that key is most likely a typo of, or
+// nothing when none is close enough. Dashes and underscores are treated
+// alike since manifests use both.
+func closestKey(key string, knownKeys []string) (closest string) {
+	normalizedKey := strings.Replace(strings.ToLower(key), "-", "_", -1)
+
+	bestDistance := 3
+	if len(key) <= 4 {
+		bestDistance = 2
+	}
+
+	for _, knownKey := range knownKeys {
+		distance := editDistance(normalizedKey, strings.Replace(knownKey, "-", "_", -1))
+		if distance < bestDistance {
+			bestDistance = distance
+			closest = knownKey
+		}
+	}
+	return
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+type problemsByLine []ManifestProblem
+
+func (problems problemsByLine) Len() int           { return len(problems) }
+func (problems problemsByLine) Swap(i, j int)      { problems[i], problems[j] = problems[j], problems[i] }
+func (problems problemsByLine) Less(i, j int) bool { return problems[i].Line < problems[j].Line }
@@ -0,0 +1,234 @@
+package metrics
+
+import (
+	"bytes"
+	"cf"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const ContentType = "text/plain; version=0.0.4"
+
+var reportedStates = []cf.InstanceState{
+	cf.InstanceRunning,
+	cf.InstanceStarting,
+	cf.InstanceFlapping,
+	cf.InstanceDown,
+}
+
+type AppStats struct {
+	Name      string
+	Guid      string
+	State     string
+	Instances []cf.AppInstanceFields
+}
+
+// Snapshot holds the stats of the apps of one space. FailedApps are the apps
+// whose instances could not be fetched; they are left out of Apps.
+type Snapshot struct {
+	Org        string
+	Space      string
+	Apps       []AppStats
+	FailedApps []AppStats
+}
+
+type instanceKey struct {
+	appGuid string
+	index   int
+}
+
+// Exporter keeps the latest snapshot of app instance stats and serves it in
+// the Prometheus text exposition format.
+type Exporter struct {
+	mutex sync.Mutex
+
+	snapshot      Snapshot
+	lastScrape    time.Time
+	lastSucceeded bool
+	scrapeErrors  int
+
+	previousStates map[instanceKey]cf.InstanceState
+	crashes        map[instanceKey]int
+}
+
+func NewExporter() (exporter *Exporter) {
+	exporter = new(Exporter)
+	exporter.previousStates = map[instanceKey]cf.InstanceState{}
+	exporter.crashes = map[instanceKey]int{}
+	return
+}
+
+// Update replaces the served snapshot, counting a crash for every instance
+// that went into the flapping or down state since the previous one. The
+// states of failed apps are kept, so they are compared on the next update.
+func (exporter *Exporter) Update(snapshot Snapshot, scrapedAt time.Time) {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	states := map[instanceKey]cf.InstanceState{}
+	for _, app := range snapshot.FailedApps {
+		for key, state := range exporter.previousStates {
+			if key.appGuid == app.Guid {
+				states[key] = state
+			}
+		}
+	}
+
+	for _, app := range snapshot.Apps {
+		for index, instance := range app.Instances {
+			key := instanceKey{app.Guid, index}
+			states[key] = instance.State
+
+			if isCrashed(instance.State) && !isCrashed(exporter.previousStates[key]) {
+				exporter.crashes[key]++
+			}
+		}
+	}
+
+	exporter.snapshot = snapshot
+	exporter.previousStates = states
+	exporter.lastScrape = scrapedAt
+	exporter.lastSucceeded = true
+}
+
+// UpdateFailed records a failed poll while still serving the last snapshot.
+func (exporter *Exporter) UpdateFailed(scrapedAt time.Time) {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	exporter.lastScrape = scrapedAt
+	exporter.lastSucceeded = false
+	exporter.scrapeErrors++
+}
+
+func (exporter *Exporter) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	buffer := &bytes.Buffer{}
+	exporter.WriteMetrics(buffer)
+
+	writer.Header().Set("Content-Type", ContentType)
+	writer.Write(buffer.Bytes())
+}
+
+func (exporter *Exporter) WriteMetrics(writer io.Writer) {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	snapshot := exporter.snapshot
+
+	writeHeader(writer, "cf_app_instance_cpu_ratio", "gauge", "CPU usage of an app instance as a fraction of one core.")
+	exporter.writeInstanceGauges(writer, "cf_app_instance_cpu_ratio", func(instance cf.AppInstanceFields) string {
+		return formatFloat(instance.CpuUsage)
+	})
+
+	writeHeader(writer, "cf_app_instance_memory_bytes", "gauge", "Memory used by an app instance.")
+	exporter.writeInstanceGauges(writer, "cf_app_instance_memory_bytes", func(instance cf.AppInstanceFields) string {
+		return fmt.Sprintf("%d", instance.MemUsage)
+	})
+
+	writeHeader(writer, "cf_app_instance_memory_quota_bytes", "gauge", "Memory limit of an app instance.")
+	exporter.writeInstanceGauges(writer, "cf_app_instance_memory_quota_bytes", func(instance cf.AppInstanceFields) string {
+		return fmt.Sprintf("%d", instance.MemQuota)
+	})
+
+	writeHeader(writer, "cf_app_instance_disk_bytes", "gauge", "Disk used by an app instance.")
+	exporter.writeInstanceGauges(writer, "cf_app_instance_disk_bytes", func(instance cf.AppInstanceFields) string {
+		return fmt.Sprintf("%d", instance.DiskUsage)
+	})
+
+	writeHeader(writer, "cf_app_instance_disk_quota_bytes", "gauge", "Disk limit of an app instance.")
+	exporter.writeInstanceGauges(writer, "cf_app_instance_disk_quota_bytes", func(instance cf.AppInstanceFields) string {
+		return fmt.Sprintf("%d", instance.DiskQuota)
+	})
+
+	writeHeader(writer, "cf_app_instance_up", "gauge", "Whether an app instance is running (1) or not (0).")
+	exporter.writeInstanceGauges(writer, "cf_app_instance_up", func(instance cf.AppInstanceFields) string {
+		if instance.State == cf.InstanceRunning {
+			return "1"
+		}
+		return "0"
+	})
+
+	writeHeader(writer, "cf_app_instances", "gauge", "Number of app instances in each state.")
+	for _, app := range snapshot.Apps {
+		counts := map[cf.InstanceState]int{}
+		for _, instance := range app.Instances {
+			counts[instance.State]++
+		}
+
+		for _, state := range reportedStates {
+			fmt.Fprintf(writer, "cf_app_instances{%s,state=\"%s\"} %d\n", appLabels(snapshot, app), state, counts[state])
+		}
+	}
+
+	writeHeader(writer, "cf_app_instance_crashes_total", "counter", "Times an app instance was seen going into the flapping or down state.")
+	for _, app := range snapshot.Apps {
+		for index := range app.Instances {
+			fmt.Fprintf(writer, "cf_app_instance_crashes_total{%s} %d\n", instanceLabels(snapshot, app, index), exporter.crashes[instanceKey{app.Guid, index}])
+		}
+	}
+
+	lastSucceeded := "0"
+	if exporter.lastSucceeded {
+		lastSucceeded = "1"
+	}
+
+	writeHeader(writer, "cf_exporter_last_scrape_success", "gauge", "Whether the last poll of the Cloud Controller succeeded.")
+	fmt.Fprintf(writer, "cf_exporter_last_scrape_success %s\n", lastSucceeded)
+
+	writeHeader(writer, "cf_exporter_last_scrape_timestamp_seconds", "gauge", "Unix time of the last poll of the Cloud Controller.")
+	fmt.Fprintf(writer, "cf_exporter_last_scrape_timestamp_seconds %d\n", exporter.lastScrape.Unix())
+
+	writeHeader(writer, "cf_exporter_scrape_errors_total", "counter", "Polls of the Cloud Controller that failed.")
+	fmt.Fprintf(writer, "cf_exporter_scrape_errors_total %d\n", exporter.scrapeErrors)
+
+	writeHeader(writer, "cf_exporter_app_scrape_errors", "gauge", "Apps whose instances could not be fetched in the last poll.")
+	fmt.Fprintf(writer, "cf_exporter_app_scrape_errors %d\n", len(snapshot.FailedApps))
+
+	writeHeader(writer, "cf_exporter_app_scrape_error", "gauge", "Set for each app whose instances could not be fetched in the last poll.")
+	for _, app := range snapshot.FailedApps {
+		fmt.Fprintf(writer, "cf_exporter_app_scrape_error{%s} 1\n", appLabels(snapshot, app))
+	}
+}
+
+func (exporter *Exporter) writeInstanceGauges(writer io.Writer, name string, value func(cf.AppInstanceFields) string) {
+	for _, app := range exporter.snapshot.Apps {
+		for index, instance := range app.Instances {
+			fmt.Fprintf(writer, "%s{%s} %s\n", name, instanceLabels(exporter.snapshot, app, index), value(instance))
+		}
+	}
+}
+
+func writeHeader(writer io.Writer, name, metricType, help string) {
+	fmt.Fprintf(writer, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(writer, "# TYPE %s %s\n", name, metricType)
+}
+
+func appLabels(snapshot Snapshot, app AppStats) string {
+	return fmt.Sprintf(`org="%s",space="%s",app="%s"`,
+		escapeLabelValue(snapshot.Org),
+		escapeLabelValue(snapshot.Space),
+		escapeLabelValue(app.Name),
+	)
+}
+
+func instanceLabels(snapshot Snapshot, app AppStats, index int) string {
+	return fmt.Sprintf(`%s,instance="%d"`, appLabels(snapshot, app), index)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	return fmt.Sprintf("%g", value)
+}
+
+func isCrashed(state cf.InstanceState) bool {
+	return state == cf.InstanceFlapping || state == cf.InstanceDown
+}
@@ -0,0 +1,149 @@
+package metrics_test
+
+import (
+	"bytes"
+	"cf"
+	. "cf/metrics"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExporterWritesInstanceGauges(t *testing.T) {
+	exporter := NewExporter()
+	exporter.Update(Snapshot{
+		Org:   "my-org",
+		Space: "my-space",
+		Apps: []AppStats{
+			{
+				Name:  "my-app",
+				Guid:  "my-app-guid",
+				State: "started",
+				Instances: []cf.AppInstanceFields{
+					{State: cf.InstanceRunning, CpuUsage: 0.25, MemUsage: 1024, MemQuota: 2048, DiskUsage: 10, DiskQuota: 20},
+					{State: cf.InstanceFlapping},
+				},
+			},
+			{Name: "stopped-app", Guid: "stopped-app-guid", State: "stopped"},
+		},
+	}, time.Unix(1400000000, 0))
+
+	output := writeExporter(exporter)
+
+	assert.Contains(t, output, "# TYPE cf_app_instance_cpu_ratio gauge\n")
+	assert.Contains(t, output, `cf_app_instance_cpu_ratio{org="my-org",space="my-space",app="my-app",instance="0"} 0.25`+"\n")
+	assert.Contains(t, output, `cf_app_instance_memory_bytes{org="my-org",space="my-space",app="my-app",instance="0"} 1024`+"\n")
+	assert.Contains(t, output, `cf_app_instance_memory_quota_bytes{org="my-org",space="my-space",app="my-app",instance="0"} 2048`+"\n")
+	assert.Contains(t, output, `cf_app_instance_disk_bytes{org="my-org",space="my-space",app="my-app",instance="0"} 10`+"\n")
+	assert.Contains(t, output, `cf_app_instance_disk_quota_bytes{org="my-org",space="my-space",app="my-app",instance="0"} 20`+"\n")
+	assert.Contains(t, output, `cf_app_instance_up{org="my-org",space="my-space",app="my-app",instance="0"} 1`+"\n")
+	assert.Contains(t, output, `cf_app_instance_up{org="my-org",space="my-space",app="my-app",instance="1"} 0`+"\n")
+
+	assert.Contains(t, output, `cf_app_instances{org="my-org",space="my-space",app="my-app",state="running"} 1`+"\n")
+	assert.Contains(t, output, `cf_app_instances{org="my-org",space="my-space",app="my-app",state="flapping"} 1`+"\n")
+	assert.Contains(t, output, `cf_app_instances{org="my-org",space="my-space",app="my-app",state="down"} 0`+"\n")
+	assert.Contains(t, output, `cf_app_instances{org="my-org",space="my-space",app="stopped-app",state="running"} 0`+"\n")
+
+	assert.Contains(t, output, "cf_exporter_last_scrape_success 1\n")
+	assert.Contains(t, output, "cf_exporter_last_scrape_timestamp_seconds 1400000000\n")
+}
+
+func TestExporterCountsCrashesAcrossUpdates(t *testing.T) {
+	snapshotWithState := func(state cf.InstanceState) Snapshot {
+		return Snapshot{
+			Org:   "my-org",
+			Space: "my-space",
+			Apps: []AppStats{{
+				Name:      "my-app",
+				Guid:      "my-app-guid",
+				Instances: []cf.AppInstanceFields{{State: state}},
+			}},
+		}
+	}
+
+	exporter := NewExporter()
+	exporter.Update(snapshotWithState(cf.InstanceRunning), time.Now())
+	exporter.Update(snapshotWithState(cf.InstanceFlapping), time.Now())
+	exporter.Update(snapshotWithState(cf.InstanceFlapping), time.Now())
+	exporter.Update(snapshotWithState(cf.InstanceRunning), time.Now())
+	exporter.Update(snapshotWithState(cf.InstanceDown), time.Now())
+
+	output := writeExporter(exporter)
+
+	assert.Contains(t, output, "# TYPE cf_app_instance_crashes_total counter\n")
+	assert.Contains(t, output, `cf_app_instance_crashes_total{org="my-org",space="my-space",app="my-app",instance="0"} 2`+"\n")
+}
+
+func TestExporterReportsAppsWhoseInstancesCouldNotBeFetched(t *testing.T) {
+	myApp := AppStats{Name: "my-app", Guid: "my-app-guid", Instances: []cf.AppInstanceFields{{State: cf.InstanceFlapping}}}
+	otherApp := AppStats{Name: "other-app", Guid: "other-app-guid", Instances: []cf.AppInstanceFields{{State: cf.InstanceRunning}}}
+	failedApp := AppStats{Name: "my-app", Guid: "my-app-guid"}
+
+	exporter := NewExporter()
+	exporter.Update(Snapshot{Org: "my-org", Space: "my-space", Apps: []AppStats{myApp, otherApp}}, time.Now())
+	exporter.Update(Snapshot{Org: "my-org", Space: "my-space", Apps: []AppStats{otherApp}, FailedApps: []AppStats{failedApp}}, time.Now())
+
+	output := writeExporter(exporter)
+
+	assert.Contains(t, output, "cf_exporter_last_scrape_success 1\n")
+	assert.Contains(t, output, "cf_exporter_app_scrape_errors 1\n")
+	assert.Contains(t, output, `cf_exporter_app_scrape_error{org="my-org",space="my-space",app="my-app"} 1`+"\n")
+	assert.Contains(t, output, `cf_app_instance_up{org="my-org",space="my-space",app="other-app",instance="0"} 1`+"\n")
+	assert.NotContains(t, output, `app="my-app",instance="0"`)
+
+	exporter.Update(Snapshot{Org: "my-org", Space: "my-space", Apps: []AppStats{myApp, otherApp}}, time.Now())
+	output = writeExporter(exporter)
+
+	assert.Contains(t, output, "cf_exporter_app_scrape_errors 0\n")
+	assert.Contains(t, output, `cf_app_instance_crashes_total{org="my-org",space="my-space",app="my-app",instance="0"} 1`+"\n")
+}
+
+func TestExporterKeepsTheLastSnapshotWhenAnUpdateFails(t *testing.T) {
+	exporter := NewExporter()
+	exporter.Update(Snapshot{
+		Apps: []AppStats{{Name: "my-app", Instances: []cf.AppInstanceFields{{State: cf.InstanceRunning}}}},
+	}, time.Now())
+	exporter.UpdateFailed(time.Now())
+
+	output := writeExporter(exporter)
+
+	assert.Contains(t, output, `app="my-app",instance="0"} 1`)
+	assert.Contains(t, output, "cf_exporter_last_scrape_success 0\n")
+	assert.Contains(t, output, "cf_exporter_scrape_errors_total 1\n")
+}
+
+func TestExporterEscapesLabelValues(t *testing.T) {
+	exporter := NewExporter()
+	exporter.Update(Snapshot{
+		Org: `my "org"`,
+		Apps: []AppStats{{
+			Name:      "back\\slash\nnewline",
+			Instances: []cf.AppInstanceFields{{State: cf.InstanceRunning}},
+		}},
+	}, time.Now())
+
+	output := writeExporter(exporter)
+
+	assert.Contains(t, output, `org="my \"org\"",space="",app="back\\slash\nnewline"`)
+}
+
+func TestExporterServesTheTextFormat(t *testing.T) {
+	exporter := NewExporter()
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest("GET", "/metrics", nil)
+	assert.NoError(t, err)
+
+	exporter.ServeHTTP(recorder, request)
+
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, recorder.Header().Get("Content-Type"), ContentType)
+	assert.Contains(t, recorder.Body.String(), "cf_exporter_last_scrape_success 0\n")
+}
+
+func writeExporter(exporter *Exporter) string {
+	buffer := &bytes.Buffer{}
+	exporter.WriteMetrics(buffer)
+	return buffer.String()
+}
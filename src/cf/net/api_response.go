@@ -1,6 +1,7 @@
 package net
 
 import (
+	"cf/errors"
 	"fmt"
 )
 
@@ -14,6 +15,7 @@ type ApiResponse struct {
 	isError        bool
 	isHttpResponse bool
 	isNotFound     bool
+	isTimeout      bool
 }
 
 func NewApiResponse(message string, errorCode string, statusCode int) (apiResponse ApiResponse) {
@@ -59,6 +61,14 @@ func NewApiResponseWithError(message string, err error) (apiResponse ApiResponse
 	}
 }
 
+func NewTimeoutApiResponse(message string, err error) (apiResponse ApiResponse) {
+	return ApiResponse{
+		Message:   fmt.Sprintf("%s: %s", message, err.Error()),
+		isError:   true,
+		isTimeout: true,
+	}
+}
+
 func NewNotFoundApiResponse(message string, a ...interface{}) (apiResponse ApiResponse) {
 	return ApiResponse{
 		Message:    fmt.Sprintf(message, a...),
@@ -89,3 +99,21 @@ func (apiResponse ApiResponse) IsSuccessful() bool {
 func (apiResponse ApiResponse) IsNotSuccessful() bool {
 	return apiResponse.IsError() || apiResponse.IsNotFound()
 }
+
+func (apiResponse ApiResponse) IsTimeout() bool {
+	return apiResponse.isTimeout
+}
+
+func (apiResponse ApiResponse) ToError() errors.Error {
+	switch {
+	case apiResponse.IsSuccessful():
+		return nil
+	case apiResponse.IsNotFound():
+		return errors.NewNotFoundError(apiResponse.Message)
+	case apiResponse.IsTimeout():
+		return errors.NewTimeoutError(apiResponse.Message)
+	case apiResponse.IsHttpError():
+		return errors.NewErrorFromStatusCode(apiResponse.StatusCode, apiResponse.Message)
+	}
+	return errors.New(apiResponse.Message)
+}
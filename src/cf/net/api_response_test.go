@@ -0,0 +1,26 @@
+package net_test
+
+import (
+	"cf/errors"
+	. "cf/net"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestApiResponseToError(t *testing.T) {
+	assert.Nil(t, NewSuccessfulApiResponse().ToError())
+	assert.Nil(t, NewApiResponseWithStatusCode(201).ToError())
+
+	err := NewNotFoundApiResponse("%s not found", "my-app").ToError()
+	assert.Equal(t, err.Error(), "my-app not found")
+	assert.Equal(t, err.ExitCode(), errors.EXIT_NOT_FOUND)
+
+	err = NewApiResponse("forbidden", "10003", 403).ToError()
+	assert.Equal(t, err.ExitCode(), errors.EXIT_PERMISSION)
+
+	err = NewApiResponse("server error", "10001", 500).ToError()
+	assert.Equal(t, err.ExitCode(), errors.EXIT_SERVER)
+
+	err = NewApiResponseWithMessage("something went wrong").ToError()
+	assert.Equal(t, err.ExitCode(), errors.EXIT_FAILED)
+}
@@ -0,0 +1,163 @@
+package net
+
+import (
+	"cf/terminal"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+type DryRunRequest struct {
+	Method string
+	Url    string
+	Body   string
+}
+
+var (
+	dryRunEnabled  bool
+	dryRunRequests []DryRunRequest
+	dryRunMutex    sync.Mutex
+	dryRunStdout   io.Writer = os.Stdout
+)
+
+func EnableDryRun() {
+	dryRunMutex.Lock()
+	defer dryRunMutex.Unlock()
+
+	dryRunEnabled = true
+	dryRunRequests = []DryRunRequest{}
+}
+
+func DisableDryRun() {
+	dryRunMutex.Lock()
+	defer dryRunMutex.Unlock()
+
+	dryRunEnabled = false
+	dryRunRequests = nil
+}
+
+func IsDryRun() bool {
+	dryRunMutex.Lock()
+	defer dryRunMutex.Unlock()
+
+	return dryRunEnabled
+}
+
+// DryRunRequests returns the mutating requests that were intercepted, in the order they were made.
+func DryRunRequests() (requests []DryRunRequest) {
+	dryRunMutex.Lock()
+	defer dryRunMutex.Unlock()
+
+	requests = make([]DryRunRequest, len(dryRunRequests))
+	copy(requests, dryRunRequests)
+	return
+}
+
+func SetDryRunStdout(s io.Writer) {
+	dryRunStdout = s
+}
+
+func (gateway Gateway) shouldInterceptForDryRun(request *Request) bool {
+	if gateway.DryRunExempt || !IsDryRun() {
+		return false
+	}
+
+	switch request.HttpReq.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return false
+	}
+	return true
+}
+
+func interceptForDryRun(request *Request) (rawResponse *http.Response, apiResponse ApiResponse) {
+	dryRunRequest := DryRunRequest{
+		Method: request.HttpReq.Method,
+		Url:    request.HttpReq.URL.String(),
+		Body:   dryRunBody(request),
+	}
+
+	dryRunMutex.Lock()
+	dryRunRequests = append(dryRunRequests, dryRunRequest)
+	requestNumber := len(dryRunRequests)
+	dryRunMutex.Unlock()
+
+	fmt.Fprintf(dryRunStdout, "%s %s %s\n", terminal.WarningColor("DRY RUN:"), dryRunRequest.Method, dryRunRequest.Url)
+	if dryRunRequest.Body != "" {
+		fmt.Fprintf(dryRunStdout, "%s\n", dryRunRequest.Body)
+	}
+
+	rawResponse = &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(dryRunResponseBody(request, requestNumber))),
+		Request:    request.HttpReq,
+	}
+	apiResponse = NewApiResponseWithStatusCode(http.StatusOK)
+	return
+}
+
+// dryRunResponseBody makes up the resource the Cloud Controller would answer
+// a create or update with, holding the fields that were sent. Its guid comes
+// from the url of an update, or is a placeholder for a create, so that
+// commands going on to use what they created name it in the later requests.
+func dryRunResponseBody(request *Request, requestNumber int) string {
+	var guid string
+	switch request.HttpReq.Method {
+	case "POST":
+		guid = fmt.Sprintf("DRY-RUN-GUID-%d", requestNumber)
+	case "PUT":
+		pathParts := strings.Split(request.HttpReq.URL.Path, "/")
+		if len(pathParts) < 4 || pathParts[1] != "v2" {
+			return ""
+		}
+		guid = pathParts[3]
+	default:
+		return ""
+	}
+
+	entity := map[string]interface{}{}
+	if request.SeekableBody != nil && !strings.Contains(request.HttpReq.Header.Get("Content-Type"), "multipart/form-data") {
+		request.SeekableBody.Seek(0, 0)
+		json.NewDecoder(request.SeekableBody).Decode(&entity)
+		request.SeekableBody.Seek(0, 0)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"id":       guid,
+		"metadata": map[string]interface{}{"guid": guid},
+		"entity":   entity,
+	})
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+func dryRunBody(request *Request) string {
+	if request.SeekableBody == nil {
+		return ""
+	}
+
+	if strings.Contains(request.HttpReq.Header.Get("Content-Type"), "multipart/form-data") {
+		return "[MULTIPART/FORM-DATA CONTENT HIDDEN]"
+	}
+
+	request.SeekableBody.Seek(0, 0)
+	bytes, err := ioutil.ReadAll(request.SeekableBody)
+	request.SeekableBody.Seek(0, 0)
+	if err != nil {
+		return ""
+	}
+
+	body := Sanitize(string(bytes))
+	if len(body) > MAX_EXCHANGE_LENGTH {
+		body = body[:MAX_EXCHANGE_LENGTH] + "\n[TRUNCATED]"
+	}
+	return body
+}
@@ -0,0 +1,108 @@
+package net_test
+
+import (
+	"bytes"
+	. "cf/net"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRunInterceptsMutatingRequests(t *testing.T) {
+	requestCount := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+	}))
+	defer ts.Close()
+
+	output := withDryRun(func() {
+		gateway := NewCloudControllerGateway()
+
+		apiResponse := gateway.CreateResource(ts.URL+"/v2/apps", "BEARER my-access-token", strings.NewReader(`{"name":"my-app","token":"my-secret"}`))
+		assert.True(t, apiResponse.IsSuccessful())
+
+		apiResponse = gateway.DeleteResource(ts.URL+"/v2/apps/my-app-guid", "BEARER my-access-token")
+		assert.True(t, apiResponse.IsSuccessful())
+
+		requests := DryRunRequests()
+		assert.Equal(t, len(requests), 2)
+		assert.Equal(t, requests[0].Method, "POST")
+		assert.Equal(t, requests[0].Url, ts.URL+"/v2/apps")
+		assert.Equal(t, requests[0].Body, `{"name":"my-app","token":"[PRIVATE DATA HIDDEN]"}`)
+		assert.Equal(t, requests[1].Method, "DELETE")
+	})
+
+	assert.Equal(t, requestCount, 0)
+	assert.Contains(t, output, "POST "+ts.URL+"/v2/apps")
+	assert.Contains(t, output, "DELETE "+ts.URL+"/v2/apps/my-app-guid")
+	assert.NotContains(t, output, "my-secret")
+}
+
+func TestDryRunAnswersCreatesAndUpdatesWithTheResourceSent(t *testing.T) {
+	withDryRun(func() {
+		gateway := NewCloudControllerGateway()
+
+		resource := struct {
+			Metadata struct{ Guid string }
+			Entity   struct{ Name string }
+		}{}
+		apiResponse := gateway.CreateResourceForResponse("https://api.example.com/v2/apps", "BEARER my-access-token", strings.NewReader(`{"name":"my-app"}`), &resource)
+		assert.True(t, apiResponse.IsSuccessful())
+		assert.Equal(t, resource.Metadata.Guid, "DRY-RUN-GUID-1")
+		assert.Equal(t, resource.Entity.Name, "my-app")
+
+		apiResponse = gateway.UpdateResourceForResponse("https://api.example.com/v2/apps/my-app-guid", "BEARER my-access-token", strings.NewReader(`{"name":"new-name"}`), &resource)
+		assert.True(t, apiResponse.IsSuccessful())
+		assert.Equal(t, resource.Metadata.Guid, "my-app-guid")
+		assert.Equal(t, resource.Entity.Name, "new-name")
+	})
+}
+
+func TestDryRunPerformsGetRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte(`{"name":"my-app"}`))
+	}))
+	defer ts.Close()
+
+	withDryRun(func() {
+		gateway := NewCloudControllerGateway()
+
+		resource := struct{ Name string }{}
+		apiResponse := gateway.GetResource(ts.URL+"/v2/apps/my-app-guid", "BEARER my-access-token", &resource)
+
+		assert.True(t, apiResponse.IsSuccessful())
+		assert.Equal(t, resource.Name, "my-app")
+		assert.Equal(t, len(DryRunRequests()), 0)
+	})
+}
+
+func TestDryRunExemptGatewaysPerformMutatingRequests(t *testing.T) {
+	requestCount := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+	}))
+	defer ts.Close()
+
+	withDryRun(func() {
+		gateway := NewUAAGateway()
+		gateway.DryRunExempt = true
+
+		apiResponse := gateway.CreateResource(ts.URL+"/oauth/token", "", strings.NewReader("grant_type=password"))
+		assert.True(t, apiResponse.IsSuccessful())
+	})
+
+	assert.Equal(t, requestCount, 1)
+}
+
+func withDryRun(callback func()) string {
+	output := &bytes.Buffer{}
+	SetDryRunStdout(output)
+	EnableDryRun()
+
+	defer DisableDryRun()
+
+	callback()
+	return output.String()
+}
@@ -59,6 +59,7 @@ type Gateway struct {
 	errHandler      errorHandler
 	PollingEnabled  bool
 	PollingThrottle time.Duration
+	DryRunExempt    bool
 }
 
 func newGateway(errHandler errorHandler) (gateway Gateway) {
@@ -121,6 +122,11 @@ func (gateway Gateway) createUpdateOrDeleteResource(verb, url, accessToken strin
 
 }
 
+// sizedBody is a body whose length is known without reading it
+type sizedBody interface {
+	Size() int64
+}
+
 func (gateway Gateway) NewRequest(method, path, accessToken string, body io.ReadSeeker) (req *Request, apiResponse ApiResponse) {
 	if body != nil {
 		body.Seek(0, 0)
@@ -148,6 +154,8 @@ func (gateway Gateway) NewRequest(method, path, accessToken string, body io.Read
 				break
 			}
 			request.ContentLength = fileStats.Size()
+		case sizedBody:
+			request.ContentLength = v.Size()
 		}
 	}
 
@@ -274,6 +282,10 @@ func (gateway Gateway) waitForJob(jobUrl, accessToken string) (apiResponse ApiRe
 func (gateway Gateway) doRequestHandlingAuth(request *Request) (rawResponse *http.Response, apiResponse ApiResponse) {
 	httpReq := request.HttpReq
 
+	if gateway.shouldInterceptForDryRun(request) {
+		return interceptForDryRun(request)
+	}
+
 	// perform request
 	rawResponse, apiResponse = gateway.doRequestAndHandlerError(request)
 	if apiResponse.IsSuccessful() || gateway.authenticator == nil {
@@ -304,6 +316,12 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (rawResponse *htt
 
 func (gateway Gateway) doRequestAndHandlerError(request *Request) (rawResponse *http.Response, apiResponse ApiResponse) {
 	rawResponse, err := doRequest(request.HttpReq)
+	if timeoutErr, ok := err.(interface {
+		Timeout() bool
+	}); ok && timeoutErr.Timeout() {
+		apiResponse = NewTimeoutApiResponse("Error performing request", err)
+		return
+	}
 	if err != nil {
 		apiResponse = NewApiResponseWithError("Error performing request", err)
 		return
@@ -4,18 +4,42 @@ import (
 	"cf/terminal"
 	"cf/trace"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httputil"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 const (
 	PRIVATE_DATA_PLACEHOLDER = "[PRIVATE DATA HIDDEN]"
+	MAX_RECENT_EXCHANGES     = 10
+	MAX_EXCHANGE_LENGTH      = 4096
 )
 
+var (
+	recentExchanges      []string
+	recentExchangesMutex sync.Mutex
+)
+
+// privateJsonKeys are the JSON properties whose values are never recorded,
+// wherever they appear in a request or response body. They hold user
+// passwords, service credentials and the app env carrying VCAP_SERVICES.
+var privateJsonKeys = []string{
+	"password",
+	"credentials",
+	"client_secret",
+	"environment_json",
+	"system_env_json",
+	"VCAP_SERVICES",
+	"access_token",
+	"refresh_token",
+	"token",
+}
+
 func newHttpClient() *http.Client {
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
@@ -55,6 +79,7 @@ func Sanitize(input string) (sanitized string) {
 	sanitized = sanitizeJson("access_token", sanitized)
 	sanitized = sanitizeJson("refresh_token", sanitized)
 	sanitized = sanitizeJson("token", sanitized)
+	sanitized = sanitizeJson("password", sanitized)
 
 	return
 }
@@ -79,10 +104,13 @@ func dumpRequest(req *http.Request) {
 	if err != nil {
 		trace.Logger.Printf("Error dumping request\n%s\n", err)
 	} else {
-		trace.Logger.Printf("\n%s\n%s\n", terminal.HeaderColor("REQUEST:"), Sanitize(string(dumpedRequest)))
+		sanitizedRequest := Sanitize(string(dumpedRequest))
+		trace.Logger.Printf("\n%s\n%s\n", terminal.HeaderColor("REQUEST:"), sanitizedRequest)
 		if !shouldDisplayBody {
 			trace.Logger.Println("[MULTIPART/FORM-DATA CONTENT HIDDEN]")
+			sanitizedRequest = sanitizedRequest + "\n[MULTIPART/FORM-DATA CONTENT HIDDEN]"
 		}
+		recordExchange("REQUEST:\n" + sanitizedRequest)
 	}
 }
 
@@ -91,6 +119,94 @@ func dumpResponse(res *http.Response) {
 	if err != nil {
 		trace.Logger.Printf("Error dumping response\n%s\n", err)
 	} else {
-		trace.Logger.Printf("\n%s\n%s\n", terminal.HeaderColor("RESPONSE:"), Sanitize(string(dumpedResponse)))
+		sanitizedResponse := Sanitize(string(dumpedResponse))
+		trace.Logger.Printf("\n%s\n%s\n", terminal.HeaderColor("RESPONSE:"), sanitizedResponse)
+		recordExchange("RESPONSE:\n" + sanitizedResponse)
+	}
+}
+
+func recordExchange(exchange string) {
+	exchange = hidePrivateBody(exchange)
+	if len(exchange) > MAX_EXCHANGE_LENGTH {
+		exchange = exchange[:MAX_EXCHANGE_LENGTH] + "\n[TRUNCATED]"
+	}
+
+	recentExchangesMutex.Lock()
+	defer recentExchangesMutex.Unlock()
+
+	recentExchanges = append(recentExchanges, exchange)
+	if len(recentExchanges) > MAX_RECENT_EXCHANGES {
+		recentExchanges = recentExchanges[len(recentExchanges)-MAX_RECENT_EXCHANGES:]
+	}
+}
+
+// hidePrivateBody replaces the values of privateJsonKeys in the JSON body of
+// a dumped exchange. A body that mentions one of them but can't be parsed,
+// like a chunked response, is hidden altogether.
+func hidePrivateBody(exchange string) string {
+	bodyStart := regexp.MustCompile(`\r?\n\r?\n`).FindStringIndex(exchange)
+	if bodyStart == nil || !mentionsPrivateKey(exchange[bodyStart[1]:]) {
+		return exchange
+	}
+	headers, body := exchange[:bodyStart[1]], exchange[bodyStart[1]:]
+
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	err := decoder.Decode(&value)
+	if err != nil {
+		return headers + PRIVATE_DATA_PLACEHOLDER
+	}
+
+	hiddenBody, err := json.Marshal(hidePrivateValues(value))
+	if err != nil {
+		return headers + PRIVATE_DATA_PLACEHOLDER
+	}
+	return headers + string(hiddenBody)
+}
+
+func mentionsPrivateKey(body string) bool {
+	for _, key := range privateJsonKeys {
+		if strings.Contains(body, `"`+key+`"`) {
+			return true
+		}
+	}
+	return false
+}
+
+func hidePrivateValues(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, nestedValue := range value {
+			if isPrivateKey(key) {
+				value[key] = PRIVATE_DATA_PLACEHOLDER
+			} else {
+				value[key] = hidePrivateValues(nestedValue)
+			}
+		}
+	case []interface{}:
+		for index, nestedValue := range value {
+			value[index] = hidePrivateValues(nestedValue)
+		}
+	}
+	return value
+}
+
+func isPrivateKey(key string) bool {
+	for _, privateKey := range privateJsonKeys {
+		if key == privateKey {
+			return true
+		}
 	}
+	return false
+}
+
+// RecentExchanges returns the last sanitized requests and responses, oldest first.
+func RecentExchanges() (exchanges []string) {
+	recentExchangesMutex.Lock()
+	defer recentExchangesMutex.Unlock()
+
+	exchanges = make([]string, len(recentExchanges))
+	copy(exchanges, recentExchanges)
+	return
 }
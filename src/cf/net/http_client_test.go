@@ -4,6 +4,7 @@ import (
 	. "cf/net"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -160,3 +161,77 @@ func TestPrepareRedirectFailsAfterOneRedirect(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestRecentExchangesAreSanitized(t *testing.T) {
+	originalReq, err := http.NewRequest("GET", "/foo", nil)
+	assert.NoError(t, err)
+	originalReq.Header.Set("Authorization", "my-auth-token")
+
+	redirectReq, err := http.NewRequest("GET", "/recent-exchange", nil)
+	assert.NoError(t, err)
+
+	err = PrepareRedirect(redirectReq, []*http.Request{originalReq})
+	assert.NoError(t, err)
+
+	exchanges := RecentExchanges()
+	lastExchange := exchanges[len(exchanges)-1]
+	assert.Contains(t, lastExchange, "GET /recent-exchange")
+	assert.Contains(t, lastExchange, "Authorization: [PRIVATE DATA HIDDEN]")
+	assert.NotContains(t, lastExchange, "my-auth-token")
+}
+
+func TestSanitizeRemovesPasswordsFromBody(t *testing.T) {
+	request := `{"userName":"my-user","password":"my-password"}`
+	expected := `{"userName":"my-user","password":"[PRIVATE DATA HIDDEN]"}`
+
+	assert.Equal(t, Sanitize(request), expected)
+}
+
+func TestRecentExchangesHidePrivateJsonValues(t *testing.T) {
+	originalReq, err := http.NewRequest("GET", "/foo", nil)
+	assert.NoError(t, err)
+
+	body := `{"name":"my-db","credentials":{"username":"admin","password":"s3cret"},"entity":{"system_env_json":{"VCAP_SERVICES":{"uri":"mysql://admin:s3cret@db"}}}}`
+	redirectReq, err := http.NewRequest("PUT", "/v2/user_provided_service_instances/my-guid", strings.NewReader(body))
+	assert.NoError(t, err)
+
+	err = PrepareRedirect(redirectReq, []*http.Request{originalReq})
+	assert.NoError(t, err)
+
+	exchanges := RecentExchanges()
+	lastExchange := exchanges[len(exchanges)-1]
+	assert.Contains(t, lastExchange, `"name":"my-db"`)
+	assert.Contains(t, lastExchange, `"credentials":"[PRIVATE DATA HIDDEN]"`)
+	assert.Contains(t, lastExchange, `"system_env_json":"[PRIVATE DATA HIDDEN]"`)
+	assert.NotContains(t, lastExchange, "s3cret")
+}
+
+func TestRecentExchangesHideUnparseablePrivateBodies(t *testing.T) {
+	originalReq, err := http.NewRequest("GET", "/foo", nil)
+	assert.NoError(t, err)
+
+	body := `{"credentials":{"password":"s3cret"`
+	redirectReq, err := http.NewRequest("PUT", "/v2/service_bindings", strings.NewReader(body))
+	assert.NoError(t, err)
+
+	err = PrepareRedirect(redirectReq, []*http.Request{originalReq})
+	assert.NoError(t, err)
+
+	exchanges := RecentExchanges()
+	lastExchange := exchanges[len(exchanges)-1]
+	assert.Contains(t, lastExchange, "PUT /v2/service_bindings")
+	assert.NotContains(t, lastExchange, "s3cret")
+}
+
+func TestRecentExchangesAreBounded(t *testing.T) {
+	originalReq, err := http.NewRequest("GET", "/foo", nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < MAX_RECENT_EXCHANGES+5; i++ {
+		redirectReq, err := http.NewRequest("GET", "/bar", nil)
+		assert.NoError(t, err)
+		PrepareRedirect(redirectReq, []*http.Request{originalReq})
+	}
+
+	assert.Equal(t, len(RecentExchanges()), MAX_RECENT_EXCHANGES)
+}
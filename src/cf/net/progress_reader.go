@@ -0,0 +1,75 @@
+package net
+
+import (
+	"io"
+	"os"
+)
+
+type ProgressReporter interface {
+	Update(sent, total int64)
+}
+
+// ProgressReader tells reporter how much of body has been read each time the
+// http client reads from it. Seeking back restarts the count, so a retried
+// request is reported from the start again.
+type ProgressReader struct {
+	body     io.ReadSeeker
+	size     int64
+	sent     int64
+	reporter ProgressReporter
+}
+
+func NewProgressReader(body io.ReadSeeker, reporter ProgressReporter) (reader *ProgressReader, err error) {
+	reader = &ProgressReader{
+		body:     body,
+		reporter: reporter,
+	}
+
+	switch body := body.(type) {
+	case *os.File:
+		var stat os.FileInfo
+		stat, err = body.Stat()
+		if err != nil {
+			return
+		}
+		reader.size = stat.Size()
+	case sizedBody:
+		reader.size = body.Size()
+	}
+	return
+}
+
+func (reader *ProgressReader) Size() int64 {
+	return reader.size
+}
+
+func (reader *ProgressReader) Read(p []byte) (n int, err error) {
+	n, err = reader.body.Read(p)
+	if n > 0 {
+		reader.sent += int64(n)
+		reader.reporter.Update(reader.sent, reader.size)
+	}
+	return
+}
+
+func (reader *ProgressReader) Seek(offset int64, whence int) (position int64, err error) {
+	position, err = reader.body.Seek(offset, whence)
+	if err != nil {
+		return
+	}
+
+	reader.sent = position
+	if position == 0 {
+		reader.reporter.Update(0, reader.size)
+	}
+	return
+}
+
+// Close closes the body when it can be, as the http client expects of a
+// request body.
+func (reader *ProgressReader) Close() (err error) {
+	if closer, ok := reader.body.(io.Closer); ok {
+		err = closer.Close()
+	}
+	return
+}
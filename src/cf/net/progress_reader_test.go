@@ -0,0 +1,56 @@
+package net
+
+import (
+	"fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+type fakeProgressReporter struct {
+	updates [][]int64
+}
+
+func (reporter *fakeProgressReporter) Update(sent, total int64) {
+	reporter.updates = append(reporter.updates, []int64{sent, total})
+}
+
+func TestProgressReaderReportsBytesRead(t *testing.T) {
+	fileutils.TempFile("progress_test", func(file *os.File, err error) {
+		assert.NoError(t, err)
+		_, err = file.WriteString("0123456789")
+		assert.NoError(t, err)
+
+		reporter := &fakeProgressReporter{}
+		reader, err := NewProgressReader(file, reporter)
+		assert.NoError(t, err)
+		assert.Equal(t, reader.Size(), int64(10))
+
+		_, err = reader.Seek(0, 0)
+		assert.NoError(t, err)
+
+		buffer := make([]byte, 4)
+		reader.Read(buffer)
+		contents, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, string(contents), "456789")
+
+		assert.Equal(t, reporter.updates, [][]int64{{0, 10}, {4, 10}, {10, 10}})
+	})
+}
+
+func TestNewRequestUsesTheProgressReaderSizeAsContentLength(t *testing.T) {
+	fileutils.TempFile("progress_test", func(file *os.File, err error) {
+		assert.NoError(t, err)
+		_, err = file.WriteString("0123456789")
+		assert.NoError(t, err)
+
+		reader, err := NewProgressReader(file, &fakeProgressReporter{})
+		assert.NoError(t, err)
+
+		request, apiResponse := NewCloudControllerGateway().NewRequest("PUT", "https://example.com/v2/apps/app-guid/bits", "BEARER my-token", reader)
+		assert.True(t, apiResponse.IsSuccessful())
+		assert.Equal(t, request.HttpReq.ContentLength, int64(10))
+	})
+}
@@ -0,0 +1,56 @@
+package net
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// StreamingBody is a request body produced on the fly by write, through a
+// pipe, instead of being kept on disk or in memory. Since the gateway rewinds
+// bodies to retry requests, rewinding starts write over again; write must
+// produce the same size bytes every time.
+type StreamingBody struct {
+	write  func(writer io.Writer) error
+	size   int64
+	reader *io.PipeReader
+}
+
+func NewStreamingBody(size int64, write func(writer io.Writer) error) *StreamingBody {
+	return &StreamingBody{write: write, size: size}
+}
+
+func (body *StreamingBody) Size() int64 {
+	return body.size
+}
+
+func (body *StreamingBody) Read(p []byte) (n int, err error) {
+	if body.reader == nil {
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(body.write(writer))
+		}()
+		body.reader = reader
+	}
+	return body.reader.Read(p)
+}
+
+// Seek only supports going back to the start of the body.
+func (body *StreamingBody) Seek(offset int64, whence int) (position int64, err error) {
+	if offset != 0 || whence != os.SEEK_SET {
+		err = errors.New("A streaming body can only be rewound to its start")
+		return
+	}
+
+	body.Close()
+	return
+}
+
+// Close stops the write in progress, if any.
+func (body *StreamingBody) Close() (err error) {
+	if body.reader != nil {
+		err = body.reader.Close()
+		body.reader = nil
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestStreamingBodyStartsOverWhenRewound(t *testing.T) {
+	writes := 0
+	body := NewStreamingBody(11, func(writer io.Writer) (err error) {
+		writes++
+		_, err = fmt.Fprint(writer, "hello world")
+		return
+	})
+
+	buffer := make([]byte, 5)
+	_, err := io.ReadFull(body, buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, string(buffer), "hello")
+
+	position, err := body.Seek(0, os.SEEK_SET)
+	assert.NoError(t, err)
+	assert.Equal(t, position, int64(0))
+
+	contents, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, string(contents), "hello world")
+	assert.Equal(t, writes, 2)
+	assert.Equal(t, body.Size(), int64(11))
+}
+
+func TestStreamingBodyCannotSeekElsewhere(t *testing.T) {
+	body := NewStreamingBody(0, func(writer io.Writer) error { return nil })
+
+	_, err := body.Seek(5, os.SEEK_SET)
+	assert.Error(t, err)
+
+	_, err = body.Seek(0, os.SEEK_END)
+	assert.Error(t, err)
+}
+
+func TestStreamingBodyReturnsWriteErrors(t *testing.T) {
+	body := NewStreamingBody(0, func(writer io.Writer) error {
+		fmt.Fprint(writer, "partial")
+		return errors.New("disk on fire")
+	})
+
+	contents, err := ioutil.ReadAll(body)
+	assert.Equal(t, string(contents), "partial")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disk on fire")
+}
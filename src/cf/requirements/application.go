@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/api"
+	"cf/errors"
 	"cf/net"
 	"cf/terminal"
 )
@@ -27,16 +28,16 @@ func newApplicationRequirement(name string, ui terminal.UI, aR api.ApplicationRe
 	return
 }
 
-func (req *applicationApiRequirement) Execute() (success bool) {
+func (req *applicationApiRequirement) Execute() (err errors.Error) {
 	var apiResponse net.ApiResponse
 	req.application, apiResponse = req.appRepo.Read(req.name)
 
 	if apiResponse.IsNotSuccessful() {
-		req.ui.Failed(apiResponse.Message)
-		return false
+		err = req.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
-	return true
+	return
 }
 
 func (req *applicationApiRequirement) GetApplication() cf.Application {
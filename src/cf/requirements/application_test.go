@@ -16,9 +16,9 @@ func TestApplicationReqExecute(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	appReq := newApplicationRequirement("foo", ui, appRepo)
-	success := appReq.Execute()
+	err := appReq.Execute()
 
-	assert.True(t, success)
+	assert.NoError(t, err)
 	assert.Equal(t, appRepo.ReadName, "foo")
 	assert.Equal(t, appReq.GetApplication(), app)
 }
@@ -28,7 +28,7 @@ func TestApplicationReqExecuteWhenApplicationNotFound(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	appReq := newApplicationRequirement("foo", ui, appRepo)
-	success := appReq.Execute()
+	err := appReq.Execute()
 
-	assert.False(t, success)
+	assert.Error(t, err)
 }
@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/api"
+	"cf/errors"
 	"cf/net"
 	"cf/terminal"
 )
@@ -27,16 +28,16 @@ func newBuildpackRequirement(name string, ui terminal.UI, bR api.BuildpackReposi
 	return
 }
 
-func (req *buildpackApiRequirement) Execute() (success bool) {
+func (req *buildpackApiRequirement) Execute() (err errors.Error) {
 	var apiResponse net.ApiResponse
 	req.buildpack, apiResponse = req.buildpackRepo.FindByName(req.name)
 
 	if apiResponse.IsNotSuccessful() {
-		req.ui.Failed(apiResponse.Message)
-		return false
+		err = req.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
-	return true
+	return
 }
 
 func (req *buildpackApiRequirement) GetBuildpack() cf.Buildpack {
@@ -16,9 +16,9 @@ func TestBuildpackReqExecute(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	buildpackReq := newBuildpackRequirement("foo", ui, buildpackRepo)
-	success := buildpackReq.Execute()
+	err := buildpackReq.Execute()
 
-	assert.True(t, success)
+	assert.NoError(t, err)
 	assert.Equal(t, buildpackRepo.FindByNameName, "foo")
 	assert.Equal(t, buildpackReq.GetBuildpack(), buildpack)
 }
@@ -28,7 +28,7 @@ func TestBuildpackReqExecuteWhenBuildpackNotFound(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	buildpackReq := newBuildpackRequirement("foo", ui, buildpackRepo)
-	success := buildpackReq.Execute()
+	err := buildpackReq.Execute()
 
-	assert.False(t, success)
+	assert.Error(t, err)
 }
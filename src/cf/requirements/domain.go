@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/api"
+	"cf/errors"
 	"cf/net"
 	"cf/terminal"
 )
@@ -27,16 +28,16 @@ func newDomainRequirement(name string, ui terminal.UI, domainRepo api.DomainRepo
 	return
 }
 
-func (req *domainApiRequirement) Execute() bool {
+func (req *domainApiRequirement) Execute() (err errors.Error) {
 	var apiResponse net.ApiResponse
 	req.domain, apiResponse = req.domainRepo.FindByNameInCurrentSpace(req.name)
 
 	if apiResponse.IsNotSuccessful() {
-		req.ui.Failed(apiResponse.Message)
-		return false
+		err = req.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
-	return true
+	return
 }
 
 func (req *domainApiRequirement) GetDomain() cf.Domain {
@@ -16,9 +16,9 @@ func TestDomainReqExecute(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	domainReq := newDomainRequirement("example.com", ui, domainRepo)
-	success := domainReq.Execute()
+	err := domainReq.Execute()
 
-	assert.True(t, success)
+	assert.NoError(t, err)
 	assert.Equal(t, domainRepo.FindByNameInCurrentSpaceName, "example.com")
 	assert.Equal(t, domainReq.GetDomain(), domain)
 }
@@ -28,9 +28,9 @@ func TestDomainReqWhenDomainDoesNotExist(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	domainReq := newDomainRequirement("example.com", ui, domainRepo)
-	success := domainReq.Execute()
+	err := domainReq.Execute()
 
-	assert.False(t, success)
+	assert.Error(t, err)
 }
 
 func TestDomainReqOnError(t *testing.T) {
@@ -38,7 +38,7 @@ func TestDomainReqOnError(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	domainReq := newDomainRequirement("example.com", ui, domainRepo)
-	success := domainReq.Execute()
+	err := domainReq.Execute()
 
-	assert.False(t, success)
+	assert.Error(t, err)
 }
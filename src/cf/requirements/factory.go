@@ -3,11 +3,12 @@ package requirements
 import (
 	"cf/api"
 	"cf/configuration"
+	"cf/errors"
 	"cf/terminal"
 )
 
 type Requirement interface {
-	Execute() (success bool)
+	Execute() (err errors.Error)
 }
 
 type Factory interface {
@@ -2,6 +2,7 @@ package requirements
 
 import (
 	"cf/configuration"
+	"cf/errors"
 	"cf/terminal"
 )
 
@@ -14,10 +15,10 @@ func newLoginRequirement(ui terminal.UI, config *configuration.Configuration) Lo
 	return LoginRequirement{ui, config}
 }
 
-func (req LoginRequirement) Execute() (success bool) {
+func (req LoginRequirement) Execute() (err errors.Error) {
 	if !req.config.IsLoggedIn() {
-		req.ui.Say(terminal.NotLoggedInText())
-		return false
+		err = req.ui.FailWithError(errors.NewNotLoggedInError(terminal.NotLoggedInText()))
+		return
 	}
-	return true
+	return
 }
@@ -2,6 +2,7 @@ package requirements
 
 import (
 	"cf/configuration"
+	"cf/errors"
 	"github.com/stretchr/testify/assert"
 	testassert "testhelpers/assert"
 	testterm "testhelpers/terminal"
@@ -15,16 +16,17 @@ func TestLoginRequirement(t *testing.T) {
 	}
 
 	req := newLoginRequirement(ui, config)
-	success := req.Execute()
-	assert.True(t, success)
+	err := req.Execute()
+	assert.NoError(t, err)
 
 	config = &configuration.Configuration{
 		AccessToken: "",
 	}
 
 	req = newLoginRequirement(ui, config)
-	success = req.Execute()
-	assert.False(t, success)
+	err = req.Execute()
+	assert.Error(t, err)
 
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"Not logged in."}})
+	assert.Equal(t, ui.FailedWithError.ExitCode(), errors.EXIT_NOT_LOGGED_IN)
 }
@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/api"
+	"cf/errors"
 	"cf/net"
 	"cf/terminal"
 )
@@ -27,16 +28,16 @@ func newOrganizationRequirement(name string, ui terminal.UI, sR api.Organization
 	return
 }
 
-func (req *organizationApiRequirement) Execute() (success bool) {
+func (req *organizationApiRequirement) Execute() (err errors.Error) {
 	var apiResponse net.ApiResponse
 	req.org, apiResponse = req.orgRepo.FindByName(req.name)
 
 	if apiResponse.IsNotSuccessful() {
-		req.ui.Failed(apiResponse.Message)
-		return false
+		err = req.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
-	return true
+	return
 }
 
 func (req *organizationApiRequirement) GetOrganization() cf.Organization {
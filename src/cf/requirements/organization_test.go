@@ -16,9 +16,9 @@ func TestOrgReqExecute(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	orgReq := newOrganizationRequirement("foo", ui, orgRepo)
-	success := orgReq.Execute()
+	err := orgReq.Execute()
 
-	assert.True(t, success)
+	assert.NoError(t, err)
 	assert.Equal(t, orgRepo.FindByNameName, "foo")
 	assert.Equal(t, orgReq.GetOrganization(), org)
 }
@@ -28,7 +28,7 @@ func TestOrgReqWhenOrgDoesNotExist(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	orgReq := newOrganizationRequirement("foo", ui, orgRepo)
-	success := orgReq.Execute()
+	err := orgReq.Execute()
 
-	assert.False(t, success)
+	assert.Error(t, err)
 }
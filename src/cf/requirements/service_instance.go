@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/api"
+	"cf/errors"
 	"cf/net"
 	"cf/terminal"
 )
@@ -27,16 +28,16 @@ func newServiceInstanceRequirement(name string, ui terminal.UI, sR api.ServiceRe
 	return
 }
 
-func (req *serviceInstanceApiRequirement) Execute() (success bool) {
+func (req *serviceInstanceApiRequirement) Execute() (err errors.Error) {
 	var apiResponse net.ApiResponse
 	req.serviceInstance, apiResponse = req.serviceRepo.FindInstanceByName(req.name)
 
 	if apiResponse.IsNotSuccessful() {
-		req.ui.Failed(apiResponse.Message)
-		return false
+		err = req.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
-	return true
+	return
 }
 
 func (req *serviceInstanceApiRequirement) GetServiceInstance() cf.ServiceInstance {
@@ -16,9 +16,9 @@ func TestServiceInstanceReqExecute(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	req := newServiceInstanceRequirement("foo", ui, repo)
-	success := req.Execute()
+	err := req.Execute()
 
-	assert.True(t, success)
+	assert.NoError(t, err)
 	assert.Equal(t, repo.FindInstanceByNameName, "foo")
 	assert.Equal(t, req.GetServiceInstance(), instance)
 }
@@ -28,7 +28,7 @@ func TestServiceInstanceReqExecuteWhenServiceInstanceNotFound(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	req := newServiceInstanceRequirement("foo", ui, repo)
-	success := req.Execute()
+	err := req.Execute()
 
-	assert.False(t, success)
+	assert.Error(t, err)
 }
@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/api"
+	"cf/errors"
 	"cf/net"
 	"cf/terminal"
 )
@@ -27,16 +28,16 @@ func newSpaceRequirement(name string, ui terminal.UI, sR api.SpaceRepository) (r
 	return
 }
 
-func (req *spaceApiRequirement) Execute() (success bool) {
+func (req *spaceApiRequirement) Execute() (err errors.Error) {
 	var apiResponse net.ApiResponse
 	req.space, apiResponse = req.spaceRepo.FindByName(req.name)
 
 	if apiResponse.IsNotSuccessful() {
-		req.ui.Failed(apiResponse.Message)
-		return false
+		err = req.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
-	return true
+	return
 }
 
 func (req *spaceApiRequirement) GetSpace() cf.Space {
@@ -16,9 +16,9 @@ func TestSpaceReqExecute(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	spaceReq := newSpaceRequirement("foo", ui, spaceRepo)
-	success := spaceReq.Execute()
+	err := spaceReq.Execute()
 
-	assert.True(t, success)
+	assert.NoError(t, err)
 	assert.Equal(t, spaceRepo.FindByNameName, "foo")
 	assert.Equal(t, spaceReq.GetSpace(), space)
 }
@@ -28,7 +28,7 @@ func TestSpaceReqExecuteWhenSpaceNotFound(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	spaceReq := newSpaceRequirement("foo", ui, spaceRepo)
-	success := spaceReq.Execute()
+	err := spaceReq.Execute()
 
-	assert.False(t, success)
+	assert.Error(t, err)
 }
@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/configuration"
+	"cf/errors"
 	"cf/terminal"
 	"fmt"
 )
@@ -21,15 +22,15 @@ func newTargetedOrgRequirement(ui terminal.UI, config *configuration.Configurati
 	return targetedOrgApiRequirement{ui, config}
 }
 
-func (req targetedOrgApiRequirement) Execute() (success bool) {
+func (req targetedOrgApiRequirement) Execute() (err errors.Error) {
 	if !req.config.HasOrganization() {
 		message := fmt.Sprintf("No org targeted, use '%s' to target an org.",
 			terminal.CommandColor(cf.Name()+" target -o ORG"))
-		req.ui.Failed(message)
-		return false
+		err = req.ui.Failed(message)
+		return
 	}
 
-	return true
+	return
 }
 
 func (req targetedOrgApiRequirement) GetOrganizationFields() (org cf.OrganizationFields) {
@@ -19,14 +19,14 @@ func TestTargetedOrgRequirement(t *testing.T) {
 	}
 
 	req := newTargetedOrgRequirement(ui, config)
-	success := req.Execute()
-	assert.True(t, success)
+	err := req.Execute()
+	assert.NoError(t, err)
 
 	config.OrganizationFields = cf.OrganizationFields{}
 
 	req = newTargetedOrgRequirement(ui, config)
-	success = req.Execute()
-	assert.False(t, success)
+	err = req.Execute()
+	assert.Error(t, err)
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
 		{"FAILED"},
 		{"No org targeted"},
@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/configuration"
+	"cf/errors"
 	"cf/terminal"
 	"fmt"
 )
@@ -16,19 +17,19 @@ func newTargetedSpaceRequirement(ui terminal.UI, config *configuration.Configura
 	return TargetedSpaceRequirement{ui, config}
 }
 
-func (req TargetedSpaceRequirement) Execute() (success bool) {
+func (req TargetedSpaceRequirement) Execute() (err errors.Error) {
 	if !req.config.HasOrganization() {
 		message := fmt.Sprintf("No org and space targeted, use '%s' to target an org and space",
 			terminal.CommandColor(cf.Name()+" target -o ORG -s SPACE"))
-		req.ui.Failed(message)
-		return false
+		err = req.ui.Failed(message)
+		return
 	}
 
 	if !req.config.HasSpace() {
 		message := fmt.Sprintf("No space targeted, use '%s' to target a space", terminal.CommandColor("cf target -s"))
-		req.ui.Failed(message)
-		return false
+		err = req.ui.Failed(message)
+		return
 	}
 
-	return true
+	return
 }
@@ -24,14 +24,14 @@ func TestSpaceRequirement(t *testing.T) {
 	}
 
 	req := newTargetedSpaceRequirement(ui, config)
-	success := req.Execute()
-	assert.True(t, success)
+	err := req.Execute()
+	assert.NoError(t, err)
 
 	config.SpaceFields = cf.SpaceFields{}
 
 	req = newTargetedSpaceRequirement(ui, config)
-	success = req.Execute()
-	assert.False(t, success)
+	err = req.Execute()
+	assert.Error(t, err)
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
 		{"FAILED"},
 		{"No space targeted"},
@@ -41,8 +41,8 @@ func TestSpaceRequirement(t *testing.T) {
 	config.OrganizationFields = cf.OrganizationFields{}
 
 	req = newTargetedSpaceRequirement(ui, config)
-	success = req.Execute()
-	assert.False(t, success)
+	err = req.Execute()
+	assert.Error(t, err)
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
 		{"FAILED"},
 		{"No org and space targeted"},
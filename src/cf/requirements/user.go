@@ -3,6 +3,7 @@ package requirements
 import (
 	"cf"
 	"cf/api"
+	"cf/errors"
 	"cf/net"
 	"cf/terminal"
 )
@@ -27,16 +28,16 @@ func newUserRequirement(username string, ui terminal.UI, userRepo api.UserReposi
 	return
 }
 
-func (req *userApiRequirement) Execute() (success bool) {
+func (req *userApiRequirement) Execute() (err errors.Error) {
 	var apiResponse net.ApiResponse
 	req.user, apiResponse = req.userRepo.FindByUsername(req.username)
 
 	if apiResponse.IsNotSuccessful() {
-		req.ui.Failed(apiResponse.Message)
-		return false
+		err = req.ui.FailWithError(apiResponse.ToError())
+		return
 	}
 
-	return true
+	return
 }
 
 func (req *userApiRequirement) GetUser() cf.UserFields {
@@ -18,9 +18,9 @@ func TestUserReqExecute(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	userReq := newUserRequirement("foo", ui, userRepo)
-	success := userReq.Execute()
+	err := userReq.Execute()
 
-	assert.True(t, success)
+	assert.NoError(t, err)
 	assert.Equal(t, userRepo.FindByUsernameUsername, "foo")
 	assert.Equal(t, userReq.GetUser(), user)
 }
@@ -30,9 +30,9 @@ func TestUserReqWhenUserDoesNotExist(t *testing.T) {
 	ui := new(testterm.FakeUI)
 
 	userReq := newUserRequirement("foo", ui, userRepo)
-	success := userReq.Execute()
+	err := userReq.Execute()
 
-	assert.False(t, success)
+	assert.Error(t, err)
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
 		{"FAILED"},
 		{"UserFields not found"},
@@ -2,6 +2,7 @@ package requirements
 
 import (
 	"cf/api"
+	"cf/errors"
 	"cf/terminal"
 )
 
@@ -14,13 +15,13 @@ func newValidAccessTokenRequirement(ui terminal.UI, appRepo api.ApplicationRepos
 	return ValidAccessTokenRequirement{ui, appRepo}
 }
 
-func (req ValidAccessTokenRequirement) Execute() (success bool) {
+func (req ValidAccessTokenRequirement) Execute() (err errors.Error) {
 	_, apiResponse := req.appRepo.Read("checking_for_valid_access_token")
 
 	if apiResponse.IsNotSuccessful() && apiResponse.StatusCode == 401 {
-		req.ui.Say(terminal.NotLoggedInText())
-		return false
+		err = req.ui.FailWithError(errors.NewNotLoggedInError(terminal.NotLoggedInText()))
+		return
 	}
 
-	return true
+	return
 }
@@ -15,13 +15,13 @@ func TestValidAccessRequirement(t *testing.T) {
 	}
 
 	req := newValidAccessTokenRequirement(ui, appRepo)
-	success := req.Execute()
-	assert.False(t, success)
+	err := req.Execute()
+	assert.Error(t, err)
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"Not logged in."}})
 
 	appRepo.ReadAuthErr = false
 
 	req = newValidAccessTokenRequirement(ui, appRepo)
-	success = req.Execute()
-	assert.True(t, success)
+	err = req.Execute()
+	assert.NoError(t, err)
 }
@@ -0,0 +1,71 @@
+package terminal
+
+import (
+	"cf/errors"
+	"fmt"
+	"os"
+)
+
+var nonInteractive bool
+
+// EnableNonInteractive makes every prompt fail instead of reading stdin.
+func EnableNonInteractive() {
+	nonInteractive = true
+}
+
+func DisableNonInteractive() {
+	nonInteractive = false
+}
+
+func IsNonInteractive() bool {
+	return nonInteractive
+}
+
+func StdinIsTerminal() bool {
+	stat, err := os.Stdin.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+func StdoutIsTerminal() bool {
+	stat, err := os.Stdout.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+func NewNonInteractiveError(name, flag string) errors.Error {
+	message := fmt.Sprintf("Cannot prompt for %s in non-interactive mode.", name)
+	if flag != "" {
+		message = fmt.Sprintf("%s Use %s to provide it.", message, flag)
+	}
+	return errors.NewUsageError(message)
+}
+
+// PromptFor asks for the named value, or fails naming the flag that supplies
+// it when prompts are disabled.
+func PromptFor(ui UI, name, flag string) (answer string, err errors.Error) {
+	if IsNonInteractive() {
+		err = ui.FailWithError(NewNonInteractiveError(name, flag))
+		return
+	}
+	answer = ui.Ask("%s%s", name, PromptColor(">"))
+	return
+}
+
+func PromptForPassword(ui UI, name, flag string) (answer string, err errors.Error) {
+	if IsNonInteractive() {
+		err = ui.FailWithError(NewNonInteractiveError(name, flag))
+		return
+	}
+	answer = ui.AskForPassword("%s%s", name, PromptColor(">"))
+	return
+}
+
+// Confirm asks the user to confirm, or fails naming -f when prompts are
+// disabled.
+func Confirm(ui UI, message string, args ...interface{}) (confirmed bool, err errors.Error) {
+	if IsNonInteractive() {
+		err = ui.FailWithError(NewNonInteractiveError("confirmation", "-f"))
+		return
+	}
+	confirmed = ui.Confirm(message, args...)
+	return
+}
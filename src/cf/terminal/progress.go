@@ -0,0 +1,130 @@
+package terminal
+
+import (
+	"cf/formatters"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	progressBarWidth       = 30
+	progressRedrawInterval = 100 * time.Millisecond
+	ProgressLineInterval   = 5 * time.Second
+)
+
+// ProgressBar reports how much of a transfer is done. On a terminal it
+// redraws a single line with a bar, the bytes sent, the rate and the time
+// left; otherwise it prints a percentage line every ProgressLineInterval so
+// logs don't fill up with redraws.
+type ProgressBar struct {
+	writer     io.Writer
+	isTerminal bool
+	total      int64
+	startedAt  time.Time
+	reportedAt time.Time
+	finished   bool
+	now        func() time.Time
+}
+
+func NewProgressBar() *ProgressBar {
+	return newProgressBar(os.Stdout, StdoutIsTerminal(), time.Now)
+}
+
+func newProgressBar(writer io.Writer, isTerminal bool, now func() time.Time) (bar *ProgressBar) {
+	bar = &ProgressBar{
+		writer:     writer,
+		isTerminal: isTerminal,
+		now:        now,
+	}
+	bar.startedAt = now()
+	return
+}
+
+// Update records that sent bytes out of total have been transferred. Going
+// back to no bytes sent restarts the transfer, as happens when a request is
+// retried.
+func (bar *ProgressBar) Update(sent, total int64) {
+	now := bar.now()
+	bar.total = total
+	if sent == 0 {
+		bar.startedAt = now
+		bar.finished = false
+	}
+
+	if bar.finished {
+		return
+	}
+
+	done := sent >= bar.total
+	if bar.isTerminal {
+		if done || now.Sub(bar.reportedAt) >= progressRedrawInterval {
+			bar.reportedAt = now
+			fmt.Fprintf(bar.writer, "\r%s\033[K", bar.render(sent, now))
+		}
+	} else if done || (now.Sub(bar.reportedAt) >= ProgressLineInterval && now.Sub(bar.startedAt) >= ProgressLineInterval) {
+		bar.reportedAt = now
+		fmt.Fprintf(bar.writer, "Uploaded %s of %s (%d%%)\n",
+			formatters.ByteSize(uint64(sent)),
+			formatters.ByteSize(uint64(bar.total)),
+			bar.percentage(sent),
+		)
+	}
+
+	if done {
+		bar.finished = true
+		if bar.isTerminal {
+			fmt.Fprintln(bar.writer, "")
+		}
+	}
+}
+
+func (bar *ProgressBar) render(sent int64, now time.Time) string {
+	filled := int(int64(progressBarWidth) * sent / maxInt64(bar.total, 1))
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+
+	elapsed := now.Sub(bar.startedAt)
+	rate := float64(0)
+	if elapsed > 0 {
+		rate = float64(sent) / elapsed.Seconds()
+	}
+
+	eta := "--"
+	if sent >= bar.total {
+		eta = "0s"
+	} else if rate > 0 {
+		eta = (time.Duration(float64(bar.total-sent)/rate) * time.Second).String()
+	}
+
+	return fmt.Sprintf("[%s%s] %3d%% %s/%s %s/s ETA %s",
+		strings.Repeat("=", filled),
+		strings.Repeat(" ", progressBarWidth-filled),
+		bar.percentage(sent),
+		formatters.ByteSize(uint64(sent)),
+		formatters.ByteSize(uint64(bar.total)),
+		formatters.ByteSize(uint64(rate)),
+		eta,
+	)
+}
+
+func (bar *ProgressBar) percentage(sent int64) int64 {
+	if bar.total <= 0 {
+		return 100
+	}
+	percentage := 100 * sent / bar.total
+	if percentage > 100 {
+		percentage = 100
+	}
+	return percentage
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
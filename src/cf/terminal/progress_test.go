@@ -0,0 +1,87 @@
+package terminal
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (clock *fakeClock) now() time.Time {
+	return clock.current
+}
+
+func (clock *fakeClock) advance(duration time.Duration) {
+	clock.current = clock.current.Add(duration)
+}
+
+func TestProgressBarOnATerminal(t *testing.T) {
+	out := &bytes.Buffer{}
+	clock := &fakeClock{current: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)}
+	bar := newProgressBar(out, true, clock.now)
+
+	bar.Update(0, 4*1024*1024)
+	clock.advance(2 * time.Second)
+	bar.Update(1024*1024, 4*1024*1024)
+
+	lines := strings.Split(out.String(), "\r")
+	assert.Equal(t, len(lines), 3)
+	assert.Contains(t, lines[1], "[                              ]   0% 0/4M")
+	assert.Contains(t, lines[2], "[=======                       ]  25% 1M/4M 512K/s ETA 6s")
+
+	clock.advance(6 * time.Second)
+	bar.Update(4*1024*1024, 4*1024*1024)
+	assert.Contains(t, out.String(), "[==============================] 100% 4M/4M 512K/s ETA 0s\033[K\n")
+}
+
+func TestProgressBarOnATerminalThrottlesRedraws(t *testing.T) {
+	out := &bytes.Buffer{}
+	clock := &fakeClock{current: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)}
+	bar := newProgressBar(out, true, clock.now)
+
+	bar.Update(0, 100)
+	clock.advance(10 * time.Millisecond)
+	bar.Update(10, 100)
+	clock.advance(10 * time.Millisecond)
+	bar.Update(20, 100)
+
+	assert.Equal(t, strings.Count(out.String(), "\r"), 1)
+
+	bar.Update(100, 100)
+	assert.Equal(t, strings.Count(out.String(), "\r"), 2)
+}
+
+func TestProgressBarWhenNotATerminal(t *testing.T) {
+	out := &bytes.Buffer{}
+	clock := &fakeClock{current: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)}
+	bar := newProgressBar(out, false, clock.now)
+
+	bar.Update(0, 1000)
+	clock.advance(time.Second)
+	bar.Update(100, 1000)
+	clock.advance(ProgressLineInterval)
+	bar.Update(600, 1000)
+	clock.advance(time.Second)
+	bar.Update(700, 1000)
+	bar.Update(1000, 1000)
+	bar.Update(1000, 1000)
+
+	assert.Equal(t, out.String(), "Uploaded 600 of 1000 (60%)\nUploaded 1000 of 1000 (100%)\n")
+}
+
+func TestProgressBarRestartsWhenTheUploadIsRetried(t *testing.T) {
+	out := &bytes.Buffer{}
+	clock := &fakeClock{current: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)}
+	bar := newProgressBar(out, false, clock.now)
+
+	bar.Update(1000, 1000)
+	bar.Update(0, 1000)
+	bar.Update(1000, 1000)
+
+	assert.Equal(t, out.String(), "Uploaded 1000 of 1000 (100%)\nUploaded 1000 of 1000 (100%)\n")
+}
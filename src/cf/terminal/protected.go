@@ -0,0 +1,66 @@
+package terminal
+
+import (
+	"cf/errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const ALLOW_PROTECTED_DESTRUCTIVE = "CF_ALLOW_PROTECTED_DESTRUCTIVE"
+
+func AllowProtectedDestructive() bool {
+	allow := os.Getenv(ALLOW_PROTECTED_DESTRUCTIVE)
+	return allow != "" && allow != "false"
+}
+
+// ConfirmProtectedDeletion lists what a deletion will cascade to and asks the
+// user to type the resource name. Forcing is only honored when
+// CF_ALLOW_PROTECTED_DESTRUCTIVE is set.
+func ConfirmProtectedDeletion(ui UI, resourceType, resourceName string, cascades []string, force bool) (confirmed bool, err errors.Error) {
+	if force {
+		if AllowProtectedDestructive() {
+			ui.Warn("%s %s is protected, deleting it because %s is set.", strings.Title(resourceType), resourceName, ALLOW_PROTECTED_DESTRUCTIVE)
+			confirmed = true
+			return
+		}
+
+		err = ui.Failed("%s %s is protected and cannot be deleted with -f.\nConfirm the deletion interactively or set %s=true.",
+			strings.Title(resourceType), resourceName, ALLOW_PROTECTED_DESTRUCTIVE)
+		return
+	}
+
+	ui.Warn("%s %s is protected.", strings.Title(resourceType), EntityNameColor(resourceName))
+	if len(cascades) > 0 {
+		ui.Say("Deleting it will also remove:")
+		for _, cascade := range cascades {
+			ui.Say("  %s", cascade)
+		}
+	}
+
+	if IsNonInteractive() {
+		err = ui.FailWithError(NewNonInteractiveError("the "+resourceType+" name", fmt.Sprintf("-f with %s=true", ALLOW_PROTECTED_DESTRUCTIVE)))
+		return
+	}
+
+	answer := ui.Ask("Type the name of the %s to confirm deletion%s", resourceType, PromptColor(">"))
+	if answer != resourceName {
+		ui.Say("Name did not match, nothing was deleted.")
+		return
+	}
+	confirmed = true
+	return
+}
+
+// CascadeLine formats a list of dependent resources for ConfirmProtectedDeletion.
+func CascadeLine(label string, names []string) string {
+	if len(names) == 0 {
+		return label + ": none"
+	}
+	return label + ": " + strings.Join(names, ", ")
+}
+
+// CascadeCount formats a number of dependent resources for ConfirmProtectedDeletion.
+func CascadeCount(label string, count int) string {
+	return fmt.Sprintf("%s: %d", label, count)
+}
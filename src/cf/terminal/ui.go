@@ -3,6 +3,7 @@ package terminal
 import (
 	"cf"
 	"cf/configuration"
+	"cf/errors"
 	"cf/trace"
 	"fmt"
 	"github.com/codegangsta/cli"
@@ -19,19 +20,21 @@ func NotLoggedInText() string {
 }
 
 type UI interface {
-	PrintPaginator(rows []string, err error)
+	PrintPaginator(rows []string, err error) errors.Error
 	Say(message string, args ...interface{})
 	Warn(message string, args ...interface{})
 	Ask(prompt string, args ...interface{}) (answer string)
 	AskForPassword(prompt string, args ...interface{}) (answer string)
 	Confirm(message string, args ...interface{}) bool
 	Ok()
-	Failed(message string, args ...interface{})
+	Failed(message string, args ...interface{}) errors.Error
+	FailWithError(err error) errors.Error
 	FailWithUsage(ctxt *cli.Context, cmdName string)
-	ConfigFailure(err error)
+	ConfigFailure(err error) errors.Error
 	ShowConfiguration(*configuration.Configuration)
 	LoadingIndication()
 	Wait(duration time.Duration)
+	ClearScreen()
 	DisplayTable(table [][]string)
 	Table(headers []string) Table
 }
@@ -40,20 +43,21 @@ type terminalUI struct {
 }
 
 var stdin io.Reader = os.Stdin
+var stderr io.Writer = os.Stderr
 
 func NewUI() UI {
 	return terminalUI{}
 }
 
-func (c terminalUI) PrintPaginator(rows []string, err error) {
+func (c terminalUI) PrintPaginator(rows []string, err error) errors.Error {
 	if err != nil {
-		c.Failed(err.Error())
-		return
+		return c.Failed(err.Error())
 	}
 
 	for _, row := range rows {
 		c.Say(row)
 	}
+	return nil
 }
 
 func (c terminalUI) Say(message string, args ...interface{}) {
@@ -76,7 +80,13 @@ func (c terminalUI) Confirm(message string, args ...interface{}) bool {
 	return false
 }
 
+// Ask reads the answer from stdin. It never blocks in non-interactive mode;
+// commands prompt through PromptFor and Confirm, which fail there instead.
 func (c terminalUI) Ask(prompt string, args ...interface{}) (answer string) {
+	if IsNonInteractive() {
+		return
+	}
+
 	fmt.Println("")
 	fmt.Printf(prompt+" ", args...)
 	fmt.Fscanln(stdin, &answer)
@@ -87,26 +97,36 @@ func (c terminalUI) Ok() {
 	c.Say(SuccessColor("OK"))
 }
 
-func (c terminalUI) Failed(message string, args ...interface{}) {
+func (c terminalUI) Failed(message string, args ...interface{}) errors.Error {
 	message = fmt.Sprintf(message, args...)
-	c.Say(FailureColor("FAILED"))
-	c.Say(message)
+	return c.FailWithError(errors.New(message))
+}
+
+// FailWithError prints the error to stderr and returns it as an errors.Error,
+// for the command to return to the runner, which maps it to an exit code.
+func (c terminalUI) FailWithError(err error) errors.Error {
+	cfErr, ok := err.(errors.Error)
+	if !ok {
+		cfErr = errors.New(err.Error())
+	}
+
+	fmt.Fprintln(stderr, FailureColor("FAILED"))
+	fmt.Fprintln(stderr, cfErr.Error())
 
 	trace.Logger.Print("FAILED")
-	trace.Logger.Print(message)
-	os.Exit(1)
+	trace.Logger.Print(cfErr.Error())
+	return cfErr
 }
 
 func (c terminalUI) FailWithUsage(ctxt *cli.Context, cmdName string) {
-	c.Say(FailureColor("FAILED"))
-	c.Say("Incorrect Usage.\n")
+	fmt.Fprintln(stderr, FailureColor("FAILED"))
+	fmt.Fprint(stderr, "Incorrect Usage.\n\n")
 	cli.ShowCommandHelp(ctxt, cmdName)
 	c.Say("")
-	os.Exit(1)
 }
 
-func (c terminalUI) ConfigFailure(err error) {
-	c.Failed("Please use '%s api' to set an API endpoint and then 'cf login' to login.", cf.Name())
+func (c terminalUI) ConfigFailure(err error) errors.Error {
+	return c.Failed("Please use '%s api' to set an API endpoint and then 'cf login' to login.", cf.Name())
 }
 
 func (ui terminalUI) ShowConfiguration(config *configuration.Configuration) {
@@ -137,6 +157,17 @@ func (c terminalUI) Wait(duration time.Duration) {
 	time.Sleep(duration)
 }
 
+// ClearScreen moves the cursor home and erases the terminal so the next
+// output redraws the view in place. When stdout is not a terminal it only
+// separates the views with a blank line.
+func (c terminalUI) ClearScreen() {
+	if !StdoutIsTerminal() {
+		fmt.Println("")
+		return
+	}
+	fmt.Print("\033[H\033[2J")
+}
+
 func (ui terminalUI) Table(headers []string) Table {
 	return NewTable(ui, headers)
 }
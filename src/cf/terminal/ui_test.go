@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"bytes"
+	"cf/errors"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"os"
@@ -54,6 +55,72 @@ func TestConfirmNo(t *testing.T) {
 	})
 }
 
+func TestFailedWritesToStderrAndReturnsTheError(t *testing.T) {
+	ui := new(terminalUI)
+	var err errors.Error
+	errOut := captureStderr(func() {
+		err = ui.Failed("Hello %s", "World")
+	})
+
+	assert.Contains(t, errOut, "FAILED")
+	assert.Contains(t, errOut, "Hello World")
+	assert.Equal(t, err.Error(), "Hello World")
+	assert.Equal(t, err.ExitCode(), errors.EXIT_FAILED)
+}
+
+func TestFailWithErrorKeepsExitCode(t *testing.T) {
+	ui := new(terminalUI)
+	var err errors.Error
+	captureStderr(func() {
+		err = ui.FailWithError(errors.NewNotFoundError("App my-app not found"))
+	})
+
+	assert.Equal(t, err.ExitCode(), errors.EXIT_NOT_FOUND)
+}
+
+func TestConfirmFailsInNonInteractiveMode(t *testing.T) {
+	EnableNonInteractive()
+	defer DisableNonInteractive()
+
+	ui := new(terminalUI)
+	var (
+		confirmed bool
+		err       errors.Error
+	)
+	errOut := captureStderr(func() {
+		confirmed, err = Confirm(ui, "Really delete %s?", "my-app")
+	})
+
+	assert.False(t, confirmed)
+	assert.Equal(t, err.ExitCode(), errors.EXIT_USAGE)
+	assert.Contains(t, errOut, "Cannot prompt for confirmation in non-interactive mode. Use -f to provide it.")
+}
+
+func TestPromptForFailsInNonInteractiveMode(t *testing.T) {
+	EnableNonInteractive()
+	defer DisableNonInteractive()
+
+	ui := new(terminalUI)
+	var err errors.Error
+	errOut := captureStderr(func() {
+		_, err = PromptFor(ui, "Username", "-u")
+	})
+
+	assert.Equal(t, err.ExitCode(), errors.EXIT_USAGE)
+	assert.Contains(t, errOut, "Cannot prompt for Username in non-interactive mode. Use -u to provide it.")
+}
+
+func captureStderr(f func()) string {
+	defer func() {
+		stderr = os.Stderr
+	}()
+
+	buf := new(bytes.Buffer)
+	stderr = buf
+	f()
+	return buf.String()
+}
+
 func simulateStdin(input string, block func()) {
 	defer func() {
 		stdin = os.Stdin
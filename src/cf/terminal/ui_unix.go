@@ -27,6 +27,10 @@ var sttyArgvEOn []string = []string{"stty", "echo"}
 var ws syscall.WaitStatus = 0
 
 func (ui terminalUI) AskForPassword(prompt string, args ...interface{}) (passwd string) {
+	if IsNonInteractive() {
+		return
+	}
+
 	sig := make(chan os.Signal, 10)
 
 	// Display the prompt.
@@ -4,12 +4,25 @@ import (
 	"archive/zip"
 	"errors"
 	"fileutils"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 )
 
 type Zipper interface {
 	Zip(dirToZip string, targetFile *os.File) (err error)
+	WriteZip(dirToZip string, writer io.Writer, options ZipOptions) (err error)
+}
+
+// ZipOptions changes how a directory is zipped. By default symlinks are kept
+// as symlinks; DereferenceSymlinks stores what they point to instead.
+// NormalizeFileModes gives files the mode of a newly created file plus their
+// executable bits, the way app files were stored when they were copied
+// before being zipped.
+type ZipOptions struct {
+	DereferenceSymlinks bool
+	NormalizeFileModes  bool
 }
 
 type ApplicationZipper struct{}
@@ -17,12 +30,19 @@ type ApplicationZipper struct{}
 var doNotZipExtensions = []string{".zip", ".war", ".jar"}
 
 func (zipper ApplicationZipper) Zip(dirOrZipFile string, targetFile *os.File) (err error) {
+	err = zipper.WriteZip(dirOrZipFile, targetFile, ZipOptions{})
+	targetFile.Seek(0, os.SEEK_SET)
+	return
+}
+
+// WriteZip streams the zip of dirOrZipFile to writer, without keeping it on
+// disk.
+func (zipper ApplicationZipper) WriteZip(dirOrZipFile string, writer io.Writer, options ZipOptions) (err error) {
 	if shouldNotZip(filepath.Ext(dirOrZipFile)) {
-		err = fileutils.CopyPathToWriter(dirOrZipFile, targetFile)
+		err = fileutils.CopyPathToWriter(dirOrZipFile, writer)
 	} else {
-		err = writeZipFile(dirOrZipFile, targetFile)
+		err = writeZipFile(dirOrZipFile, writer, options)
 	}
-	targetFile.Seek(0, os.SEEK_SET)
 	return
 }
 
@@ -35,7 +55,7 @@ func shouldNotZip(extension string) (result bool) {
 	return
 }
 
-func writeZipFile(dir string, targetFile *os.File) (err error) {
+func writeZipFile(dir string, target io.Writer, options ZipOptions) (err error) {
 	isEmpty, err := fileutils.IsDirEmpty(dir)
 	if err != nil {
 		return
@@ -45,25 +65,134 @@ func writeZipFile(dir string, targetFile *os.File) (err error) {
 		return
 	}
 
-	writer := zip.NewWriter(targetFile)
-	defer writer.Close()
+	appZip := &appZipWriter{
+		writer:      zip.NewWriter(target),
+		options:     options,
+		visitedDirs: map[string]bool{},
+		dirs:        map[string]os.FileInfo{},
+		writtenDirs: map[string]bool{},
+	}
 
-	err = WalkAppFiles(dir, func(fileName string, fullPath string) (err error) {
-		fileInfo, err := os.Stat(fullPath)
-		if err != nil {
-			return err
+	err = appZip.writeDir(dir, "")
+	if err != nil {
+		appZip.writer.Close()
+		return
+	}
+
+	return appZip.writer.Close()
+}
+
+type appZipWriter struct {
+	writer  *zip.Writer
+	options ZipOptions
+
+	// directories being zipped, so that dereferenced symlinks to a parent
+	// directory don't loop forever
+	visitedDirs map[string]bool
+
+	// directories are only written once something is stored in them, so
+	// that directories whose files are all ignored are left out
+	dirs        map[string]os.FileInfo
+	writtenDirs map[string]bool
+}
+
+// writeDir adds the entries of dir to the zip, with their names under prefix.
+func (appZip *appZipWriter) writeDir(dir string, prefix string) (err error) {
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return
+	}
+	if appZip.visitedDirs[resolvedDir] {
+		return
+	}
+	appZip.visitedDirs[resolvedDir] = true
+	defer delete(appZip.visitedDirs, resolvedDir)
+
+	return WalkAppEntries(resolvedDir, func(fileName string, fullPath string, fileInfo os.FileInfo) (err error) {
+		name := path.Join(prefix, filepath.ToSlash(fileName))
+
+		if fileInfo.Mode()&os.ModeSymlink != 0 && appZip.options.DereferenceSymlinks {
+			// a dangling link has nothing to dereference, so it stays a link
+			targetInfo, statErr := os.Stat(fullPath)
+			if statErr == nil {
+				fileInfo = targetInfo
+			}
+
+			if fileInfo.IsDir() {
+				appZip.dirs[name] = fileInfo
+				return appZip.writeDir(fullPath, name)
+			}
 		}
 
-		header, err := zip.FileInfoHeader(fileInfo)
-		header.Name = filepath.ToSlash(fileName)
+		if fileInfo.IsDir() {
+			appZip.dirs[name] = fileInfo
+			return
+		}
+
+		err = appZip.writeParentDirs(name)
 		if err != nil {
-			return err
+			return
 		}
+		return appZip.writeEntry(name, fullPath, fileInfo)
+	})
+}
 
-		zipFilePart, err := writer.CreateHeader(header)
-		err = fileutils.CopyPathToWriter(fullPath, zipFilePart)
+func (appZip *appZipWriter) writeParentDirs(name string) (err error) {
+	parent := path.Dir(name)
+	if parent == "." || appZip.writtenDirs[parent] {
 		return
-	})
+	}
+
+	err = appZip.writeParentDirs(parent)
+	if err != nil {
+		return
+	}
 
+	appZip.writtenDirs[parent] = true
+	return appZip.writeEntry(parent, "", appZip.dirs[parent])
+}
+
+// writeEntry stores the file, directory or symlink described by fileInfo,
+// keeping its mode unless the options say otherwise. A symlink's content is
+// the path it points to, which is how zip tools store them.
+func (appZip *appZipWriter) writeEntry(name string, fullPath string, fileInfo os.FileInfo) (err error) {
+	writer := appZip.writer
+	header, err := zip.FileInfoHeader(fileInfo)
+	if err != nil {
+		return
+	}
+	header.Name = name
+
+	switch {
+	case fileInfo.IsDir():
+		header.Name += "/"
+		header.Method = zip.Store
+		_, err = writer.CreateHeader(header)
+	case fileInfo.Mode()&os.ModeSymlink != 0:
+		var target string
+		target, err = os.Readlink(fullPath)
+		if err != nil {
+			return
+		}
+
+		header.Method = zip.Store
+		var zipFilePart io.Writer
+		zipFilePart, err = writer.CreateHeader(header)
+		if err != nil {
+			return
+		}
+		_, err = io.WriteString(zipFilePart, filepath.ToSlash(target))
+	default:
+		if appZip.options.NormalizeFileModes {
+			header.SetMode(fileutils.NewFileMode() | fileInfo.Mode()&0111)
+		}
+
+		var zipFilePart io.Writer
+		zipFilePart, err = writer.CreateHeader(header)
+		if err != nil {
+			return
+		}
+		err = fileutils.CopyPathToWriter(fullPath, zipFilePart)
+	}
 	return
 }
@@ -6,8 +6,10 @@ import (
 	"fileutils"
 	"github.com/stretchr/testify/assert"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -46,18 +48,24 @@ func TestZipWithDirectory(t *testing.T) {
 			return file.Name, string(buf.Bytes())
 		}
 
-		assert.Equal(t, len(reader.File), 3)
+		assert.Equal(t, len(reader.File), 5)
 
 		name, contents := readFileInZip(0)
 		assert.Equal(t, name, "foo.txt")
 		assert.Equal(t, contents, "This is a simple text file.")
 
-		name, contents = readFileInZip(1)
+		assert.Equal(t, reader.File[1].Name, "subDir/")
+		assert.True(t, reader.File[1].FileInfo().IsDir())
+
+		name, contents = readFileInZip(2)
 		assert.Equal(t, name, "subDir/bar.txt")
 		assert.Equal(t, contents, "I am in a subdirectory.")
-		assert.Equal(t, reader.File[1].FileInfo().Mode(), uint32(0666))
+		assert.Equal(t, reader.File[2].FileInfo().Mode(), uint32(0666))
 
-		name, contents = readFileInZip(2)
+		assert.Equal(t, reader.File[3].Name, "subDir/otherDir/")
+		assert.True(t, reader.File[3].FileInfo().IsDir())
+
+		name, contents = readFileInZip(4)
 		assert.Equal(t, name, "subDir/otherDir/file.txt")
 		assert.Equal(t, contents, "This file should be present.")
 	})
@@ -122,6 +130,90 @@ func TestZipWithEmptyDir(t *testing.T) {
 	})
 }
 
+func TestZipKeepsSymlinksAndExecutableBits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	withSymlinkedApp(t, func(dir string) {
+		entries := zipEntries(t, dir, ZipOptions{})
+
+		assert.Equal(t, entries["bin/run"].mode, os.FileMode(0755))
+		assert.Equal(t, entries["node_modules/.bin/run"].mode&os.ModeSymlink, os.ModeSymlink)
+		assert.Equal(t, entries["node_modules/.bin/run"].contents, "../../bin/run")
+		assert.Equal(t, entries["current"].mode&os.ModeSymlink, os.ModeSymlink)
+		assert.Equal(t, entries["current"].contents, "bin")
+		assert.Equal(t, entries["dangling"].contents, "missing")
+		assert.True(t, entries["node_modules/"].mode.IsDir())
+		assert.Equal(t, len(entries), 7)
+	})
+}
+
+func TestZipDereferencingSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	withSymlinkedApp(t, func(dir string) {
+		err := os.Symlink("..", filepath.Join(dir, "bin", "parent"))
+		assert.NoError(t, err)
+
+		entries := zipEntries(t, dir, ZipOptions{DereferenceSymlinks: true})
+
+		assert.Equal(t, entries["node_modules/.bin/run"].mode, os.FileMode(0755))
+		assert.Equal(t, entries["node_modules/.bin/run"].contents, "#!/bin/sh")
+		assert.True(t, entries["current/"].mode.IsDir())
+		assert.Equal(t, entries["current/run"].contents, "#!/bin/sh")
+		assert.Equal(t, entries["dangling"].mode&os.ModeSymlink, os.ModeSymlink)
+
+		// a link back to the app is not followed into itself again
+		_, found := entries["bin/parent/bin/run"]
+		assert.False(t, found)
+	})
+}
+
+type zipEntry struct {
+	mode     os.FileMode
+	contents string
+}
+
+func withSymlinkedApp(t *testing.T, cb func(dir string)) {
+	fileutils.TempDir("zip_test", func(dir string, err error) {
+		assert.NoError(t, err)
+
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0755))
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "node_modules", ".bin"), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bin", "run"), []byte("#!/bin/sh"), 0755))
+		assert.NoError(t, os.Chmod(filepath.Join(dir, "bin", "run"), 0755))
+		assert.NoError(t, os.Symlink("../../bin/run", filepath.Join(dir, "node_modules", ".bin", "run")))
+		assert.NoError(t, os.Symlink("bin", filepath.Join(dir, "current")))
+		assert.NoError(t, os.Symlink("missing", filepath.Join(dir, "dangling")))
+
+		cb(dir)
+	})
+}
+
+func zipEntries(t *testing.T, dir string, options ZipOptions) (entries map[string]zipEntry) {
+	buffer := &bytes.Buffer{}
+	err := ApplicationZipper{}.WriteZip(dir, buffer, options)
+	assert.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert.NoError(t, err)
+
+	entries = map[string]zipEntry{}
+	for _, file := range reader.File {
+		contents := &bytes.Buffer{}
+		fileReader, err := file.Open()
+		assert.NoError(t, err)
+		io.Copy(contents, fileReader)
+		fileReader.Close()
+
+		entries[file.Name] = zipEntry{mode: file.Mode(), contents: contents.String()}
+	}
+	return
+}
+
 func fileToString(t *testing.T, file *os.File) string {
 	bytesBuf := &bytes.Buffer{}
 	_, err := io.Copy(bytesBuf, file)
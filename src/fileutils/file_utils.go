@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
 func OpenFile(path string) (file *os.File, err error) {
@@ -105,3 +106,27 @@ func SetExecutableBitsWithPaths(dest string, src string) (err error) {
 
 	return SetExecutableBits(dest, fileToCopyInfo)
 }
+
+var (
+	newFileModeOnce sync.Once
+	newFileMode     os.FileMode = 0644
+)
+
+// NewFileMode is the mode a file gets when it is created here, which is 0666
+// less the umask on unix.
+func NewFileMode() os.FileMode {
+	newFileModeOnce.Do(func() {
+		TempFile("file_mode", func(file *os.File, err error) {
+			if err != nil {
+				return
+			}
+
+			fileInfo, err := file.Stat()
+			if err != nil {
+				return
+			}
+			newFileMode = fileInfo.Mode().Perm()
+		})
+	})
+	return newFileMode
+}
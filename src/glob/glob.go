@@ -17,6 +17,7 @@ type Glob struct {
 	Pattern string         // original glob pattern
 	s       string         // translated to regexp pattern
 	r       *regexp.Regexp // compiled regexp
+	literal bool           // paths are matched without converting backslashes
 }
 
 const charPat = `[^/]`
@@ -76,7 +77,7 @@ func CompileGlob(pat string) (glob Glob, err error) {
 	if err != nil {
 		return
 	}
-	glob = Glob{pat, s, r}
+	glob = Glob{Pattern: pat, s: s, r: r}
 	return
 }
 
@@ -90,7 +91,110 @@ func MustCompileGlob(pat string) Glob {
 	return g
 }
 
+// CompileGitignoreGlob compiles a pattern written in .gitignore notation.
+// On top of the notation above, `\` makes the next char match itself and
+// `[...]` matches a single char of a class, or outside of it with `[!...]`.
+// A leading `**/` matches in every directory, a trailing `/**` matches
+// everything inside a directory and `/**/` matches zero or more
+// directories; any other `**` works like `*`. Unlike CompileGlob,
+// backslashes are never taken as path separators.
+func CompileGitignoreGlob(pat string) (glob Glob, err error) {
+	s, err := translateGitignoreGlob(pat)
+	if err != nil {
+		return
+	}
+	r, err := regexp.Compile(s)
+	if err != nil {
+		return
+	}
+	glob = Glob{Pattern: pat, s: s, r: r, literal: true}
+	return
+}
+
+func translateGitignoreGlob(pat string) (string, error) {
+	chars := []rune(pat)
+	out := ""
+
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(chars) {
+				return "", GlobError(pat)
+			}
+			i++
+			out += regexp.QuoteMeta(string(chars[i]))
+		case c == '[':
+			class, length := translateCharClass(chars[i:])
+			if length == 0 {
+				out += `\[`
+				continue
+			}
+			out += class
+			i += length - 1
+		case c == '?':
+			out += `[^/]`
+		case c == '*' && hasRunesAt(chars, i, "**/") && i == 0:
+			out += `(.*/)?`
+			i += 2
+		case c == '*' && hasRunesAt(chars, i, "**") && i+2 == len(chars) && i > 0 && chars[i-1] == '/':
+			out += `.*`
+			i++
+		case c == '*':
+			for i+1 < len(chars) && chars[i+1] == '*' {
+				i++
+			}
+			out += `[^/]*`
+		case c == '/' && hasRunesAt(chars, i, "/**/"):
+			out += `(/.*)?/`
+			i += 3
+		default:
+			out += regexp.QuoteMeta(string(c))
+		}
+	}
+
+	return "^" + out + "$", nil
+}
+
+// translateCharClass turns the class starting chars into a regexp class,
+// returning how many chars it took, or 0 when the class is not closed.
+func translateCharClass(chars []rune) (class string, length int) {
+	i := 1
+	negated := false
+	if i < len(chars) && (chars[i] == '!' || chars[i] == '^') {
+		negated = true
+		i++
+	}
+
+	members := ""
+	for first := true; i < len(chars); i, first = i+1, false {
+		c := chars[i]
+		switch {
+		case c == ']' && !first:
+			if negated {
+				return "[^/" + members + "]", i + 1
+			}
+			return "[" + members + "]", i + 1
+		case c == '\\' && i+1 < len(chars):
+			i++
+			members += `\` + string(chars[i])
+		case c == '\\' || c == '[' || c == ']' || c == '^':
+			members += `\` + string(c)
+		default:
+			members += string(c)
+		}
+	}
+	return
+}
+
+func hasRunesAt(chars []rune, i int, s string) bool {
+	return strings.HasPrefix(string(chars[i:]), s)
+}
+
 func (g Glob) Match(path string) bool {
+	if g.literal {
+		return g.r.MatchString(path)
+	}
 	return g.r.MatchString(toSlash(path))
 }
 
@@ -71,3 +71,60 @@ func TestGlobNonMatches(t *testing.T) {
 		}
 	}
 }
+
+var gitignoreMatches = [][]string{
+	{"*.log", "a.log", ".log"},
+	{"**/foo", "foo", "a/foo", "a/b/foo"},
+	{"a/**", "a/b", "a/b/c"},
+	{"a/**/b", "a/b", "a/x/b", "a/x/y/b"},
+	{"a**b", "ab", "axb"},
+	{"file[0-9].txt", "file1.txt"},
+	{"file[!0-9].txt", "filea.txt"},
+	{`\#notes`, "#notes"},
+	{`\!important`, "!important"},
+	{`star\*`, "star*"},
+	{`a\ `, "a "},
+	{"a[b", "a[b"},
+	{"a+b(c)", "a+b(c)"},
+}
+
+var gitignoreNonMatches = [][]string{
+	{"*.log", "a/b.log", "a.logs"},
+	{"**/foo", "foo/bar", "afoo"},
+	{"a/**", "a", "b/a/c"},
+	{"a/**/b", "a/bb", "ab"},
+	{"a**b", "a/b", "a/x/b"},
+	{"file[0-9].txt", "filea.txt", "file10.txt"},
+	{"file[!0-9].txt", "file1.txt", "file/.txt"},
+	{`star\*`, "starry"},
+	{`a\b`, `a\b`, "a/b"},
+}
+
+func TestGitignoreGlobMatches(t *testing.T) {
+	for _, parts := range gitignoreMatches {
+		pat, paths := parts[0], parts[1:]
+		glob, err := CompileGitignoreGlob(pat)
+
+		assert.NoError(t, err)
+		for _, path := range paths {
+			assert.True(t, glob.Match(path), "path %q should match %q", path, pat)
+		}
+	}
+}
+
+func TestGitignoreGlobNonMatches(t *testing.T) {
+	for _, parts := range gitignoreNonMatches {
+		pat, paths := parts[0], parts[1:]
+		glob, err := CompileGitignoreGlob(pat)
+
+		assert.NoError(t, err)
+		for _, path := range paths {
+			assert.False(t, glob.Match(path), "path %q should not match %q", path, pat)
+		}
+	}
+}
+
+func TestGitignoreGlobWithATrailingBackslash(t *testing.T) {
+	_, err := CompileGitignoreGlob(`foo\`)
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"cf/app"
 	"cf/commands"
 	"cf/configuration"
+	"cf/crash"
 	"cf/manifest"
 	"cf/net"
 	"cf/requirements"
@@ -20,11 +21,13 @@ import (
 )
 
 func main() {
+	var config *configuration.Configuration
+
 	defer func() {
 		maybeSomething := recover()
 
 		if maybeSomething != nil {
-			displayCrashDialog()
+			displayCrashDialog(maybeSomething, config)
 		}
 	}()
 
@@ -34,25 +37,31 @@ func main() {
 		os.Setenv("CF_COLOR", "true")
 	}
 
+	if !terminal.StdinIsTerminal() {
+		terminal.EnableNonInteractive()
+	}
+
 	termUI := terminal.NewUI()
 	configRepo := configuration.NewConfigurationDiskRepository()
-	config := loadConfig(termUI, configRepo)
+	config = loadConfig(termUI, configRepo)
 	manifestRepo := manifest.NewManifestDiskRepository()
+	crashRepo := crash.NewCrashReportDiskRepository()
 	repoLocator := api.NewRepositoryLocator(config, configRepo, map[string]net.Gateway{
 		"auth":             net.NewUAAGateway(),
 		"cloud-controller": net.NewCloudControllerGateway(),
 		"uaa":              net.NewUAAGateway(),
 	})
 
-	cmdFactory := commands.NewFactory(termUI, config, configRepo, manifestRepo, repoLocator)
+	cmdFactory := commands.NewFactory(termUI, config, configRepo, manifestRepo, crashRepo, repoLocator)
 	reqFactory := requirements.NewFactory(termUI, config, repoLocator)
-	cmdRunner := commands.NewRunner(cmdFactory, reqFactory)
+	cmdRunner := commands.NewRunner(termUI, cmdFactory, reqFactory)
 
 	app, err := app.NewApp(cmdRunner)
 	if err != nil {
 		return
 	}
 	app.Run(os.Args)
+	os.Exit(cmdRunner.ExitCode())
 }
 
 func init() {
@@ -96,19 +105,45 @@ OPTIONS:
 func loadConfig(termUI terminal.UI, configRepo configuration.ConfigurationRepository) (config *configuration.Configuration) {
 	config, err := configRepo.Get()
 	if err != nil {
-		termUI.Failed(fmt.Sprintf(
+		configRepo.Delete()
+		failure := termUI.Failed(fmt.Sprintf(
 			"Error loading config. Please reset target (%s) and log in (%s).",
 			terminal.CommandColor(fmt.Sprintf("%s target", cf.Name())),
 			terminal.CommandColor(fmt.Sprintf("%s login", cf.Name())),
 		))
-		configRepo.Delete()
-		os.Exit(1)
-		return
+		os.Exit(failure.ExitCode())
 	}
 	return
 }
 
-func displayCrashDialog() {
+func displayCrashDialog(maybeSomething interface{}, config *configuration.Configuration) {
+	stackTrace := string(debug.Stack())
+	report := crash.NewReport(maybeSomething, stackTrace, os.Args, config)
+
+	reportPath, err := crash.NewCrashReportDiskRepository().Save(report)
+	if err != nil {
+		displayCrashDialogWithStackTrace(report, err)
+		return
+	}
+
+	formattedString := `
+
+%s
+
+Something completely unexpected happened. This is a bug in %s.
+Please file this bug : https://github.com/cloudfoundry/cli/issues
+and attach the crash report written to:
+
+	%s
+
+Use '%s' to review it and to redact anything you do not want to share.
+	`
+
+	println(fmt.Sprintf(formattedString, awwShucks(), cf.Name(), reportPath, fmt.Sprintf("%s crash-reports", cf.Name())))
+	os.Exit(1)
+}
+
+func displayCrashDialogWithStackTrace(report crash.Report, saveErr error) {
 	formattedString := `
 
 %s
@@ -122,10 +157,12 @@ Tell us that you ran this command:
 and got this stack trace:
 
 %s
+
+The crash report could not be saved: %s
 	`
 
-	stackTrace := "\t" + strings.Replace(string(debug.Stack()), "\n", "\n\t", -1)
-	println(fmt.Sprintf(formattedString, awwShucks(), cf.Name(), strings.Join(os.Args, " "), stackTrace))
+	stackTrace := "\t" + strings.Replace(report.StackTrace, "\n", "\n\t", -1)
+	println(fmt.Sprintf(formattedString, awwShucks(), cf.Name(), report.CommandLine, stackTrace, saveErr.Error()))
 	os.Exit(1)
 }
 
@@ -1,20 +1,29 @@
 package api
 
 import (
+	"cf"
 	"cf/net"
+	"io"
+	"strings"
 )
 
 type FakeApplicationBitsRepository struct {
-	UploadedAppGuid string
-	UploadedDir     string
-	UploadAppErr    bool
+	UploadedAppGuid    string
+	UploadedDir        string
+	UploadedZipOptions cf.ZipOptions
+	UploadAppErr       bool
 
 	CallbackZipSize   uint64
 	CallbackFileCount uint64
+
+	DownloadedAppGuid string
+	DownloadContents  string
+	DownloadAppErr    bool
 }
 
-func (repo *FakeApplicationBitsRepository) UploadApp(appGuid, dir string, cb func(zipSize, fileCount uint64)) (apiResponse net.ApiResponse) {
+func (repo *FakeApplicationBitsRepository) UploadApp(appGuid, dir string, options cf.ZipOptions, progress net.ProgressReporter, cb func(zipSize, fileCount uint64)) (apiResponse net.ApiResponse) {
 	repo.UploadedDir = dir
+	repo.UploadedZipOptions = options
 	repo.UploadedAppGuid = appGuid
 
 	if repo.UploadAppErr {
@@ -26,3 +35,15 @@ func (repo *FakeApplicationBitsRepository) UploadApp(appGuid, dir string, cb fun
 
 	return
 }
+
+func (repo *FakeApplicationBitsRepository) DownloadApp(appGuid string, destination io.Writer) (apiResponse net.ApiResponse) {
+	repo.DownloadedAppGuid = appGuid
+
+	if repo.DownloadAppErr {
+		apiResponse = net.NewApiResponseWithMessage("Error downloading app")
+		return
+	}
+
+	io.Copy(destination, strings.NewReader(repo.DownloadContents))
+	return
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"cf"
+	"cf/net"
+)
+
+type FakeAppEnvRepo struct {
+	AppGuid     string
+	Env         cf.AppEnvironment
+	ApiResponse net.ApiResponse
+}
+
+func (repo *FakeAppEnvRepo) GetEnv(appGuid string) (env cf.AppEnvironment, apiResponse net.ApiResponse) {
+	repo.AppGuid = appGuid
+	return repo.Env, repo.ApiResponse
+}
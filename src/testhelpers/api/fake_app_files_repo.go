@@ -2,19 +2,70 @@ package api
 
 import (
 	"cf/net"
+	"io"
 )
 
 type FakeAppFilesRepo struct {
 	AppGuid  string
+	Instance int
 	Path     string
 	FileList string
+
+	Listings        map[string]string
+	Files           map[string][]byte
+	DownloadedPaths []string
+
+	TailChunks  []string
+	TailOffsets []int64
+}
+
+func (repo *FakeAppFilesRepo) ListFiles(appGuid string, instance int, path string) (files string, apiResponse net.ApiResponse) {
+	repo.AppGuid = appGuid
+	repo.Instance = instance
+	repo.Path = path
+
+	if repo.Listings == nil {
+		files = repo.FileList
+		return
+	}
+
+	files, found := repo.Listings[path]
+	if !found {
+		apiResponse = net.NewNotFoundApiResponse("%s not found", path)
+	}
+	return
+}
+
+func (repo *FakeAppFilesRepo) DownloadFile(appGuid string, instance int, path string, destination io.Writer) (apiResponse net.ApiResponse) {
+	repo.AppGuid = appGuid
+	repo.Instance = instance
+	repo.DownloadedPaths = append(repo.DownloadedPaths, path)
+
+	contents, found := repo.Files[path]
+	if !found {
+		apiResponse = net.NewNotFoundApiResponse("%s not found", path)
+		return
+	}
+
+	destination.Write(contents)
+	return
 }
 
-func (repo *FakeAppFilesRepo) ListFiles(appGuid, path string) (files string, apiResponse net.ApiResponse) {
+func (repo *FakeAppFilesRepo) DownloadFileFrom(appGuid string, instance int, path string, offset int64, destination io.Writer) (newOffset int64, apiResponse net.ApiResponse) {
 	repo.AppGuid = appGuid
+	repo.Instance = instance
 	repo.Path = path
+	repo.TailOffsets = append(repo.TailOffsets, offset)
+
+	if len(repo.TailChunks) == 0 {
+		apiResponse = net.NewNotFoundApiResponse("%s not found", path)
+		return
+	}
 
-	files = repo.FileList
+	chunk := repo.TailChunks[0]
+	repo.TailChunks = repo.TailChunks[1:]
 
+	destination.Write([]byte(chunk))
+	newOffset = offset + int64(len(chunk))
 	return
 }
@@ -11,6 +11,11 @@ type FakeAppInstancesRepo struct {
 	GetInstancesAppGuid    string
 	GetInstancesResponses  [][]cf.AppInstanceFields
 	GetInstancesErrorCodes []string
+
+	DeleteInstanceAppGuid     string
+	DeleteInstanceIndex       int
+	DeleteInstanceIndexes     []int
+	DeleteInstanceApiResponse net.ApiResponse
 }
 
 func (repo *FakeAppInstancesRepo) GetInstances(appGuid string) (instances []cf.AppInstanceFields, apiResponse net.ApiResponse) {
@@ -32,3 +37,10 @@ func (repo *FakeAppInstancesRepo) GetInstances(appGuid string) (instances []cf.A
 
 	return
 }
+
+func (repo *FakeAppInstancesRepo) DeleteInstance(appGuid string, index int) (apiResponse net.ApiResponse) {
+	repo.DeleteInstanceAppGuid = appGuid
+	repo.DeleteInstanceIndex = index
+	repo.DeleteInstanceIndexes = append(repo.DeleteInstanceIndexes, index)
+	return repo.DeleteInstanceApiResponse
+}
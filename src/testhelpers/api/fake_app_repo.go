@@ -9,11 +9,12 @@ import (
 type FakeApplicationRepository struct {
 	FindAllApps []cf.Application
 
-	ReadName     string
-	ReadApp      cf.Application
-	ReadErr      bool
-	ReadAuthErr  bool
-	ReadNotFound bool
+	ReadName       string
+	ReadApp        cf.Application
+	ReadAppsByName map[string]cf.Application
+	ReadErr        bool
+	ReadAuthErr    bool
+	ReadNotFound   bool
 
 	CreateAppParams []cf.AppParams
 
@@ -29,6 +30,11 @@ func (repo *FakeApplicationRepository) Read(name string) (app cf.Application, ap
 	repo.ReadName = name
 	app = repo.ReadApp
 
+	namedApp, found := repo.ReadAppsByName[name]
+	if found {
+		app = namedApp
+	}
+
 	if repo.ReadErr {
 		apiResponse = net.NewApiResponseWithMessage("Error finding app by name.")
 	}
@@ -12,6 +12,7 @@ type FakeAppSummaryRepo struct {
 	GetSummaryErrorCode string
 	GetSummaryAppGuid   string
 	GetSummarySummary   cf.AppSummary
+	GetSummariesByGuid  map[string]cf.AppSummary
 }
 
 func (repo *FakeAppSummaryRepo) GetSummariesInCurrentSpace() (apps []cf.AppSummary, apiResponse net.ApiResponse) {
@@ -23,6 +24,11 @@ func (repo *FakeAppSummaryRepo) GetSummary(appGuid string) (summary cf.AppSummar
 	repo.GetSummaryAppGuid = appGuid
 	summary = repo.GetSummarySummary
 
+	guidSummary, found := repo.GetSummariesByGuid[appGuid]
+	if found {
+		summary = guidSummary
+	}
+
 	if repo.GetSummaryErrorCode != "" {
 		apiResponse = net.NewApiResponse("Error", repo.GetSummaryErrorCode, http.StatusBadRequest)
 	}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"cf"
+	"cf/api"
+	"cf/net"
+)
+
+// FakeAuditEventsRepo returns one entry of EventPages per call and fails once
+// they run out, which ends a --follow loop.
+type FakeAuditEventsRepo struct {
+	Filters    []api.AuditEventFilter
+	EventPages [][]cf.AuditEventFields
+}
+
+func (repo *FakeAuditEventsRepo) ListAuditEvents(filter api.AuditEventFilter) (eventChan chan []cf.AuditEventFields, statusChan chan net.ApiResponse) {
+	repo.Filters = append(repo.Filters, filter)
+
+	eventChan = make(chan []cf.AuditEventFields, 4)
+	statusChan = make(chan net.ApiResponse, 1)
+
+	if len(repo.EventPages) == 0 {
+		statusChan <- net.NewApiResponseWithMessage("No more events")
+		close(eventChan)
+		close(statusChan)
+		return
+	}
+
+	events := repo.EventPages[0]
+	repo.EventPages = repo.EventPages[1:]
+
+	go func() {
+		if len(events) > 0 {
+			eventChan <- events
+		}
+		close(eventChan)
+		close(statusChan)
+	}()
+
+	return
+}
@@ -11,7 +11,7 @@ type FakeBuildpackBitsRepository struct {
 	UploadBuildpackPath        string
 }
 
-func (repo *FakeBuildpackBitsRepository) UploadBuildpack(buildpack cf.Buildpack, dir string) net.ApiResponse {
+func (repo *FakeBuildpackBitsRepository) UploadBuildpack(buildpack cf.Buildpack, dir string, progress net.ProgressReporter) net.ApiResponse {
 	if repo.UploadBuildpackErr {
 		return net.NewApiResponseWithMessage("Invalid buildpack")
 	}
@@ -19,7 +19,8 @@ type FakeDomainRepository struct {
 	FindByNameInOrgDomain      cf.Domain
 	FindByNameInOrgApiResponse net.ApiResponse
 
-	FindByNameInCurrentSpaceName string
+	FindByNameInCurrentSpaceName    string
+	FindByNameInCurrentSpaceDomains map[string]cf.Domain
 
 	FindByNameName     string
 	FindByNameDomain   cf.Domain
@@ -68,6 +69,15 @@ func (repo *FakeDomainRepository) FindByNameInCurrentSpace(name string) (domain
 	repo.FindByNameInCurrentSpaceName = name
 	domain = repo.FindByNameDomain
 
+	if repo.FindByNameInCurrentSpaceDomains != nil {
+		var found bool
+		domain, found = repo.FindByNameInCurrentSpaceDomains[name]
+		if !found {
+			apiResponse = net.NewNotFoundApiResponse("%s %s not found", "Domain", name)
+		}
+		return
+	}
+
 	if repo.FindByNameNotFound {
 		apiResponse = net.NewNotFoundApiResponse("%s %s not found", "Domain", name)
 	}
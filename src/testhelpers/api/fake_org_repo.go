@@ -16,6 +16,10 @@ type FakeOrgRepository struct {
 	FindByNameNotFound     bool
 	FindByNameOrganization cf.Organization
 
+	CountResourcesOrgGuid string
+	CountResourcesCounts  cf.OrganizationResourceCounts
+	CountResourcesErr     bool
+
 	RenameOrganizationGuid string
 	RenameNewName          string
 
@@ -65,6 +69,16 @@ func (repo *FakeOrgRepository) FindByName(name string) (org cf.Organization, api
 	return
 }
 
+func (repo *FakeOrgRepository) CountResources(orgGuid string) (counts cf.OrganizationResourceCounts, apiResponse net.ApiResponse) {
+	repo.CountResourcesOrgGuid = orgGuid
+	counts = repo.CountResourcesCounts
+
+	if repo.CountResourcesErr {
+		apiResponse = net.NewApiResponseWithMessage("Error counting resources.")
+	}
+	return
+}
+
 func (repo *FakeOrgRepository) Create(name string) (apiResponse net.ApiResponse) {
 	if repo.CreateOrgExists {
 		apiResponse = net.NewApiResponse("Space already exists", cf.ORG_EXISTS, 400)
@@ -15,9 +15,12 @@ type FakeRouteRepository struct {
 	FindByHostAndDomainRoute    cf.Route
 	FindByHostAndDomainErr      bool
 	FindByHostAndDomainNotFound bool
+	FindByHostAndDomainRoutes   map[string]cf.Route
 
-	CreatedHost       string
-	CreatedDomainGuid string
+	CreatedHost        string
+	CreatedDomainGuid  string
+	CreatedHosts       []string
+	CreatedDomainGuids []string
 
 	CreateInSpaceHost         string
 	CreateInSpaceDomainGuid   string
@@ -25,11 +28,13 @@ type FakeRouteRepository struct {
 	CreateInSpaceCreatedRoute cf.Route
 	CreateInSpaceErr          bool
 
-	BoundRouteGuid string
-	BoundAppGuid   string
+	BoundRouteGuid  string
+	BoundAppGuid    string
+	BoundRouteGuids []string
 
-	UnboundRouteGuid string
-	UnboundAppGuid   string
+	UnboundRouteGuid  string
+	UnboundAppGuid    string
+	UnboundRouteGuids []string
 
 	ListErr bool
 	Routes  []cf.Route
@@ -96,12 +101,22 @@ func (repo *FakeRouteRepository) FindByHostAndDomain(host, domain string) (route
 	}
 
 	route = repo.FindByHostAndDomainRoute
+
+	if repo.FindByHostAndDomainRoutes != nil {
+		var found bool
+		route, found = repo.FindByHostAndDomainRoutes[host+"."+domain]
+		if !found {
+			apiResponse = net.NewNotFoundApiResponse("%s %s.%s not found", "Route", host, domain)
+		}
+	}
 	return
 }
 
 func (repo *FakeRouteRepository) Create(host, domainGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse) {
 	repo.CreatedHost = host
 	repo.CreatedDomainGuid = domainGuid
+	repo.CreatedHosts = append(repo.CreatedHosts, host)
+	repo.CreatedDomainGuids = append(repo.CreatedDomainGuids, domainGuid)
 
 	createdRoute.Guid = host + "-route-guid"
 
@@ -125,12 +140,14 @@ func (repo *FakeRouteRepository) CreateInSpace(host, domainGuid, spaceGuid strin
 func (repo *FakeRouteRepository) Bind(routeGuid, appGuid string) (apiResponse net.ApiResponse) {
 	repo.BoundRouteGuid = routeGuid
 	repo.BoundAppGuid = appGuid
+	repo.BoundRouteGuids = append(repo.BoundRouteGuids, routeGuid)
 	return
 }
 
 func (repo *FakeRouteRepository) Unbind(routeGuid, appGuid string) (apiResponse net.ApiResponse) {
 	repo.UnboundRouteGuid = routeGuid
 	repo.UnboundAppGuid = appGuid
+	repo.UnboundRouteGuids = append(repo.UnboundRouteGuids, routeGuid)
 	return
 }
 
@@ -20,6 +20,9 @@ type FakeServiceRepo struct {
 
 	FindInstanceByNameMap generic.Map
 
+	// instances named here are not found until CreateServiceInstance creates them
+	FindInstanceByNameMissing []string
+
 	DeleteServiceServiceInstance cf.ServiceInstance
 
 	RenameServiceServiceInstance cf.ServiceInstance
@@ -36,6 +39,14 @@ func (repo *FakeServiceRepo) CreateServiceInstance(name, planGuid string) (ident
 	repo.CreateServiceInstancePlanGuid = planGuid
 	identicalAlreadyExists = repo.CreateServiceAlreadyExists
 
+	missing := []string{}
+	for _, missingName := range repo.FindInstanceByNameMissing {
+		if missingName != name {
+			missing = append(missing, missingName)
+		}
+	}
+	repo.FindInstanceByNameMissing = missing
+
 	return
 }
 
@@ -52,6 +63,12 @@ func (repo *FakeServiceRepo) FindInstanceByName(name string) (instance cf.Servic
 		apiResponse = net.NewApiResponseWithMessage("Error finding instance")
 	}
 
+	for _, missingName := range repo.FindInstanceByNameMissing {
+		if missingName == name {
+			apiResponse = net.NewNotFoundApiResponse("%s %s not found", "Service instance", name)
+		}
+	}
+
 	if repo.FindInstanceByNameNotFound {
 		apiResponse = net.NewNotFoundApiResponse("%s %s not found", "Service instance", name)
 	}
@@ -42,7 +42,7 @@ func NewContext(cmdName string, args []string) *cli.Context {
 func findCommand(cmdName string) (cmd cli.Command) {
 	cmdFactory := commands.ConcreteFactory{}
 	reqFactory := &testreq.FakeReqFactory{}
-	cmdRunner := commands.NewRunner(cmdFactory, reqFactory)
+	cmdRunner := commands.NewRunner(nil, cmdFactory, reqFactory)
 	myApp, _ := app.NewApp(cmdRunner)
 
 	for _, cmd := range myApp.Commands {
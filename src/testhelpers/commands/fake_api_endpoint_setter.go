@@ -1,10 +1,14 @@
 package commands
 
+import (
+	"cf/errors"
+)
+
 type FakeApiEndpointSetter struct {
 	SetEndpoint string
 }
 
-func (setter *FakeApiEndpointSetter) SetApiEndpoint(endpoint string) {
+func (setter *FakeApiEndpointSetter) SetApiEndpoint(endpoint string) (err errors.Error) {
 	setter.SetEndpoint = endpoint
 	return
 }
@@ -2,12 +2,14 @@ package commands
 
 import (
 	"cf"
+	"cf/errors"
 )
 
 type FakeAppDisplayer struct {
 	AppToDisplay cf.Application
 }
 
-func (displayer *FakeAppDisplayer) ShowApp(app cf.Application) {
+func (displayer *FakeAppDisplayer) ShowApp(app cf.Application) (err errors.Error) {
 	displayer.AppToDisplay = app
+	return
 }
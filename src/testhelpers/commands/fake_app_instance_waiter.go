@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"cf/errors"
+	"time"
+)
+
+type FakeAppInstanceWaiter struct {
+	AppGuid        string
+	PreviousSinces []map[int]time.Time
+
+	WaitErrors []errors.Error
+}
+
+func (waiter *FakeAppInstanceWaiter) WaitForInstancesRestart(appGuid string, previousSinces map[int]time.Time) (err errors.Error) {
+	waiter.AppGuid = appGuid
+	waiter.PreviousSinces = append(waiter.PreviousSinces, previousSinces)
+
+	if len(waiter.WaitErrors) > 0 {
+		err = waiter.WaitErrors[0]
+		waiter.WaitErrors = waiter.WaitErrors[1:]
+	}
+	return
+}
@@ -2,13 +2,30 @@ package commands
 
 import (
 	"cf"
+	"cf/errors"
+	"time"
 )
 
 type FakeAppRestarter struct {
 	AppToRestart cf.Application
+	RestartErr   errors.Error
+
+	AppToRollingRestart cf.Application
+	RollingBatchSize    int
+	RollingPause        time.Duration
+	RollingRestartErr   errors.Error
 }
 
-func (restarter *FakeAppRestarter) ApplicationRestart(appToRestart cf.Application) {
+func (restarter *FakeAppRestarter) ApplicationRestart(appToRestart cf.Application) (err errors.Error) {
 	restarter.AppToRestart = appToRestart
+	err = restarter.RestartErr
+	return
+}
+
+func (restarter *FakeAppRestarter) ApplicationRollingRestart(appToRestart cf.Application, batchSize int, pause time.Duration) (err errors.Error) {
+	restarter.AppToRollingRestart = appToRestart
+	restarter.RollingBatchSize = batchSize
+	restarter.RollingPause = pause
+	err = restarter.RollingRestartErr
 	return
 }
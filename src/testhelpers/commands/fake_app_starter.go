@@ -2,6 +2,7 @@ package commands
 
 import (
 	"cf"
+	"cf/errors"
 )
 
 type FakeAppStarter struct {
@@ -9,7 +10,7 @@ type FakeAppStarter struct {
 	Timeout    int
 }
 
-func (starter *FakeAppStarter) ApplicationStart(appToStart cf.Application) (startedApp cf.Application, err error) {
+func (starter *FakeAppStarter) ApplicationStart(appToStart cf.Application) (startedApp cf.Application, err errors.Error) {
 	starter.AppToStart = appToStart
 	startedApp = appToStart
 	return
@@ -19,7 +20,7 @@ func (starter *FakeAppStarter) SetStartTimeoutSeconds(timeout int) {
 	starter.Timeout = timeout
 }
 
-func (starter *FakeAppStarter) ApplicationStartWithBuildpack(app cf.Application, buildpackUrl string) (startedApp cf.Application, err error) {
+func (starter *FakeAppStarter) ApplicationStartWithBuildpack(app cf.Application, buildpackUrl string) (startedApp cf.Application, err errors.Error) {
 	starter.AppToStart = app
 	startedApp = app
 	return
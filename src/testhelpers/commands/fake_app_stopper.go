@@ -2,13 +2,14 @@ package commands
 
 import (
 	"cf"
+	"cf/errors"
 )
 
 type FakeAppStopper struct {
 	AppToStop cf.Application
 }
 
-func (stopper *FakeAppStopper) ApplicationStop(app cf.Application) (updatedApp cf.Application, err error) {
+func (stopper *FakeAppStopper) ApplicationStop(app cf.Application) (updatedApp cf.Application, err errors.Error) {
 	stopper.AppToStop = app
 	updatedApp = app
 	return
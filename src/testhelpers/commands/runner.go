@@ -2,14 +2,17 @@ package commands
 
 import (
 	"cf/commands"
+	"cf/errors"
 	"github.com/codegangsta/cli"
 	testreq "testhelpers/requirements"
 )
 
 var CommandDidPassRequirements bool
+var CommandRunError errors.Error
 
 func RunCommand(cmd commands.Command, ctxt *cli.Context, reqFactory *testreq.FakeReqFactory) {
 	CommandDidPassRequirements = false
+	CommandRunError = nil
 
 	reqs, err := cmd.GetRequirements(reqFactory, ctxt)
 	if err != nil {
@@ -17,13 +20,13 @@ func RunCommand(cmd commands.Command, ctxt *cli.Context, reqFactory *testreq.Fak
 	}
 
 	for _, req := range reqs {
-		success := req.Execute()
-		if !success {
+		err = req.Execute()
+		if err != nil {
 			return
 		}
 	}
 
-	cmd.Run(ctxt)
+	CommandRunError = cmd.Run(ctxt)
 	CommandDidPassRequirements = true
 
 	return
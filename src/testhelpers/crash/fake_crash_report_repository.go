@@ -0,0 +1,42 @@
+package crash
+
+import (
+	"cf/crash"
+)
+
+type FakeCrashReportRepository struct {
+	SavedReport crash.Report
+	SavePath    string
+	SaveErr     error
+
+	Reports []crash.ReportInfo
+	ListErr error
+
+	ReadName     string
+	ReadContents string
+	ReadErr      error
+
+	RedactedName    string
+	RedactedSecrets []string
+	RedactErr       error
+}
+
+func (repo *FakeCrashReportRepository) Save(report crash.Report) (path string, err error) {
+	repo.SavedReport = report
+	return repo.SavePath, repo.SaveErr
+}
+
+func (repo *FakeCrashReportRepository) List() (reports []crash.ReportInfo, err error) {
+	return repo.Reports, repo.ListErr
+}
+
+func (repo *FakeCrashReportRepository) Read(name string) (contents string, err error) {
+	repo.ReadName = name
+	return repo.ReadContents, repo.ReadErr
+}
+
+func (repo *FakeCrashReportRepository) Redact(name string, secrets []string) (err error) {
+	repo.RedactedName = name
+	repo.RedactedSecrets = secrets
+	return repo.RedactErr
+}
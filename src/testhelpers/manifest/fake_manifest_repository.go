@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"cf/manifest"
+	"os"
 )
 
 type FakeManifestRepository struct {
@@ -13,6 +14,16 @@ type FakeManifestRepository struct {
 	ManifestDir       string
 	ManifestFilename  string
 	ManifestPathErr   error
+
+	ValidateManifestPath     string
+	ValidateManifestProblems []manifest.ManifestProblem
+	ValidateManifestErr      error
+
+	WriteManifestPath      string
+	WriteManifestManifest  *manifest.AppManifest
+	WriteManifestOverwrite bool
+	WriteManifestExists    bool
+	WriteManifestErr       error
 }
 
 func (repo *FakeManifestRepository) ReadManifest(dir string) (m *manifest.Manifest, errs manifest.ManifestErrors) {
@@ -45,3 +56,23 @@ func (repo *FakeManifestRepository) ManifestPath(userSpecifiedPath string) (mani
 	err = repo.ManifestPathErr
 	return
 }
+
+func (repo *FakeManifestRepository) ValidateManifest(path string) (problems []manifest.ManifestProblem, err error) {
+	repo.ValidateManifestPath = path
+	problems = repo.ValidateManifestProblems
+	err = repo.ValidateManifestErr
+	return
+}
+
+func (repo *FakeManifestRepository) WriteManifest(path string, appManifest *manifest.AppManifest, overwrite bool) (err error) {
+	repo.WriteManifestPath = path
+	repo.WriteManifestOverwrite = overwrite
+	if repo.WriteManifestExists && !overwrite {
+		err = &os.PathError{Op: "open", Path: path, Err: os.ErrExist}
+		return
+	}
+
+	repo.WriteManifestManifest = appManifest
+	err = repo.WriteManifestErr
+	return
+}
@@ -0,0 +1,13 @@
+package net
+
+// FakeProgressReporter records the progress reported for an upload instead
+// of printing it.
+type FakeProgressReporter struct {
+	Sent  int64
+	Total int64
+}
+
+func (reporter *FakeProgressReporter) Update(sent, total int64) {
+	reporter.Sent = sent
+	reporter.Total = total
+}
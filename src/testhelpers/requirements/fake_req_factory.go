@@ -2,6 +2,7 @@ package requirements
 
 import (
 	"cf"
+	"cf/errors"
 	"cf/requirements"
 )
 
@@ -94,8 +95,11 @@ type FakeRequirement struct {
 	success bool
 }
 
-func (r FakeRequirement) Execute() (success bool) {
-	return r.success
+func (r FakeRequirement) Execute() (err errors.Error) {
+	if !r.success {
+		err = errors.New("Requirement failed")
+	}
+	return
 }
 
 func (r FakeRequirement) GetApplication() cf.Application {
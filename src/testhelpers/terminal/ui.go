@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"cf/configuration"
+	"cf/errors"
 	term "cf/terminal"
 	"fmt"
 	"github.com/codegangsta/cli"
@@ -16,18 +17,20 @@ type FakeUI struct {
 	Inputs                     []string
 	FailedWithUsage            bool
 	FailedWithUsageCommandName string
+	FailedWithError            errors.Error
 	ShowConfigurationCalled    bool
+	ClearScreenCount           int
 }
 
-func (ui *FakeUI) PrintPaginator(rows []string, err error) {
+func (ui *FakeUI) PrintPaginator(rows []string, err error) errors.Error {
 	if err != nil {
-		ui.Failed(err.Error())
-		return
+		return ui.Failed(err.Error())
 	}
 
 	for _, row := range rows {
 		ui.Say(row)
 	}
+	return nil
 }
 
 func (ui *FakeUI) Say(message string, args ...interface{}) {
@@ -68,20 +71,31 @@ func (ui *FakeUI) Ok() {
 	ui.Say("OK")
 }
 
-func (ui *FakeUI) Failed(message string, args ...interface{}) {
+func (ui *FakeUI) Failed(message string, args ...interface{}) errors.Error {
+	message = fmt.Sprintf(message, args...)
+	return ui.FailWithError(errors.New(message))
+}
+
+func (ui *FakeUI) FailWithError(err error) errors.Error {
+	cfErr, ok := err.(errors.Error)
+	if !ok {
+		cfErr = errors.New(err.Error())
+	}
+	ui.FailedWithError = cfErr
+
 	ui.Say("FAILED")
-	ui.Say(message, args...)
-	return
+	ui.Say("%s", cfErr.Error())
+	return cfErr
 }
 
-func (ui *FakeUI) ConfigFailure(err error) {
-	ui.Failed("Error loading config file.\n%s", err.Error())
+func (ui *FakeUI) ConfigFailure(err error) errors.Error {
+	return ui.Failed("Error loading config file.\n%s", err.Error())
 }
 
 func (ui *FakeUI) FailWithUsage(ctxt *cli.Context, cmdName string) {
 	ui.FailedWithUsage = true
 	ui.FailedWithUsageCommandName = cmdName
-	ui.Failed("Incorrect Usage.")
+	ui.FailWithError(errors.NewUsageError("Incorrect Usage."))
 }
 
 func (ui *FakeUI) DumpOutputs() string {
@@ -103,6 +117,10 @@ func (c FakeUI) Wait(duration time.Duration) {
 	time.Sleep(duration)
 }
 
+func (ui *FakeUI) ClearScreen() {
+	ui.ClearScreenCount++
+}
+
 func (ui *FakeUI) DisplayTable(table [][]string) {
 
 	for _, line := range table {